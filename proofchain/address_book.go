@@ -0,0 +1,173 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// WalletContact is a named recipient in the tenant address book
+type WalletContact struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Address     string                 `json:"address"`
+	Network     string                 `json:"network"`
+	Description *string                `json:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+}
+
+// AddContactRequest is the request to add an address book contact
+type AddContactRequest struct {
+	Name        string                 `json:"name"`
+	Address     string                 `json:"address"`
+	Network     string                 `json:"network"`
+	Description string                 `json:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TransferToContactRequest is the request to transfer to an address book contact
+type TransferToContactRequest struct {
+	FromAddress string `json:"from_address"`
+	Amount      string `json:"amount"`
+	Token       string `json:"token,omitempty"`
+}
+
+// AddContact adds a named recipient to the address book. The address is
+// checked against the contact's network before it is saved.
+func (w *WalletClient) AddContact(ctx context.Context, req *AddContactRequest) (*WalletContact, error) {
+	if err := validateContact(req); err != nil {
+		return nil, err
+	}
+
+	var contact WalletContact
+	err := w.http.Post(ctx, "/wallets/contacts", req, &contact)
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// ListContacts returns address book contacts, optionally filtered by network
+func (w *WalletClient) ListContacts(ctx context.Context, network string) ([]WalletContact, error) {
+	params := url.Values{}
+	if network != "" {
+		params.Set("network", network)
+	}
+
+	var contacts []WalletContact
+	err := w.http.Get(ctx, "/wallets/contacts", params, &contacts)
+	if err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// GetContact returns an address book contact
+func (w *WalletClient) GetContact(ctx context.Context, contactID string) (*WalletContact, error) {
+	var contact WalletContact
+	err := w.http.Get(ctx, "/wallets/contacts/"+contactID, nil, &contact)
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// DeleteContact removes a contact from the address book
+func (w *WalletClient) DeleteContact(ctx context.Context, contactID string) error {
+	return w.http.Delete(ctx, "/wallets/contacts/"+contactID)
+}
+
+// TransferToContact transfers tokens to an address book contact, using the
+// contact's stored address and network
+func (w *WalletClient) TransferToContact(ctx context.Context, contactID string, req *TransferToContactRequest) (*TransferResult, error) {
+	contact, err := w.GetContact(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAddress(contact.Network, contact.Address); err != nil {
+		return nil, err
+	}
+
+	return w.Transfer(ctx, &TransferRequest{
+		FromAddress: req.FromAddress,
+		ToAddress:   contact.Address,
+		Amount:      req.Amount,
+		Token:       req.Token,
+		Network:     contact.Network,
+	})
+}
+
+func validateContact(req *AddContactRequest) error {
+	var details []ValidationErrorDetail
+	if req.Name == "" {
+		details = append(details, ValidationErrorDetail{Field: "name", Message: "is required"})
+	}
+	if req.Network == "" {
+		details = append(details, ValidationErrorDetail{Field: "network", Message: "is required"})
+	}
+	if len(details) > 0 {
+		return NewValidationError("invalid contact", details)
+	}
+	return validateAddress(req.Network, req.Address)
+}
+
+// validateAddress checks that address is well-formed for network: base58
+// public keys on Solana, 0x-prefixed 20-byte hex addresses elsewhere. Mixed-
+// case hex addresses must carry a valid EIP-55 checksum, which catches most
+// typos; all-lowercase and all-uppercase addresses carry none.
+func validateAddress(network, address string) error {
+	var msg string
+	if strings.HasPrefix(strings.ToLower(network), "solana") {
+		if len(address) < 32 || len(address) > 44 || strings.Trim(address, base58Alphabet) != "" {
+			msg = "must be a base58 Solana address"
+		}
+	} else {
+		b, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+		if !strings.HasPrefix(address, "0x") || err != nil || len(b) != 20 {
+			msg = "must be a 0x-prefixed 40 character hex address"
+		} else if !validChecksum(address[2:]) {
+			msg = "has an invalid EIP-55 checksum; check the address for typos"
+		}
+	}
+	if msg != "" {
+		return NewValidationError("invalid address for network "+network, []ValidationErrorDetail{
+			{Field: "address", Message: msg},
+		})
+	}
+	return nil
+}
+
+// validChecksum reports whether the 40 hex digits in addr are all one case
+// or match their EIP-55 mixed-case checksum.
+func validChecksum(addr string) bool {
+	lower := strings.ToLower(addr)
+	if addr == lower || addr == strings.ToUpper(addr) {
+		return true
+	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower))
+	sum := h.Sum(nil)
+	for i := 0; i < len(lower); i++ {
+		c := lower[i]
+		if c < 'a' {
+			continue // Digits have no case
+		}
+		nibble := sum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = sum[i/2] & 0x0f
+		}
+		if (nibble >= 8) != (addr[i] != c) {
+			return false
+		}
+	}
+	return true
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
@@ -0,0 +1,26 @@
+package proofchain
+
+import "testing"
+
+func TestValidateAddressChecksum(t *testing.T) {
+	tests := []struct {
+		address string
+		valid   bool
+	}{
+		// EIP-55 test vectors
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", true},
+		{"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", true},
+		{"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", true},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true},
+		{"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", true},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", false},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", false},
+	}
+	for _, tt := range tests {
+		err := validateAddress("polygon", tt.address)
+		if (err == nil) != tt.valid {
+			t.Errorf("validateAddress(%q) = %v, want valid %v", tt.address, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// NormalizationStrategy controls how cohort scores are normalized before
+// they are weighted into a fanpass score.
+type NormalizationStrategy string
+
+const (
+	NormalizationPercentile NormalizationStrategy = "percentile"
+	NormalizationMinMax     NormalizationStrategy = "min_max"
+	NormalizationZScore     NormalizationStrategy = "z_score"
+	NormalizationNone       NormalizationStrategy = "none"
+)
+
+// AggregationRule is a composite fanpass scoring rule.
+type AggregationRule struct {
+	ID            string                `json:"id"`
+	Name          string                `json:"name"`
+	Description   *string               `json:"description,omitempty"`
+	CohortWeights map[string]float64    `json:"cohort_weights"` // cohort ID -> weight
+	Normalization NormalizationStrategy `json:"normalization"`
+	IsDefault     bool                  `json:"is_default"`
+	Status        string                `json:"status"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
+}
+
+// =============================================================================
+// Request types
+// =============================================================================
+
+// CreateAggregationRuleRequest creates an aggregation rule.
+type CreateAggregationRuleRequest struct {
+	Name          string                `json:"name"`
+	Description   string                `json:"description,omitempty"`
+	CohortWeights map[string]float64    `json:"cohort_weights"`
+	Normalization NormalizationStrategy `json:"normalization,omitempty"` // default percentile
+	IsDefault     bool                  `json:"is_default,omitempty"`
+}
+
+// UpdateAggregationRuleRequest updates an aggregation rule. Nil fields are
+// left unchanged; a non-nil CohortWeights replaces all weights.
+type UpdateAggregationRuleRequest struct {
+	Name          *string                `json:"name,omitempty"`
+	Description   *string                `json:"description,omitempty"`
+	CohortWeights map[string]float64     `json:"cohort_weights,omitempty"`
+	Normalization *NormalizationStrategy `json:"normalization,omitempty"`
+	IsDefault     *bool                  `json:"is_default,omitempty"`
+}
+
+// =============================================================================
+// Client
+// =============================================================================
+
+// AggregationRulesClient manages fanpass aggregation rules. It is available
+// as Client.Fanpass.AggregationRules.
+type AggregationRulesClient struct {
+	http *HTTPClient
+}
+
+// NewAggregationRulesClient creates a new aggregation rules client.
+func NewAggregationRulesClient(http *HTTPClient) *AggregationRulesClient {
+	return &AggregationRulesClient{http: http}
+}
+
+// List returns all aggregation rules.
+func (a *AggregationRulesClient) List(ctx context.Context) ([]AggregationRule, error) {
+	var rules []AggregationRule
+	err := a.http.Get(ctx, "/passport-v2/fanpass/aggregation-rules", nil, &rules)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Get returns an aggregation rule by ID.
+func (a *AggregationRulesClient) Get(ctx context.Context, ruleID string) (*AggregationRule, error) {
+	var rule AggregationRule
+	err := a.http.Get(ctx, "/passport-v2/fanpass/aggregation-rules/"+url.PathEscape(ruleID), nil, &rule)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Create creates an aggregation rule.
+func (a *AggregationRulesClient) Create(ctx context.Context, req *CreateAggregationRuleRequest) (*AggregationRule, error) {
+	if err := validateCohortWeights(req.CohortWeights, true); err != nil {
+		return nil, err
+	}
+
+	var rule AggregationRule
+	err := a.http.Post(ctx, "/passport-v2/fanpass/aggregation-rules", req, &rule)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Update updates an aggregation rule.
+func (a *AggregationRulesClient) Update(ctx context.Context, ruleID string, req *UpdateAggregationRuleRequest) (*AggregationRule, error) {
+	if err := validateCohortWeights(req.CohortWeights, false); err != nil {
+		return nil, err
+	}
+
+	var rule AggregationRule
+	err := a.http.Patch(ctx, "/passport-v2/fanpass/aggregation-rules/"+url.PathEscape(ruleID), req, &rule)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Delete deletes an aggregation rule.
+func (a *AggregationRulesClient) Delete(ctx context.Context, ruleID string) error {
+	return a.http.Delete(ctx, "/passport-v2/fanpass/aggregation-rules/"+url.PathEscape(ruleID))
+}
+
+func validateCohortWeights(weights map[string]float64, required bool) error {
+	if len(weights) == 0 {
+		if required {
+			return NewValidationError("cohort_weights is required", []ValidationErrorDetail{
+				{Field: "cohort_weights", Message: "at least one cohort weight is required"},
+			})
+		}
+		return nil
+	}
+	var details []ValidationErrorDetail
+	for cohortID, w := range weights {
+		if w < 0 {
+			details = append(details, ValidationErrorDetail{
+				Field:   "cohort_weights",
+				Message: fmt.Sprintf("weight for cohort %s must not be negative", cohortID),
+			})
+		}
+	}
+	if len(details) > 0 {
+		return NewValidationError("invalid cohort_weights", details)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package proofchain
+
+import (
+	"context"
+	"math/big"
+	"net/url"
+)
+
+// ApproveUnlimited can be passed as the amount to Approve to grant the
+// maximum ERC-20 allowance. The spender can then move any amount of the
+// token until the approval is revoked, so reserve it for trusted contracts.
+const ApproveUnlimited = "unlimited"
+
+// TokenAllowance is the amount of an ERC-20 token a spender may transfer
+// from a wallet
+type TokenAllowance struct {
+	WalletID     string `json:"wallet_id"`
+	Owner        string `json:"owner"`
+	Token        string `json:"token"`
+	TokenAddress string `json:"token_address"`
+	Spender      string `json:"spender"`
+	Network      string `json:"network"`
+	// Allowance is in token units, e.g. "1.5"; RawAllowance is in base units.
+	Allowance    string `json:"allowance"`
+	RawAllowance string `json:"raw_allowance"`
+	Unlimited    bool   `json:"unlimited"`
+}
+
+// ApproveOptions configures an ERC-20 approval
+type ApproveOptions struct {
+	// Network of the token; defaults to the wallet's network.
+	Network string
+	// Exact always sets the allowance to exactly the requested amount, even
+	// when the current allowance is already larger. Use it to lower an
+	// allowance. Without it, Approve sends no transaction when the current
+	// allowance already covers the amount.
+	Exact bool
+}
+
+// ApprovalResult is the outcome of Approve
+type ApprovalResult struct {
+	TxHash  string `json:"tx_hash,omitempty"` // Empty when no transaction was needed
+	Token   string `json:"token"`
+	Spender string `json:"spender"`
+	Amount  string `json:"amount"`
+	Network string `json:"network"`
+	Status  string `json:"status"`
+}
+
+// ApprovalStatusSufficient is the ApprovalResult status when the existing
+// allowance already covered the amount and no transaction was sent.
+const ApprovalStatusSufficient = "sufficient"
+
+// GetAllowance returns how much of token spender may transfer from the
+// wallet. token is a symbol or contract address.
+func (w *WalletClient) GetAllowance(ctx context.Context, walletID, token, spender string) (*TokenAllowance, error) {
+	return w.getAllowance(ctx, walletID, token, spender, "")
+}
+
+func (w *WalletClient) getAllowance(ctx context.Context, walletID, token, spender, network string) (*TokenAllowance, error) {
+	if err := validateAllowance(token, spender, network); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("token", token)
+	params.Set("spender", spender)
+	if network != "" {
+		params.Set("network", network)
+	}
+
+	var allowance TokenAllowance
+	err := w.http.Get(ctx, "/wallets/"+walletID+"/allowances", params, &allowance)
+	if err != nil {
+		return nil, err
+	}
+	return &allowance, nil
+}
+
+// Approve allows spender to transfer up to amount of token from the wallet,
+// for example a DEX router before a swap. amount is in token units, or
+// ApproveUnlimited. See ApproveOptions for when a transaction is sent.
+//
+// Example:
+//
+//	res, err := client.Wallets.Approve(ctx, walletID, "USDC", routerAddress, "250", nil)
+func (w *WalletClient) Approve(ctx context.Context, walletID, token, spender, amount string, opts *ApproveOptions) (*ApprovalResult, error) {
+	if opts == nil {
+		opts = &ApproveOptions{}
+	}
+	if err := validateAllowance(token, spender, opts.Network); err != nil {
+		return nil, err
+	}
+	unlimited := amount == ApproveUnlimited
+	requested, ok := new(big.Rat).SetString(amount)
+	if !unlimited && (!ok || requested.Sign() < 0) {
+		return nil, NewValidationError("invalid approval amount", []ValidationErrorDetail{
+			{Field: "amount", Message: "must be a non-negative decimal or ApproveUnlimited"},
+		})
+	}
+
+	if !opts.Exact {
+		current, err := w.getAllowance(ctx, walletID, token, spender, opts.Network)
+		if err != nil {
+			return nil, err
+		}
+		if allowanceCovers(current, requested, unlimited) {
+			return &ApprovalResult{
+				Token:   token,
+				Spender: spender,
+				Amount:  current.Allowance,
+				Network: current.Network,
+				Status:  ApprovalStatusSufficient,
+			}, nil
+		}
+	}
+
+	body := map[string]interface{}{
+		"token":   token,
+		"spender": spender,
+		"amount":  amount,
+	}
+	if opts.Network != "" {
+		body["network"] = opts.Network
+	}
+
+	var result ApprovalResult
+	err := w.http.Post(ctx, "/wallets/"+walletID+"/approve", body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// allowanceCovers reports whether current already permits the requested
+// amount.
+func allowanceCovers(current *TokenAllowance, requested *big.Rat, unlimited bool) bool {
+	if current.Unlimited {
+		return true
+	}
+	if unlimited {
+		return false
+	}
+	have, ok := new(big.Rat).SetString(current.Allowance)
+	return ok && have.Cmp(requested) >= 0
+}
+
+func validateAllowance(token, spender, network string) error {
+	if token == "" {
+		return NewValidationError("token is required", []ValidationErrorDetail{
+			{Field: "token", Message: "must be a token symbol or contract address"},
+		})
+	}
+	return validateAddress(network, spender)
+}
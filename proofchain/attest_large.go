@@ -0,0 +1,254 @@
+package proofchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLargeAttestThreshold   = 64 << 20
+	defaultLargeAttestPartSize    = 8 << 20
+	defaultLargeAttestConcurrency = 4
+	minLargeAttestPartSize        = 5 << 20
+	maxLargeAttestParts           = 10000
+	largeAttestPartRetries        = 3
+)
+
+// largeAttestRetryDelay is the base delay between part upload attempts.
+var largeAttestRetryDelay = time.Second
+
+// AttestLargeOptions configures AttestLarge.
+type AttestLargeOptions struct {
+	// Threshold is the file size at or above which the chunked protocol is
+	// used (default 64 MiB). Smaller files are sent with Attest.
+	Threshold int64
+	// PartSize is the size of each part (default 8 MiB, minimum 5 MiB). It
+	// is increased if the file would otherwise need more than 10000 parts.
+	PartSize int64
+	// Concurrency is the number of parts uploaded in parallel (default 4).
+	Concurrency int
+	// OnProgress, if set, is called after each part is uploaded with the
+	// number of bytes uploaded so far and the file size.
+	OnProgress func(uploaded, total int64)
+}
+
+// largeUpload is the server's response to starting a chunked upload.
+type largeUpload struct {
+	UploadID string `json:"upload_id"`
+	PartSize int64  `json:"part_size"`
+}
+
+// largeUploadPart identifies an uploaded part when completing an upload.
+type largeUploadPart struct {
+	PartNumber int    `json:"part_number"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"`
+}
+
+// AttestLarge attests a large file by uploading it in parts. Parts are
+// uploaded concurrently, each with its own SHA-256 checksum, and a failed
+// part is retried on its own instead of restarting the whole upload. The
+// server verifies the assembled file against the whole-file checksum before
+// attesting it. Files below opts.Threshold are sent with Attest.
+func (r *DocumentsResource) AttestLarge(ctx context.Context, req *AttestRequest, opts *AttestLargeOptions) (*AttestationResult, error) {
+	var o AttestLargeOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = defaultLargeAttestThreshold
+	}
+	if o.PartSize <= 0 {
+		o.PartSize = defaultLargeAttestPartSize
+	}
+	if o.PartSize < minLargeAttestPartSize {
+		o.PartSize = minLargeAttestPartSize
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultLargeAttestConcurrency
+	}
+
+	f, err := os.Open(req.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	if size < o.Threshold {
+		return r.Attest(ctx, req)
+	}
+	if parts := (size + o.PartSize - 1) / o.PartSize; parts > maxLargeAttestParts {
+		o.PartSize = (size + maxLargeAttestParts - 1) / maxLargeAttestParts
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, err
+	}
+
+	eventType := req.EventType
+	if eventType == "" {
+		eventType = "document_uploaded"
+	}
+	body := map[string]interface{}{
+		"filename":   filepathBase(req.FilePath),
+		"size":       size,
+		"part_size":  o.PartSize,
+		"sha256":     hex.EncodeToString(hash.Sum(nil)),
+		"user_id":    req.UserID,
+		"event_type": eventType,
+	}
+	if req.Metadata != nil {
+		body["metadata"] = req.Metadata
+	}
+	if req.Encrypt {
+		body["encrypt"] = true
+	}
+	if req.ExtractText {
+		body["extract_text"] = true
+	}
+
+	var upload largeUpload
+	if err := r.http.Post(ctx, "/tenant/documents/uploads", body, &upload); err != nil {
+		return nil, err
+	}
+	if upload.PartSize <= 0 {
+		upload.PartSize = o.PartSize
+	}
+
+	parts, err := r.uploadParts(ctx, f, size, &upload, &o)
+	if err != nil {
+		// Free the server-side parts; the upload cannot be resumed
+		abortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		r.http.Delete(abortCtx, "/tenant/documents/uploads/"+upload.UploadID)
+		cancel()
+		return nil, err
+	}
+
+	var result AttestationResult
+	err = r.http.Post(ctx, "/tenant/documents/uploads/"+upload.UploadID+"/complete", map[string]interface{}{
+		"parts": parts,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// uploadParts uploads every part of f with o.Concurrency workers, returning
+// the parts in order.
+func (r *DocumentsResource) uploadParts(ctx context.Context, f *os.File, size int64, upload *largeUpload, o *AttestLargeOptions) ([]largeUploadPart, error) {
+	count := int((size + upload.PartSize - 1) / upload.PartSize)
+	parts := make([]largeUploadPart, count)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	numbers := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	var uploaded int64
+
+	for w := 0; w < o.Concurrency && w < count; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, upload.PartSize)
+			for n := range numbers {
+				offset := int64(n) * upload.PartSize
+				length := upload.PartSize
+				if offset+length > size {
+					length = size - offset
+				}
+				part, err := r.uploadPart(ctx, f, upload.UploadID, n+1, offset, buf[:length])
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					parts[n] = *part
+					uploaded += length
+					if o.OnProgress != nil {
+						o.OnProgress(uploaded, size)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for n := 0; n < count; n++ {
+		select {
+		case numbers <- n:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(numbers)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, NewTimeoutError()
+	}
+	return parts, nil
+}
+
+// uploadPart reads and uploads one part, retrying transient failures.
+func (r *DocumentsResource) uploadPart(ctx context.Context, f *os.File, uploadID string, number int, offset int64, buf []byte) (*largeUploadPart, error) {
+	if _, err := f.ReadAt(buf, offset); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	sum := sha256.Sum256(buf)
+	checksum := hex.EncodeToString(sum[:])
+
+	header := http.Header{}
+	header.Set("X-Part-SHA256", checksum)
+	path := fmt.Sprintf("/tenant/documents/uploads/%s/parts/%d", uploadID, number)
+
+	var err error
+	for attempt := 0; attempt <= largeAttestPartRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, NewTimeoutError()
+			case <-time.After(largeAttestRetryDelay * time.Duration(1<<(attempt-1))):
+			}
+		}
+		err = r.http.PutRaw(ctx, path, buf, header, nil)
+		if err == nil {
+			return &largeUploadPart{PartNumber: number, Size: int64(len(buf)), SHA256: checksum}, nil
+		}
+		if !isRetryablePartError(err) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("upload part %d: %w", number, err)
+}
+
+// isRetryablePartError reports whether a part upload failure is transient.
+// A checksum mismatch is reported as a validation error and is retried, as
+// the part was most likely corrupted in transit.
+func isRetryablePartError(err error) bool {
+	var validationErr *ValidationError
+	return IsRetryable(err) || errors.As(err, &validationErr)
+}
@@ -0,0 +1,176 @@
+package proofchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAttestManyConcurrency = 4
+	attestManyRateLimitRetries   = 5
+)
+
+// attestManyMaxPause bounds the pause taken by AttestMany after a rate limit.
+var attestManyMaxPause = 60 * time.Second
+
+// AttestOutcome is the result of one attestation run by AttestMany.
+type AttestOutcome struct {
+	// Index is the position of the request in the slice passed to AttestMany.
+	Index    int
+	Request  *AttestRequest
+	Result   *AttestationResult
+	Err      error
+	Attempts int
+	Duration time.Duration
+}
+
+// AttestTotals aggregates the outcomes of AttestMany.
+type AttestTotals struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	// RateLimited counts attempts that were rejected with a rate limit and
+	// retried.
+	RateLimited int
+	Duration    time.Duration
+}
+
+// AttestMany attests files with a pool of concurrency workers and sends each
+// outcome on the returned channel as it completes, in completion order. The
+// channel is closed once every request has an outcome. When a request is
+// rate limited, all workers pause for the server's Retry-After before the
+// request is retried, so the pool backs off as a whole instead of each
+// goroutine hammering the API. If ctx is cancelled, remaining requests are
+// reported with a TimeoutError.
+//
+// Example:
+//
+//	outcomes := client.Documents.AttestMany(ctx, requests, 8)
+//	results, totals := proofchain.CollectAttestOutcomes(outcomes)
+func (r *DocumentsResource) AttestMany(ctx context.Context, requests []AttestRequest, concurrency int) <-chan AttestOutcome {
+	if concurrency <= 0 {
+		concurrency = defaultAttestManyConcurrency
+	}
+
+	out := make(chan AttestOutcome, concurrency)
+	indexes := make(chan int)
+	gate := &rateLimitGate{}
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(requests); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				out <- r.attestWithBackoff(ctx, gate, i, &requests[i])
+			}
+		}()
+	}
+
+	go func() {
+		for i := range requests {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+func (r *DocumentsResource) attestWithBackoff(ctx context.Context, gate *rateLimitGate, index int, req *AttestRequest) AttestOutcome {
+	outcome := AttestOutcome{Index: index, Request: req}
+	start := time.Now()
+	defer func() { outcome.Duration = time.Since(start) }()
+
+	for {
+		if err := gate.wait(ctx); err != nil {
+			outcome.Err = err
+			return outcome
+		}
+
+		outcome.Attempts++
+		result, err := r.Attest(ctx, req)
+		var rateErr *RateLimitError
+		if errors.As(err, &rateErr) && outcome.Attempts <= attestManyRateLimitRetries {
+			gate.pause(time.Duration(rateErr.RetryAfter) * time.Second)
+			continue
+		}
+		outcome.Result, outcome.Err = result, err
+		return outcome
+	}
+}
+
+// CollectAttestOutcomes drains outcomes from AttestMany and returns them
+// ordered by Index, along with the totals.
+func CollectAttestOutcomes(outcomes <-chan AttestOutcome) ([]AttestOutcome, AttestTotals) {
+	start := time.Now()
+	var all []AttestOutcome
+	var totals AttestTotals
+	for o := range outcomes {
+		totals.Add(&o)
+		all = append(all, o)
+	}
+	totals.Duration = time.Since(start)
+
+	ordered := make([]AttestOutcome, len(all))
+	for _, o := range all {
+		ordered[o.Index] = o
+	}
+	return ordered, totals
+}
+
+// Add counts an outcome in the totals.
+func (t *AttestTotals) Add(o *AttestOutcome) {
+	t.Total++
+	if o.Err != nil {
+		t.Failed++
+	} else {
+		t.Succeeded++
+	}
+	if o.Attempts > 1 {
+		t.RateLimited += o.Attempts - 1
+	}
+}
+
+// rateLimitGate makes every worker wait until a shared deadline after any of
+// them is rate limited.
+type rateLimitGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (g *rateLimitGate) pause(d time.Duration) {
+	if d <= 0 {
+		d = time.Second
+	}
+	if d > attestManyMaxPause {
+		d = attestManyMaxPause
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := time.Now().Add(d); until.After(g.until) {
+		g.until = until
+	}
+}
+
+func (g *rateLimitGate) wait(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		d := time.Until(g.until)
+		g.mu.Unlock()
+		if d <= 0 {
+			if ctx.Err() != nil {
+				return NewTimeoutError()
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return NewTimeoutError()
+		case <-time.After(d):
+		}
+	}
+}
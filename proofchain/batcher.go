@@ -0,0 +1,352 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultBatcherMaxEvents     = 500
+	defaultBatcherMaxBytes      = 1 << 20
+	defaultBatcherMaxAge        = 200 * time.Millisecond
+	defaultBatcherTargetLatency = time.Second
+
+	// batcherMinEvents is the smallest batch size adaptation shrinks to.
+	batcherMinEvents = 10
+	// batcherMaxRetries is how often a rate-limited batch is retried.
+	batcherMaxRetries = 5
+)
+
+// BatcherStats are cumulative statistics for a Batcher.
+type BatcherStats struct {
+	Added       int64
+	Succeeded   int64
+	Failed      int64
+	Batches     int64
+	RateLimited int64 // Batches rejected with 429 and retried
+	// BatchSize is the current adaptive batch size.
+	BatchSize int
+	// Latency is the smoothed IngestBatch latency.
+	Latency time.Duration
+}
+
+// BatcherOption configures a Batcher.
+type BatcherOption func(*Batcher)
+
+// WithBatcherMaxEvents sets the largest batch the Batcher sends (default 500,
+// max 1000).
+func WithBatcherMaxEvents(n int) BatcherOption {
+	return func(b *Batcher) {
+		if n > 0 && n <= 1000 {
+			b.maxEvents = n
+		}
+	}
+}
+
+// WithBatcherMaxBytes sets the largest encoded batch size in bytes (default
+// 1MB).
+func WithBatcherMaxBytes(n int) BatcherOption {
+	return func(b *Batcher) {
+		if n > 0 {
+			b.maxBytes = n
+		}
+	}
+}
+
+// WithBatcherMaxAge sets how long an event may wait before its batch is
+// sent (default 200ms).
+func WithBatcherMaxAge(d time.Duration) BatcherOption {
+	return func(b *Batcher) {
+		if d > 0 {
+			b.maxAge = d
+		}
+	}
+}
+
+// WithBatcherTargetLatency sets the IngestBatch latency the Batcher aims
+// for (default 1s). Batches are shrunk while latency is above it and grown
+// while latency is below half of it.
+func WithBatcherTargetLatency(d time.Duration) BatcherOption {
+	return func(b *Batcher) {
+		if d > 0 {
+			b.targetLatency = d
+		}
+	}
+}
+
+// WithBatcherOnError registers a callback for events that could not be
+// ingested. When a batch response does not report per-event results, it is
+// called once with a nil event and a summary error.
+func WithBatcherOnError(fn func(event *IngestEventRequest, err error)) BatcherOption {
+	return func(b *Batcher) {
+		b.onError = fn
+	}
+}
+
+// Batcher collects individual events and sends them with IngestBatch when
+// a batch reaches the maximum number of events or bytes, or its oldest event
+// reaches the maximum age. The batch size adapts to the API: it halves when
+// the API responds with 429 (the batch is retried after the advertised
+// delay), shrinks while latency is above the target, and grows back towards
+// the maximum while latency is low.
+//
+// Example:
+//
+//	b := proofchain.NewBatcher(proofchain.NewIngestionClient(apiKey),
+//		proofchain.WithBatcherMaxEvents(500),
+//		proofchain.WithBatcherMaxAge(200*time.Millisecond),
+//	)
+//	for _, e := range events {
+//		b.Add(e)
+//	}
+//	stats, err := b.Close(ctx)
+type Batcher struct {
+	client *IngestionClient
+
+	maxEvents     int
+	maxBytes      int
+	maxAge        time.Duration
+	targetLatency time.Duration
+	onError       func(*IngestEventRequest, error)
+
+	events  chan *IngestEventRequest
+	flushes chan chan struct{}
+	done    chan struct{}
+	closed  atomic.Bool
+	added   atomic.Int64
+
+	mu    sync.Mutex
+	stats BatcherStats
+	size  int
+}
+
+// NewBatcher creates and starts a Batcher. Call Close to flush.
+func NewBatcher(client *IngestionClient, opts ...BatcherOption) *Batcher {
+	b := &Batcher{
+		client:        client,
+		maxEvents:     defaultBatcherMaxEvents,
+		maxBytes:      defaultBatcherMaxBytes,
+		maxAge:        defaultBatcherMaxAge,
+		targetLatency: defaultBatcherTargetLatency,
+		flushes:       make(chan chan struct{}),
+		done:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.size = b.maxEvents
+	b.events = make(chan *IngestEventRequest, b.maxEvents*2)
+
+	go b.run()
+	return b
+}
+
+// Add queues an event, blocking if the buffer is full.
+func (b *Batcher) Add(event *IngestEventRequest) error {
+	if b.closed.Load() {
+		return errors.New("batcher is closed")
+	}
+	b.added.Add(1)
+	b.events <- event
+	return nil
+}
+
+// Flush sends the events buffered so far and waits until they are sent.
+func (b *Batcher) Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case b.flushes <- ack:
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return NewTimeoutError()
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return NewTimeoutError()
+	}
+}
+
+// Stats returns a snapshot of the batcher statistics.
+func (b *Batcher) Stats() BatcherStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := b.stats
+	stats.Added = b.added.Load()
+	stats.BatchSize = b.size
+	return stats
+}
+
+// Close sends buffered events and returns the final statistics. Add must not
+// be called concurrently with Close.
+func (b *Batcher) Close(ctx context.Context) (*BatcherStats, error) {
+	if !b.closed.Swap(true) {
+		close(b.events)
+	}
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return nil, NewTimeoutError()
+	}
+	stats := b.Stats()
+	return &stats, nil
+}
+
+func (b *Batcher) run() {
+	defer close(b.done)
+
+	timer := time.NewTimer(b.maxAge)
+	timer.Stop()
+	defer timer.Stop()
+
+	var buf []*IngestEventRequest
+	var bufBytes int
+	flush := func() {
+		timer.Stop()
+		b.flush(buf)
+		buf, bufBytes = nil, 0
+	}
+
+	for {
+		select {
+		case event, ok := <-b.events:
+			if !ok {
+				flush()
+				return
+			}
+			size := eventSize(event)
+			if len(buf) > 0 && bufBytes+size > b.maxBytes {
+				flush()
+			}
+			if len(buf) == 0 {
+				timer.Reset(b.maxAge)
+			}
+			buf = append(buf, event)
+			bufBytes += size
+			if len(buf) >= b.batchSize() || bufBytes >= b.maxBytes {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+		case ack := <-b.flushes:
+			flush()
+			close(ack)
+		}
+	}
+}
+
+func (b *Batcher) batchSize() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+// flush sends buf in batches of the current adaptive size, retrying
+// rate-limited batches at the reduced size.
+func (b *Batcher) flush(buf []*IngestEventRequest) {
+	ctx := context.Background()
+	retries := 0
+	for len(buf) > 0 {
+		chunk := buf[:min(len(buf), b.batchSize())]
+
+		req := &BatchIngestRequest{Events: make([]IngestEventRequest, len(chunk))}
+		for i, e := range chunk {
+			req.Events[i] = *e
+		}
+		start := time.Now()
+		resp, err := b.client.IngestBatch(ctx, req)
+		elapsed := time.Since(start)
+
+		var rateLimited *RateLimitError
+		if errors.As(err, &rateLimited) && retries < batcherMaxRetries {
+			b.adapt(elapsed, true)
+			wait := time.Duration(rateLimited.RetryAfter) * time.Second
+			if wait <= 0 {
+				wait = time.Second << retries
+			}
+			retries++
+			time.Sleep(wait)
+			continue
+		}
+		retries = 0
+		b.adapt(elapsed, false)
+		b.record(chunk, resp, err)
+		buf = buf[len(chunk):]
+	}
+}
+
+// adapt adjusts the batch size to the latency of the last batch, or to a
+// rate limit response.
+func (b *Batcher) adapt(latency time.Duration, throttled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case throttled:
+		b.stats.RateLimited++
+		b.size = max(b.size/2, batcherMinEvents)
+	case latency > b.targetLatency:
+		b.size = max(b.size*3/4, batcherMinEvents)
+	case latency < b.targetLatency/2:
+		b.size = min(b.size+b.size/10+1, b.maxEvents)
+	}
+	if !throttled {
+		if b.stats.Latency == 0 {
+			b.stats.Latency = latency
+		} else {
+			b.stats.Latency = (b.stats.Latency*7 + latency*3) / 10
+		}
+	}
+}
+
+func (b *Batcher) record(chunk []*IngestEventRequest, resp *BatchIngestResponse, err error) {
+	b.mu.Lock()
+	b.stats.Batches++
+	if err != nil {
+		b.stats.Failed += int64(len(chunk))
+	} else {
+		b.stats.Failed += int64(resp.Failed)
+		b.stats.Succeeded += int64(len(chunk) - resp.Failed)
+	}
+	b.mu.Unlock()
+
+	if b.onError == nil {
+		return
+	}
+	if err != nil {
+		for _, e := range chunk {
+			b.onError(e, err)
+		}
+		return
+	}
+	if resp.Failed == 0 {
+		return
+	}
+	if len(resp.Results) != len(chunk) {
+		b.onError(nil, fmt.Errorf("%d of %d events failed in batch", resp.Failed, len(chunk)))
+		return
+	}
+	for i, r := range resp.Results {
+		if isFailedStatus(r.Status) {
+			b.onError(chunk[i], fmt.Errorf("event rejected by server: %s", r.Status))
+		}
+	}
+}
+
+// eventSize estimates the encoded size of event in a batch.
+func eventSize(event *IngestEventRequest) int {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return 0
+	}
+	return len(data) + 1
+}
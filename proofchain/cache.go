@@ -0,0 +1,233 @@
+package proofchain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultCacheTTL = 5 * time.Minute
+
+// CachedResponse is a response body held by a ResponseCache. Entries stay
+// usable after Expires when they carry an ETag, since the client can then
+// revalidate them with If-None-Match instead of downloading the body again.
+type CachedResponse struct {
+	Body    []byte
+	ETag    string
+	Expires time.Time
+}
+
+// ResponseCache stores responses to read-heavy metadata endpoints. It must
+// be safe for concurrent use. Implement it to share a cache across processes,
+// for example in Redis; MemoryCache is an in-process implementation.
+type ResponseCache interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, entry CachedResponse)
+}
+
+// MemoryCache is an in-memory ResponseCache holding up to a fixed number of
+// entries. When full, expired entries are evicted first.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]CachedResponse
+}
+
+// NewMemoryCache creates an in-memory cache holding up to maxEntries
+// responses (default 1000).
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &MemoryCache{maxEntries: maxEntries, entries: make(map[string]CachedResponse)}
+}
+
+// Get returns the entry for key, if any.
+func (m *MemoryCache) Get(key string) (CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+// Set stores entry under key, evicting another entry if the cache is full.
+func (m *MemoryCache) Set(key string, entry CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entries[key]; !ok && len(m.entries) >= m.maxEntries {
+		m.evictLocked()
+	}
+	m.entries[key] = entry
+}
+
+func (m *MemoryCache) evictLocked() {
+	now := time.Now()
+	var victim string
+	for k, e := range m.entries {
+		if now.After(e.Expires) {
+			delete(m.entries, k)
+			return
+		}
+		if victim == "" {
+			victim = k
+		}
+	}
+	delete(m.entries, victim)
+}
+
+// WithResponseCache caches responses from static metadata endpoints such as
+// TenantInfo, Wallet.ListTokens, Schemas.Get and DataViews.List for ttl
+// (default 5m). Expired entries with an ETag are revalidated with
+// If-None-Match. A successful write to a resource, such as Schemas.Update,
+// invalidates cached reads of that resource made through the same client.
+func WithResponseCache(cache ResponseCache, ttl time.Duration) HTTPClientOption {
+	return func(c *HTTPClient) {
+		if ttl <= 0 {
+			ttl = defaultCacheTTL
+		}
+		c.cache = &responseCache{store: cache, ttl: ttl, generations: make(map[string]uint64)}
+	}
+}
+
+// responseCache wraps a ResponseCache with per-resource generations, so
+// writes invalidate earlier reads without the store supporting deletes.
+type responseCache struct {
+	store ResponseCache
+	ttl   time.Duration
+
+	mu          sync.Mutex
+	generations map[string]uint64
+}
+
+// cacheResource returns the first segment of path, e.g. "schemas" for
+// "/schemas/kyc/2".
+func cacheResource(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.IndexAny(path, "/?"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+func (r *responseCache) invalidate(path string) {
+	r.mu.Lock()
+	r.generations[cacheResource(path)]++
+	r.mu.Unlock()
+}
+
+// key identifies a response by credential, tenant, resource generation, path
+// and the custom headers sent with the request, since gateways may route or
+// shape responses by them.
+func (r *responseCache) key(credential, tenantID, path string, headers map[string]string) string {
+	r.mu.Lock()
+	gen := r.generations[cacheResource(path)]
+	r.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(credential))
+	key := hex.EncodeToString(sum[:8]) + "|" + tenantID + "|" + strconv.FormatUint(gen, 10) + "|" + path
+	if len(headers) == 0 {
+		return key
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s:%s\n", name, headers[name])
+	}
+	return key + "|" + hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// requestHeaders returns the custom headers a request made with ctx carries:
+// client-level headers overridden by context headers, keyed by canonical
+// name as they are sent.
+func (c *HTTPClient) requestHeaders(ctx context.Context) map[string]string {
+	fromCtx := HeadersFromContext(ctx)
+	if len(c.headers) == 0 && len(fromCtx) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(c.headers)+len(fromCtx))
+	for k, v := range c.headers {
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+	for k, v := range fromCtx {
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+	return headers
+}
+
+// GetCached is Get for idempotent metadata endpoints: when a response cache
+// is configured, fresh responses are served from it and stale ones are
+// revalidated by ETag. Without a cache it behaves exactly like Get.
+func (c *HTTPClient) GetCached(ctx context.Context, path string, params url.Values, result interface{}) error {
+	if c.cache == nil {
+		return c.Get(ctx, path, params, result)
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	credential := c.userToken
+	if credential == "" {
+		credential = c.APIKey()
+	}
+	key := c.cache.key(credential, c.tenantID, path, c.requestHeaders(ctx))
+
+	cached, ok := c.cache.store.Get(key)
+	if ok && time.Now().Before(cached.Expires) {
+		if result == nil || len(cached.Body) == 0 {
+			return nil
+		}
+		return decodeJSON(cached.Body, result, c.preciseNumbers)
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return err
+	}
+	if ok && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := c.execute(req, c.callTimeout, nil)
+	if err != nil {
+		return err
+	}
+
+	entry := CachedResponse{Expires: time.Now().Add(c.cache.ttl)}
+	if resp.StatusCode == http.StatusNotModified && ok {
+		entry.Body = cached.Body
+		entry.ETag = cached.ETag
+	} else {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return NewNetworkError(err)
+		}
+		entry.Body = body
+		entry.ETag = resp.Header.Get("ETag")
+	}
+
+	if result != nil && len(entry.Body) > 0 {
+		if err := decodeJSON(entry.Body, result, c.preciseNumbers); err != nil {
+			return NewNetworkError(fmt.Errorf("failed to parse response: %w", err))
+		}
+	}
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotModified {
+		c.cache.store.Set(key, entry)
+	}
+	return nil
+}
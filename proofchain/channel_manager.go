@@ -0,0 +1,245 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// ChannelManagerOption configures a ChannelManager.
+type ChannelManagerOption func(*ChannelManager)
+
+// WithSettleAfterEvents settles once n events are pending.
+func WithSettleAfterEvents(n int) ChannelManagerOption {
+	return func(m *ChannelManager) {
+		m.maxEvents = n
+	}
+}
+
+// WithSettleAfterBytes settles once n bytes of event payload are pending.
+func WithSettleAfterBytes(n int) ChannelManagerOption {
+	return func(m *ChannelManager) {
+		m.maxBytes = n
+	}
+}
+
+// WithSettleInterval settles when the oldest pending event is older than d.
+func WithSettleInterval(d time.Duration) ChannelManagerOption {
+	return func(m *ChannelManager) {
+		m.maxAge = d
+	}
+}
+
+// WithOnSettle registers a callback invoked after each successful settlement.
+func WithOnSettle(fn func(*Settlement)) ChannelManagerOption {
+	return func(m *ChannelManager) {
+		m.onSettle = fn
+	}
+}
+
+// WithOnSettleError registers a callback invoked when an automatic
+// settlement fails. The pending counters are kept so the next trigger retries.
+func WithOnSettleError(fn func(error)) ChannelManagerOption {
+	return func(m *ChannelManager) {
+		m.onError = fn
+	}
+}
+
+// ChannelManager wraps a state channel and settles it automatically when the
+// configured event-count, pending-bytes or elapsed-time thresholds are hit.
+// It is safe for concurrent use.
+//
+// Example:
+//
+//	m := proofchain.NewChannelManager(client.Channels, channel.ChannelID,
+//		proofchain.WithSettleAfterEvents(1000),
+//		proofchain.WithSettleInterval(5*time.Minute),
+//		proofchain.WithOnSettle(func(s *proofchain.Settlement) {
+//			log.Printf("settled %d events in %s", s.EventCount, s.TxHash)
+//		}),
+//	)
+//	defer m.Stop(ctx)
+//	m.Stream(ctx, &proofchain.StreamEventRequest{...})
+type ChannelManager struct {
+	channels  *ChannelsResource
+	channelID string
+
+	maxEvents int
+	maxBytes  int
+	maxAge    time.Duration
+	onSettle  func(*Settlement)
+	onError   func(error)
+
+	mu           sync.Mutex
+	pending      int
+	pendingBytes int
+	oldest       time.Time
+
+	settleMu sync.Mutex
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewChannelManager creates a manager for an existing channel. If an interval
+// is configured, a background goroutine checks it until Stop or Close.
+func NewChannelManager(channels *ChannelsResource, channelID string, opts ...ChannelManagerOption) *ChannelManager {
+	m := &ChannelManager{
+		channels:  channels,
+		channelID: channelID,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.maxAge > 0 {
+		go m.run()
+	} else {
+		close(m.done)
+	}
+	return m
+}
+
+// ChannelID returns the managed channel's ID.
+func (m *ChannelManager) ChannelID() string {
+	return m.channelID
+}
+
+// Pending returns the number of events streamed since the last settlement.
+func (m *ChannelManager) Pending() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pending
+}
+
+// Stream streams an event and settles if a threshold is reached.
+func (m *ChannelManager) Stream(ctx context.Context, req *StreamEventRequest) (*StreamAck, error) {
+	ack, err := m.channels.Stream(ctx, m.channelID, req)
+	if err != nil {
+		return nil, err
+	}
+	m.record(ctx, 1, payloadSize(req))
+	return ack, nil
+}
+
+// StreamBatch streams several events and settles if a threshold is reached.
+func (m *ChannelManager) StreamBatch(ctx context.Context, events []StreamEventRequest) (map[string]interface{}, error) {
+	result, err := m.channels.StreamBatch(ctx, m.channelID, events)
+	if err != nil {
+		return nil, err
+	}
+	size := 0
+	for i := range events {
+		size += payloadSize(&events[i])
+	}
+	m.record(ctx, len(events), size)
+	return result, nil
+}
+
+// Settle settles the channel now, regardless of thresholds. It returns nil
+// without calling the API if nothing is pending.
+func (m *ChannelManager) Settle(ctx context.Context) (*Settlement, error) {
+	m.settleMu.Lock()
+	defer m.settleMu.Unlock()
+
+	m.mu.Lock()
+	count, size := m.pending, m.pendingBytes
+	oldest := m.oldest
+	m.pending, m.pendingBytes = 0, 0
+	m.oldest = time.Time{}
+	m.mu.Unlock()
+
+	if count == 0 {
+		return nil, nil
+	}
+
+	settlement, err := m.channels.Settle(ctx, m.channelID)
+	if err != nil {
+		m.mu.Lock()
+		m.pending += count
+		m.pendingBytes += size
+		if m.oldest.IsZero() || oldest.Before(m.oldest) {
+			m.oldest = oldest
+		}
+		m.mu.Unlock()
+		return nil, err
+	}
+
+	if m.onSettle != nil {
+		m.onSettle(settlement)
+	}
+	return settlement, nil
+}
+
+// Stop stops the interval timer and settles any pending events.
+func (m *ChannelManager) Stop(ctx context.Context) (*Settlement, error) {
+	m.stopOnce.Do(func() { close(m.stop) })
+	<-m.done
+	return m.Settle(ctx)
+}
+
+// Close stops the manager, settles any pending events and closes the channel.
+func (m *ChannelManager) Close(ctx context.Context) (*Channel, error) {
+	if _, err := m.Stop(ctx); err != nil {
+		return nil, err
+	}
+	return m.channels.Close(ctx, m.channelID)
+}
+
+func (m *ChannelManager) record(ctx context.Context, n, size int) {
+	m.mu.Lock()
+	if m.pending == 0 {
+		m.oldest = time.Now()
+	}
+	m.pending += n
+	m.pendingBytes += size
+	due := (m.maxEvents > 0 && m.pending >= m.maxEvents) ||
+		(m.maxBytes > 0 && m.pendingBytes >= m.maxBytes)
+	m.mu.Unlock()
+
+	if due {
+		m.autoSettle(ctx)
+	}
+}
+
+func (m *ChannelManager) autoSettle(ctx context.Context) {
+	if _, err := m.Settle(ctx); err != nil && m.onError != nil {
+		m.onError(err)
+	}
+}
+
+func (m *ChannelManager) run() {
+	defer close(m.done)
+
+	tick := m.maxAge / 4
+	if tick < time.Second {
+		tick = time.Second
+	}
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			due := m.pending > 0 && time.Since(m.oldest) >= m.maxAge
+			m.mu.Unlock()
+			if due {
+				m.autoSettle(context.Background())
+			}
+		}
+	}
+}
+
+func payloadSize(req *StreamEventRequest) int {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
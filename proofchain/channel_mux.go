@@ -0,0 +1,220 @@
+package proofchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+// PartitionByUser routes events by user ID.
+func PartitionByUser(req *StreamEventRequest) string {
+	return req.UserID
+}
+
+// PartitionByDataField returns a partition function that routes events by a
+// string field in the event data (e.g. "region"). Events without the field go
+// to the "default" partition.
+func PartitionByDataField(field string) func(*StreamEventRequest) string {
+	return func(req *StreamEventRequest) string {
+		if v, ok := req.Data[field].(string); ok && v != "" {
+			return v
+		}
+		return "default"
+	}
+}
+
+// ChannelMuxOption configures a ChannelMux.
+type ChannelMuxOption func(*ChannelMux)
+
+// WithMuxPartitions hashes partition keys into n buckets, bounding the number
+// of channels. Without it every distinct key gets its own channel.
+func WithMuxPartitions(n int) ChannelMuxOption {
+	return func(m *ChannelMux) {
+		m.buckets = n
+	}
+}
+
+// WithMuxChannelPrefix sets the name prefix for lazily created channels.
+// Channels are named "<prefix>-<partition>". The default prefix is "mux".
+func WithMuxChannelPrefix(prefix string) ChannelMuxOption {
+	return func(m *ChannelMux) {
+		m.prefix = prefix
+	}
+}
+
+// WithMuxChannelManagerOptions applies settlement options to every channel
+// the mux creates.
+func WithMuxChannelManagerOptions(opts ...ChannelManagerOption) ChannelMuxOption {
+	return func(m *ChannelMux) {
+		m.managerOpts = append(m.managerOpts, opts...)
+	}
+}
+
+// ChannelMux routes streamed events to one of several state channels by
+// partition key, creating channels lazily and settling or closing them as a
+// group. Each channel is wrapped in a ChannelManager. It is safe for
+// concurrent use.
+//
+// Example:
+//
+//	mux := proofchain.NewChannelMux(client.Channels, proofchain.PartitionByDataField("region"),
+//		proofchain.WithMuxChannelPrefix("sensors"),
+//		proofchain.WithMuxChannelManagerOptions(proofchain.WithSettleAfterEvents(500)),
+//	)
+//	defer mux.Close(ctx)
+//	mux.Stream(ctx, &proofchain.StreamEventRequest{...})
+type ChannelMux struct {
+	channels    *ChannelsResource
+	partition   func(*StreamEventRequest) string
+	buckets     int
+	prefix      string
+	managerOpts []ChannelManagerOption
+
+	mu       sync.Mutex
+	managers map[string]*ChannelManager
+	creating map[string]chan struct{}
+}
+
+// NewChannelMux creates a multiplexer that partitions events with partition.
+func NewChannelMux(channels *ChannelsResource, partition func(*StreamEventRequest) string, opts ...ChannelMuxOption) *ChannelMux {
+	m := &ChannelMux{
+		channels:  channels,
+		partition: partition,
+		prefix:    "mux",
+		managers:  make(map[string]*ChannelManager),
+		creating:  make(map[string]chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Stream routes an event to its partition's channel, creating the channel on
+// first use.
+func (m *ChannelMux) Stream(ctx context.Context, req *StreamEventRequest) (*StreamAck, error) {
+	manager, err := m.manager(ctx, m.partitionKey(req))
+	if err != nil {
+		return nil, err
+	}
+	return manager.Stream(ctx, req)
+}
+
+// Channels returns the channel ID for each partition created so far.
+func (m *ChannelMux) Channels() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make(map[string]string, len(m.managers))
+	for key, manager := range m.managers {
+		ids[key] = manager.ChannelID()
+	}
+	return ids
+}
+
+// SettleAll settles every channel with pending events. Settlements are keyed
+// by partition; failures are joined into the returned error.
+func (m *ChannelMux) SettleAll(ctx context.Context) (map[string]*Settlement, error) {
+	results := make(map[string]*Settlement)
+	var errs []error
+	for _, key := range m.partitions() {
+		settlement, err := m.get(key).Settle(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("partition %s: %w", key, err))
+			continue
+		}
+		if settlement != nil {
+			results[key] = settlement
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// Close settles and closes every channel. The mux must not be used afterwards.
+func (m *ChannelMux) Close(ctx context.Context) error {
+	var errs []error
+	for _, key := range m.partitions() {
+		if _, err := m.get(key).Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("partition %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *ChannelMux) partitionKey(req *StreamEventRequest) string {
+	key := m.partition(req)
+	if m.buckets <= 0 {
+		return key
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return fmt.Sprintf("%d", h.Sum32()%uint32(m.buckets))
+}
+
+func (m *ChannelMux) partitions() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0, len(m.managers))
+	for key := range m.managers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *ChannelMux) get(key string) *ChannelManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.managers[key]
+}
+
+// manager returns the manager for key, creating its channel if needed.
+// Concurrent callers for the same new key wait for a single Create.
+func (m *ChannelMux) manager(ctx context.Context, key string) (*ChannelManager, error) {
+	for {
+		m.mu.Lock()
+		if manager, ok := m.managers[key]; ok {
+			m.mu.Unlock()
+			return manager, nil
+		}
+		wait, inFlight := m.creating[key]
+		if !inFlight {
+			wait = make(chan struct{})
+			m.creating[key] = wait
+		}
+		m.mu.Unlock()
+
+		if inFlight {
+			select {
+			case <-wait:
+				continue
+			case <-ctx.Done():
+				return nil, NewTimeoutError()
+			}
+		}
+
+		channel, err := m.channels.Create(ctx, &CreateChannelRequest{
+			Name:        m.prefix + "-" + key,
+			Description: "Created by ChannelMux for partition " + key,
+		})
+
+		m.mu.Lock()
+		delete(m.creating, key)
+		var manager *ChannelManager
+		if err == nil {
+			manager = NewChannelManager(m.channels, channel.ChannelID, m.managerOpts...)
+			m.managers[key] = manager
+		}
+		m.mu.Unlock()
+		close(wait)
+
+		if err != nil {
+			return nil, err
+		}
+		return manager, nil
+	}
+}
@@ -15,6 +15,9 @@ package proofchain
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -43,6 +46,9 @@ type Client struct {
 	Fanpass        *FanpassLeaderboardClient
 	Credentials    *CredentialsClient
 	PartnerKeys    *PartnerKeysClient
+	Competitions   *CompetitionsClient
+	Org            *OrgClient
+	Segments       *SegmentsClient
 }
 
 // NewClient creates a new ProofChain client.
@@ -104,10 +110,71 @@ func newClientFromHTTP(httpClient *HTTPClient) *Client {
 	c.Fanpass = NewFanpassLeaderboardClient(httpClient)
 	c.Credentials = NewCredentialsClient(httpClient)
 	c.PartnerKeys = NewPartnerKeysClient(httpClient)
+	c.Competitions = NewCompetitionsClient(httpClient)
+	c.Org = NewOrgClient(httpClient)
+	c.Segments = NewSegmentsClient(httpClient)
 
 	return c
 }
 
+// WithTenant returns a client whose requests are scoped to tenantID via the
+// X-Tenant-ID header. It is intended for partner/reseller API keys that manage
+// several tenants; the derived client shares the parent's connection pool and
+// the parent is left unchanged.
+//
+// Example:
+//
+//	acme := client.WithTenant("acme")
+//	users, err := acme.Users.List(ctx, nil)
+func (c *Client) WithTenant(tenantID string) *Client {
+	return newClientFromHTTP(c.http.withTenant(tenantID))
+}
+
+// WithOptions returns a client with opts applied on top of the parent's
+// configuration, for example a longer timeout for exports or a shorter one
+// for health checks. The derived client shares the parent's connection pool,
+// API key (see SetAPIKey) and response cache unless opts replace them; TLS
+// and proxy options give it its own transport. The parent is left unchanged
+// and both may be used concurrently.
+//
+// Example:
+//
+//	exports := client.WithOptions(proofchain.WithPerCallTimeout(5 * time.Minute))
+//	health := client.WithOptions(proofchain.WithPerCallTimeout(2*time.Second), proofchain.WithRetries(0))
+func (c *Client) WithOptions(opts ...HTTPClientOption) *Client {
+	return newClientFromHTTP(c.http.withOptions(opts...))
+}
+
+// SetAPIKey atomically swaps the API key on a live client, including any
+// clients derived from it with WithTenant. Use it with Tenant.RotateAPIKey to
+// cut over to a new key without rebuilding the client.
+func (c *Client) SetAPIKey(apiKey string) {
+	c.http.SetAPIKey(apiKey)
+}
+
+// Do calls an arbitrary API endpoint with the client's authentication,
+// retries and error mapping. body is sent as JSON when non-nil and a
+// successful response is decoded into result when non-nil. Use it to reach
+// new or beta endpoints before the SDK wraps them.
+//
+// Example:
+//
+//	var out struct{ Count int `json:"count"` }
+//	_, err := client.Do(ctx, http.MethodGet, "/beta/widgets/count", nil, &out)
+func (c *Client) Do(ctx context.Context, method, path string, body, result interface{}) (*http.Response, error) {
+	return c.http.Do(ctx, method, path, body, result)
+}
+
+// RawJSON calls an arbitrary API endpoint like Do and returns the response
+// body undecoded.
+func (c *Client) RawJSON(ctx context.Context, method, path string, body interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	if _, err := c.http.Do(ctx, method, path, body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // Verify verifies a document or event by its IPFS hash.
 func (c *Client) Verify(ctx context.Context, ipfsHash string) (*VerificationResult, error) {
 	var result VerificationResult
@@ -121,7 +188,7 @@ func (c *Client) Verify(ctx context.Context, ipfsHash string) (*VerificationResu
 // TenantInfo returns information about the current tenant.
 func (c *Client) TenantInfo(ctx context.Context) (*TenantInfo, error) {
 	var result TenantInfo
-	err := c.http.Get(ctx, "/tenant/me", nil, &result)
+	err := c.http.GetCached(ctx, "/tenant/me", nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +238,9 @@ func (r *DocumentsResource) Attest(ctx context.Context, req *AttestRequest) (*At
 	if req.Encrypt {
 		fields["encrypt"] = "1"
 	}
+	if req.ExtractText {
+		fields["extract_text"] = "1"
+	}
 
 	var result AttestationResult
 	err = r.http.RequestMultipart(ctx, "/tenant/documents", fields, "file", filename, content, &result)
@@ -198,6 +268,9 @@ func (r *DocumentsResource) AttestBytes(ctx context.Context, req *AttestBytesReq
 	if req.Encrypt {
 		fields["encrypt"] = "1"
 	}
+	if req.ExtractText {
+		fields["extract_text"] = "1"
+	}
 
 	var result AttestationResult
 	err := r.http.RequestMultipart(ctx, "/tenant/documents", fields, "file", req.Filename, req.Content, &result)
@@ -229,7 +302,10 @@ func (r *EventsResource) Create(ctx context.Context, req *CreateEventRequest) (*
 		source = "api"
 	}
 
-	data := req.Data
+	data, err := redactData(req.Data, req.Redact, req.RedactionKey, req.RedactOptional)
+	if err != nil {
+		return nil, err
+	}
 	if data == nil {
 		data = map[string]interface{}{}
 	}
@@ -242,7 +318,7 @@ func (r *EventsResource) Create(ctx context.Context, req *CreateEventRequest) (*
 	}
 
 	var result Event
-	err := r.http.Post(ctx, "/tenant/events", payload, &result)
+	err = r.http.Post(ctx, "/tenant/events", payload, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -250,18 +326,98 @@ func (r *EventsResource) Create(ctx context.Context, req *CreateEventRequest) (*
 }
 
 // Get retrieves an event by ID.
+// If ctx carries a consistency token, Get retries briefly until the event is visible.
 func (r *EventsResource) Get(ctx context.Context, eventID string) (*Event, error) {
 	var result Event
-	err := r.http.Get(ctx, "/tenant/events/"+eventID, nil, &result)
+	err := retryUntilVisible(ctx, func() error {
+		return r.http.Get(ctx, "/tenant/events/"+eventID, nil, &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Amend creates a new attested event that supersedes eventID, linked to the
+// original. The original event and its attestation are not modified.
+func (r *EventsResource) Amend(ctx context.Context, eventID string, req *AmendEventRequest) (*Event, error) {
+	if req.Reason == "" {
+		return nil, NewValidationError("reason is required", []ValidationErrorDetail{
+			{Field: "reason", Message: "amendments must give a reason"},
+		})
+	}
+
+	data, err := redactData(req.Data, req.Redact, req.RedactionKey, req.RedactOptional)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	payload := map[string]interface{}{
+		"data":   data,
+		"reason": req.Reason,
+	}
+
+	var result Event
+	err = r.http.Post(ctx, "/tenant/events/"+eventID+"/amend", payload, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// GetHistoryChain returns the full amendment chain containing eventID, from
+// the original event to the latest amendment.
+func (r *EventsResource) GetHistoryChain(ctx context.Context, eventID string) ([]Event, error) {
+	var result struct {
+		Events []Event `json:"events"`
+	}
+	err := r.http.Get(ctx, "/tenant/events/"+eventID+"/history", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
 // List lists events with optional filters.
 func (r *EventsResource) List(ctx context.Context, req *ListEventsRequest) ([]Event, error) {
-	params := make(map[string][]string)
+	return r.list(ctx, eventListParams(req))
+}
+
+// ListByChannel lists the events streamed to a state channel, for
+// reconciling a settlement against its events. opts may be nil.
+func (r *EventsResource) ListByChannel(ctx context.Context, channelID string, opts *ListEventsRequest) ([]Event, error) {
+	params := eventListParams(opts)
+	params["channel_id"] = []string{channelID}
+	return r.list(ctx, params)
+}
+
+// ListByBatch lists the events anchored on-chain in a batch, for
+// reconciling the batch transaction against its events. opts may be nil.
+func (r *EventsResource) ListByBatch(ctx context.Context, batchID string, opts *ListEventsRequest) ([]Event, error) {
+	params := eventListParams(opts)
+	params["batch_id"] = []string{batchID}
+	return r.list(ctx, params)
+}
+
+func (r *EventsResource) list(ctx context.Context, params url.Values) ([]Event, error) {
+	var result struct {
+		Events []Event `json:"events"`
+	}
+	err := r.http.Get(ctx, "/tenant/events", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
+func eventListParams(req *ListEventsRequest) url.Values {
+	params := make(url.Values)
+	if req == nil {
+		return params
+	}
 	if req.UserID != "" {
 		params["user_id"] = []string{req.UserID}
 	}
@@ -283,15 +439,10 @@ func (r *EventsResource) List(ctx context.Context, req *ListEventsRequest) ([]Ev
 	if req.Offset > 0 {
 		params["offset"] = []string{intToString(req.Offset)}
 	}
-
-	var result struct {
-		Events []Event `json:"events"`
-	}
-	err := r.http.Get(ctx, "/tenant/events", params, &result)
-	if err != nil {
-		return nil, err
+	if req.IncludeArchived {
+		params["include_archived"] = []string{"true"}
 	}
-	return result.Events, nil
+	return params
 }
 
 // Search searches events by query.
@@ -317,6 +468,9 @@ func (r *EventsResource) Search(ctx context.Context, req *SearchRequest) (*Searc
 	if req.Page > 0 {
 		payload["page"] = req.Page
 	}
+	if req.IncludeArchived {
+		payload["include_archived"] = true
+	}
 
 	var result SearchResult
 	err := r.http.Post(ctx, "/search", payload, &result)
@@ -326,6 +480,28 @@ func (r *EventsResource) Search(ctx context.Context, req *SearchRequest) (*Searc
 	return &result, nil
 }
 
+// Archive hides an event from List and Search results, and from analytics,
+// unless IncludeArchived is set. The event and its attestation are not
+// modified; Restore undoes the archive.
+func (r *EventsResource) Archive(ctx context.Context, eventID string) (*Event, error) {
+	var result Event
+	err := r.http.Post(ctx, "/tenant/events/"+eventID+"/archive", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Restore makes an archived event visible again.
+func (r *EventsResource) Restore(ctx context.Context, eventID string) (*Event, error) {
+	var result Event
+	err := r.http.Post(ctx, "/tenant/events/"+eventID+"/restore", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ByHash retrieves an event by its IPFS hash.
 func (r *EventsResource) ByHash(ctx context.Context, ipfsHash string) (*Event, error) {
 	var result Event
@@ -445,6 +621,35 @@ func (r *ChannelsResource) Settle(ctx context.Context, channelID string) (*Settl
 	return &result, nil
 }
 
+// ListSettlements lists a channel's past settlements, newest first, for
+// reconciliation against on-chain data.
+func (r *ChannelsResource) ListSettlements(ctx context.Context, channelID string, opts *ListSettlementsOptions) ([]Settlement, error) {
+	params := make(map[string][]string)
+	if opts != nil {
+		if opts.FromDate != nil {
+			params["from_date"] = []string{opts.FromDate.Format(time.RFC3339)}
+		}
+		if opts.ToDate != nil {
+			params["to_date"] = []string{opts.ToDate.Format(time.RFC3339)}
+		}
+		if opts.Limit > 0 {
+			params["limit"] = []string{intToString(opts.Limit)}
+		}
+		if opts.Offset > 0 {
+			params["offset"] = []string{intToString(opts.Offset)}
+		}
+	}
+
+	var result struct {
+		Settlements []Settlement `json:"settlements"`
+	}
+	err := r.http.Get(ctx, "/channels/"+channelID+"/settlements", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Settlements, nil
+}
+
 // Close closes a channel.
 func (r *ChannelsResource) Close(ctx context.Context, channelID string) (*Channel, error) {
 	var result Channel
@@ -520,6 +725,59 @@ func (r *CertificatesResource) List(ctx context.Context, req *ListCertificatesRe
 	return result.Certificates, nil
 }
 
+// Search finds certificates by recipient name, title, metadata, issue date
+// and revocation status.
+//
+// Example, certificates for a course issued in Q1:
+//
+//	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+//	to := from.AddDate(0, 3, 0)
+//	result, err := client.Certificates.Search(ctx, &proofchain.CertificateSearchRequest{
+//		Title:      "Data Protection Fundamentals",
+//		IssuedFrom: &from,
+//		IssuedTo:   &to,
+//	})
+func (r *CertificatesResource) Search(ctx context.Context, req *CertificateSearchRequest) (*CertificateSearchResult, error) {
+	if req.IssuedFrom != nil && req.IssuedTo != nil && !req.IssuedFrom.Before(*req.IssuedTo) {
+		return nil, NewValidationError("invalid certificate search", []ValidationErrorDetail{
+			{Field: "issued_to", Message: "must be after issued_from"},
+		})
+	}
+
+	payload := map[string]interface{}{}
+	if req.RecipientName != "" {
+		payload["recipient_name"] = req.RecipientName
+	}
+	if req.Title != "" {
+		payload["title"] = req.Title
+	}
+	if len(req.MetadataFilters) > 0 {
+		payload["metadata_filters"] = req.MetadataFilters
+	}
+	if req.IssuedFrom != nil {
+		payload["issued_from"] = req.IssuedFrom.UTC().Format(time.RFC3339)
+	}
+	if req.IssuedTo != nil {
+		payload["issued_to"] = req.IssuedTo.UTC().Format(time.RFC3339)
+	}
+	if req.Revoked != nil {
+		payload["revoked"] = *req.Revoked
+	}
+	if req.Limit > 0 {
+		payload["limit"] = req.Limit
+	}
+	if req.Offset > 0 {
+		payload["offset"] = req.Offset
+	}
+
+	var result CertificateSearchResult
+	err := r.http.Post(ctx, "/certificates/search", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // Revoke revokes a certificate.
 func (r *CertificatesResource) Revoke(ctx context.Context, certificateID, reason string) (*Certificate, error) {
 	payload := map[string]interface{}{}
@@ -545,6 +803,32 @@ func (r *CertificatesResource) Verify(ctx context.Context, certificateID string)
 	return result, nil
 }
 
+// CreateClaimLink creates a time-limited link recipients use to claim a
+// certificate into their own wallet or passport. Creating a new link
+// invalidates earlier unclaimed links for the certificate.
+func (r *CertificatesResource) CreateClaimLink(ctx context.Context, certificateID string, opts *ClaimLinkOptions) (*CertificateClaimLink, error) {
+	if opts == nil {
+		opts = &ClaimLinkOptions{}
+	}
+
+	var result CertificateClaimLink
+	err := r.http.Post(ctx, "/certificates/"+certificateID+"/claim-links", opts, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetClaimStatus returns whether a certificate has been claimed, and by whom.
+func (r *CertificatesResource) GetClaimStatus(ctx context.Context, certificateID string) (*CertificateClaimStatus, error) {
+	var result CertificateClaimStatus
+	err := r.http.Get(ctx, "/certificates/"+certificateID+"/claim", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // WebhooksResource handles webhook operations.
 type WebhooksResource struct {
 	http *HTTPClient
@@ -559,6 +843,9 @@ func (r *WebhooksResource) Create(ctx context.Context, req *CreateWebhookRequest
 	if req.Secret != "" {
 		payload["secret"] = req.Secret
 	}
+	if req.AttestDeliveries {
+		payload["attest_deliveries"] = true
+	}
 
 	var result Webhook
 	err := r.http.Post(ctx, "/webhooks", payload, &result)
@@ -602,6 +889,9 @@ func (r *WebhooksResource) Update(ctx context.Context, webhookID string, req *Up
 	if req.Active != nil {
 		payload["active"] = *req.Active
 	}
+	if req.AttestDeliveries != nil {
+		payload["attest_deliveries"] = *req.AttestDeliveries
+	}
 
 	var result Webhook
 	err := r.http.Patch(ctx, "/webhooks/"+webhookID, payload, &result)
@@ -625,3 +915,70 @@ func (r *WebhooksResource) Test(ctx context.Context, webhookID string) (map[stri
 	}
 	return result, nil
 }
+
+// ListAttestedDeliveries lists the attested deliveries of a webhook created with AttestDeliveries.
+// Each delivery references the attestation event proving what was sent, where, and when.
+func (r *WebhooksResource) ListAttestedDeliveries(ctx context.Context, webhookID string, opts *ListWebhookDeliveriesOptions) ([]AttestedWebhookDelivery, error) {
+	params := webhookDeliveryParams(opts)
+
+	var result struct {
+		Deliveries []AttestedWebhookDelivery `json:"deliveries"`
+	}
+	err := r.http.Get(ctx, "/webhooks/"+webhookID+"/deliveries/attested", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Deliveries, nil
+}
+
+// ListDeliveries lists delivery attempts for a webhook, newest first, with the
+// response code, latency and payload of each.
+func (r *WebhooksResource) ListDeliveries(ctx context.Context, webhookID string, opts *ListWebhookDeliveriesOptions) ([]WebhookDelivery, error) {
+	params := webhookDeliveryParams(opts)
+	if opts != nil && opts.Status != "" {
+		params["status"] = []string{opts.Status}
+	}
+
+	var result struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+	}
+	err := r.http.Get(ctx, "/webhooks/"+webhookID+"/deliveries", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Deliveries, nil
+}
+
+// Redeliver resends the payload of a previous delivery and returns the new
+// delivery attempt.
+func (r *WebhooksResource) Redeliver(ctx context.Context, webhookID, deliveryID string) (*WebhookDelivery, error) {
+	var result WebhookDelivery
+	err := r.http.Post(ctx, "/webhooks/"+webhookID+"/deliveries/"+deliveryID+"/redeliver", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func webhookDeliveryParams(opts *ListWebhookDeliveriesOptions) map[string][]string {
+	params := make(map[string][]string)
+	if opts == nil {
+		return params
+	}
+	if opts.EventType != "" {
+		params["event_type"] = []string{opts.EventType}
+	}
+	if opts.FromDate != nil {
+		params["from_date"] = []string{opts.FromDate.Format(time.RFC3339)}
+	}
+	if opts.ToDate != nil {
+		params["to_date"] = []string{opts.ToDate.Format(time.RFC3339)}
+	}
+	if opts.Limit > 0 {
+		params["limit"] = []string{intToString(opts.Limit)}
+	}
+	if opts.Offset > 0 {
+		params["offset"] = []string{intToString(opts.Offset)}
+	}
+	return params
+}
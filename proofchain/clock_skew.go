@@ -0,0 +1,215 @@
+package proofchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ServerTime is a measurement of the API server's clock.
+type ServerTime struct {
+	// Time is the server's time at the midpoint of the request.
+	Time time.Time `json:"time"`
+	// Skew is the server clock minus the local clock. Add it to a local
+	// timestamp to express it in server time.
+	Skew time.Duration `json:"skew"`
+	// RoundTrip is the request latency; the skew is accurate to about half
+	// of it.
+	RoundTrip time.Duration `json:"round_trip"`
+}
+
+// ServerTime measures the API server's clock and the local clock's skew from
+// it.
+func (c *Client) ServerTime(ctx context.Context) (*ServerTime, error) {
+	var result struct {
+		ServerTime time.Time `json:"server_time"`
+	}
+	start := time.Now()
+	err := c.http.Get(ctx, "/time", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	rtt := time.Since(start)
+
+	midpoint := start.Add(rtt / 2)
+	return &ServerTime{
+		Time:      result.ServerTime,
+		Skew:      result.ServerTime.Sub(midpoint),
+		RoundTrip: rtt,
+	}, nil
+}
+
+// ClockSkew holds the measured skew between the local and server clocks. It
+// is safe for concurrent use. Pass it to WithClockSkew or WithGRPCClockSkew
+// to correct client-supplied event timestamps.
+type ClockSkew struct {
+	skew       atomic.Int64
+	measuredAt atomic.Int64
+}
+
+// Offset returns the server clock minus the local clock.
+func (s *ClockSkew) Offset() time.Duration {
+	return time.Duration(s.skew.Load())
+}
+
+// MeasuredAt returns when the skew was last measured, or the zero time.
+func (s *ClockSkew) MeasuredAt() time.Time {
+	if ns := s.measuredAt.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+// Update records a new measurement.
+func (s *ClockSkew) Update(st *ServerTime) {
+	s.skew.Store(int64(st.Skew))
+	s.measuredAt.Store(time.Now().UnixNano())
+}
+
+// Adjust converts a local timestamp to server time.
+func (s *ClockSkew) Adjust(t time.Time) time.Time {
+	return t.Add(s.Offset())
+}
+
+// TrackClockSkew measures the clock skew now and then every interval until
+// ctx is done. Failed measurements keep the previous value. The first
+// measurement's error is returned so callers can tell whether the returned
+// skew is usable yet.
+//
+// Example:
+//
+//	skew, err := client.TrackClockSkew(ctx, 10*time.Minute)
+//	ingest := proofchain.NewIngestionClient(apiKey, proofchain.WithClockSkew(skew))
+func (c *Client) TrackClockSkew(ctx context.Context, interval time.Duration) (*ClockSkew, error) {
+	skew := &ClockSkew{}
+	st, err := c.ServerTime(ctx)
+	if err == nil {
+		skew.Update(st)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if st, err := c.ServerTime(ctx); err == nil {
+					skew.Update(st)
+				}
+			}
+		}
+	}()
+	return skew, err
+}
+
+// WithClockSkew adjusts client-supplied event timestamps by skew before
+// sending, so events from devices with drifted clocks are recorded in server
+// time.
+func WithClockSkew(skew *ClockSkew) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.clockSkew = skew
+	}
+}
+
+// WithGRPCClockSkew adjusts client-supplied event timestamps by skew before
+// streaming.
+func WithGRPCClockSkew(skew *ClockSkew) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.clockSkew = skew
+	}
+}
+
+// adjustTimestamp converts an RFC 3339 timestamp to server time. Timestamps
+// that do not parse are returned unchanged for the server to reject.
+func adjustTimestamp(skew *ClockSkew, ts string) string {
+	if skew == nil || ts == "" || skew.Offset() == 0 {
+		return ts
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return ts
+	}
+	return skew.Adjust(t).Format(time.RFC3339Nano)
+}
+
+// TimestampRejectedError diagnoses an event rejected because its timestamp
+// is too far from the server's clock. It wraps the API error, so errors.As
+// for *ValidationError still matches.
+type TimestampRejectedError struct {
+	Timestamp time.Time
+	// Drift is how far Timestamp is ahead of (positive) or behind
+	// (negative) the estimated server time.
+	Drift time.Duration
+	// SkewKnown reports whether a measured clock skew was used to estimate
+	// the server time; otherwise the local clock was assumed correct.
+	SkewKnown bool
+	Err       error
+}
+
+func (e *TimestampRejectedError) Error() string {
+	direction := "ahead of"
+	drift := e.Drift
+	if drift < 0 {
+		direction, drift = "behind", -drift
+	}
+	msg := fmt.Sprintf("event timestamp %s is %s %s server time", e.Timestamp.Format(time.RFC3339), drift.Round(time.Second), direction)
+	if !e.SkewKnown {
+		msg += " (assuming the local clock is correct; use WithClockSkew to correct drifted clocks)"
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *TimestampRejectedError) Unwrap() error {
+	return e.Err
+}
+
+// diagnoseTimestampError wraps err in a TimestampRejectedError if it is a
+// validation error about the event timestamp. ts is the timestamp as sent,
+// after any skew adjustment.
+func diagnoseTimestampError(err error, ts string, skew *ClockSkew) error {
+	var validationErr *ValidationError
+	if ts == "" || !errors.As(err, &validationErr) {
+		return err
+	}
+	if !strings.Contains(strings.ToLower(validationErr.Error()), "timestamp") {
+		return err
+	}
+	t, parseErr := time.Parse(time.RFC3339Nano, ts)
+	if parseErr != nil {
+		return err
+	}
+
+	serverNow := time.Now()
+	known := skew != nil && !skew.MeasuredAt().IsZero()
+	if known {
+		serverNow = skew.Adjust(serverNow)
+	}
+	return &TimestampRejectedError{Timestamp: t, Drift: t.Sub(serverNow), SkewKnown: known, Err: err}
+}
+
+// furthestTimestamp returns the RFC 3339 timestamp in ts furthest from the
+// local clock, or "" if none parse.
+func furthestTimestamp(ts []string) string {
+	now := time.Now()
+	var furthest string
+	var maxDrift time.Duration = -1
+	for _, v := range ts {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			continue
+		}
+		drift := t.Sub(now)
+		if drift < 0 {
+			drift = -drift
+		}
+		if drift > maxDrift {
+			furthest, maxDrift = v, drift
+		}
+	}
+	return furthest
+}
@@ -0,0 +1,483 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain"
+)
+
+// errUsage reports invalid arguments; main prints the command's usage.
+var errUsage = errors.New("invalid arguments")
+
+// exportPageSize is the page size used by events export.
+const exportPageSize = 100
+
+func newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parse parses args and checks the number of positional arguments.
+func parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		return errUsage
+	}
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func parseMetadata(s string) (map[string]interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		return nil, fmt.Errorf("invalid -meta JSON: %w", err)
+	}
+	return m, nil
+}
+
+func runAttest(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("attest")
+	user := fs.String("user", "cli", "user ID")
+	eventType := fs.String("type", "", "event type")
+	meta := fs.String("meta", "", "metadata as a JSON object")
+	encrypt := fs.Bool("encrypt", false, "encrypt the document")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	metadata, err := parseMetadata(*meta)
+	if err != nil {
+		return err
+	}
+
+	result, err := client.Documents.Attest(ctx, &proofchain.AttestRequest{
+		FilePath:  fs.Arg(0),
+		UserID:    *user,
+		EventType: *eventType,
+		Metadata:  metadata,
+		Encrypt:   *encrypt,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(result)
+}
+
+func runVerify(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("verify")
+	file := fs.String("file", "", "verify a local document")
+	hash := fs.String("hash", "", "expected IPFS hash of -file")
+	if err := parse(fs, args, 0, 1); err != nil {
+		return err
+	}
+
+	if *file != "" {
+		result, err := client.VerifyResource.Document(ctx, *file, *hash)
+		if err != nil {
+			return err
+		}
+		return printJSON(result)
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+	result, err := client.Verify(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return printJSON(result)
+}
+
+func eventFilterFlags(fs *flag.FlagSet) *proofchain.ListEventsRequest {
+	req := &proofchain.ListEventsRequest{}
+	fs.StringVar(&req.UserID, "user", "", "filter by user ID")
+	fs.StringVar(&req.EventType, "type", "", "filter by event type")
+	fs.StringVar(&req.Status, "status", "", "filter by status")
+	fs.StringVar(&req.StartDate, "from", "", "start date (YYYY-MM-DD)")
+	fs.StringVar(&req.EndDate, "to", "", "end date (YYYY-MM-DD)")
+	return req
+}
+
+func runEventsList(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("events list")
+	req := eventFilterFlags(fs)
+	fs.IntVar(&req.Limit, "limit", 50, "maximum events")
+	fs.IntVar(&req.Offset, "offset", 0, "events to skip")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	events, err := client.Events.List(ctx, req)
+	if err != nil {
+		return err
+	}
+	return printJSON(events)
+}
+
+func runEventsExport(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("events export")
+	req := eventFilterFlags(fs)
+	out := fs.String("o", "", "output file (default stdout)")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	total := 0
+	req.Limit = exportPageSize
+	for req.Offset = 0; ; req.Offset += exportPageSize {
+		events, err := client.Events.List(ctx, req)
+		if err != nil {
+			return err
+		}
+		for i := range events {
+			if err := enc.Encode(&events[i]); err != nil {
+				return err
+			}
+		}
+		total += len(events)
+		if len(events) < exportPageSize {
+			break
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d events\n", total)
+	return nil
+}
+
+func runChannelsCreate(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("channels create")
+	description := fs.String("description", "", "channel description")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	channel, err := client.Channels.Create(ctx, &proofchain.CreateChannelRequest{
+		Name:        fs.Arg(0),
+		Description: *description,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(channel)
+}
+
+// runChannelsStream streams events read as JSON lines, one
+// StreamEventRequest per line, from -f or stdin.
+func runChannelsStream(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("channels stream")
+	file := fs.String("f", "", "JSON lines file of events (default stdin)")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	channelID := fs.Arg(0)
+
+	r := io.Reader(os.Stdin)
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	dec := json.NewDecoder(r)
+	streamed := 0
+	for {
+		var event proofchain.StreamEventRequest
+		err := dec.Decode(&event)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("event %d: %w", streamed+1, err)
+		}
+		if event.Source == "" {
+			event.Source = "cli"
+		}
+		if _, err := client.Channels.Stream(ctx, channelID, &event); err != nil {
+			return fmt.Errorf("event %d: %w", streamed+1, err)
+		}
+		streamed++
+	}
+	fmt.Fprintf(os.Stderr, "streamed %d events to %s\n", streamed, channelID)
+	return nil
+}
+
+func runChannelsSettle(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("channels settle")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	settlement, err := client.Channels.Settle(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return printJSON(settlement)
+}
+
+func runVaultUpload(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("vault upload")
+	user := fs.String("user", "cli", "user ID")
+	folder := fs.String("folder", "", "folder ID")
+	public := fs.Bool("public", false, "make the file public")
+	encrypt := fs.Bool("encrypt", false, "encrypt the file")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	req := &proofchain.VaultUploadRequest{
+		FilePath: fs.Arg(0),
+		UserID:   *user,
+		FolderID: *folder,
+		Encrypt:  *encrypt,
+	}
+	if *public {
+		req.AccessMode = "public"
+	}
+	file, err := client.Vault.Upload(ctx, req)
+	if err != nil {
+		return err
+	}
+	return printJSON(file)
+}
+
+func runVaultDownload(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("vault download")
+	out := fs.String("o", "", "output file (default: the file's name)")
+	resume := fs.Bool("resume", false, "continue a partial download in the output file")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	fileID := fs.Arg(0)
+
+	path := *out
+	if path == "" {
+		info, err := client.Vault.Get(ctx, fileID)
+		if err != nil {
+			return err
+		}
+		path = filepath.Base(info.Name)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if *resume {
+		flags = os.O_CREATE | os.O_RDWR
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	var n int64
+	if *resume {
+		n, err = client.Vault.ResumeDownloadTo(ctx, fileID, f)
+	} else {
+		n, err = client.Vault.DownloadTo(ctx, fileID, f)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "downloaded %d bytes to %s\n", offset+n, path)
+	return nil
+}
+
+func runCertificatesIssue(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("certificates issue")
+	name := fs.String("name", "", "recipient name")
+	email := fs.String("email", "", "recipient email")
+	title := fs.String("title", "", "certificate title")
+	description := fs.String("description", "", "certificate description")
+	meta := fs.String("meta", "", "metadata as a JSON object")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *name == "" || *title == "" {
+		return errUsage
+	}
+	metadata, err := parseMetadata(*meta)
+	if err != nil {
+		return err
+	}
+
+	cert, err := client.Certificates.Issue(ctx, &proofchain.IssueCertificateRequest{
+		RecipientName:  *name,
+		RecipientEmail: *email,
+		Title:          *title,
+		Description:    *description,
+		Metadata:       metadata,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(cert)
+}
+
+func runSchemasList(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("schemas list")
+	opts := &proofchain.ListSchemasOptions{}
+	fs.StringVar(&opts.Status, "status", "", "filter by status")
+	fs.StringVar(&opts.Search, "search", "", "search term")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	schemas, err := client.Schemas.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return printJSON(schemas)
+}
+
+func runSchemasGet(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("schemas get")
+	if err := parse(fs, args, 1, 2); err != nil {
+		return err
+	}
+
+	var version *string
+	if fs.NArg() == 2 {
+		v := fs.Arg(1)
+		version = &v
+	}
+	schema, err := client.Schemas.Get(ctx, fs.Arg(0), version)
+	if err != nil {
+		return err
+	}
+	return printJSON(schema)
+}
+
+func runSchemasCreate(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("schemas create")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	schema, err := client.Schemas.Create(ctx, string(content))
+	if err != nil {
+		return err
+	}
+	return printJSON(schema)
+}
+
+// runSchemasUpdate publishes a new schema version, refusing breaking changes
+// unless -force is given.
+func runSchemasUpdate(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("schemas update")
+	force := fs.Bool("force", false, "publish even if the change is breaking")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	name := fs.Arg(0)
+	content, err := os.ReadFile(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	if !*force {
+		diff, err := client.Schemas.CheckCompatibility(ctx, name, string(content))
+		if err != nil {
+			return err
+		}
+		if diff.IsBreaking() {
+			printJSON(diff)
+			return fmt.Errorf("schema change is breaking; rerun with -force to publish anyway")
+		}
+	}
+
+	schema, err := client.Schemas.Update(ctx, name, string(content))
+	if err != nil {
+		return err
+	}
+	return printJSON(schema)
+}
+
+func runSchemasDiff(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("schemas diff")
+	if err := parse(fs, args, 3, 3); err != nil {
+		return err
+	}
+
+	diff, err := client.Schemas.Diff(ctx, fs.Arg(0), fs.Arg(1), fs.Arg(2))
+	if err != nil {
+		return err
+	}
+	return printJSON(diff)
+}
+
+// runSchemasCheck exits non-zero when the proposed schema is breaking, for
+// use in CI.
+func runSchemasCheck(ctx context.Context, client *proofchain.Client, args []string) error {
+	fs := newFlags("schemas check")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	content, err := os.ReadFile(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff, err := client.Schemas.CheckCompatibility(ctx, fs.Arg(0), string(content))
+	if err != nil {
+		return err
+	}
+	if err := printJSON(diff); err != nil {
+		return err
+	}
+	if diff.IsBreaking() {
+		return fmt.Errorf("schema change is breaking")
+	}
+	return nil
+}
+
+func sortedSubcommands(subs map[string]command) []string {
+	names := make([]string, 0, len(subs))
+	for name := range subs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
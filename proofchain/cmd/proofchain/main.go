@@ -0,0 +1,202 @@
+// Command proofchain is a command-line interface to the ProofChain API.
+//
+// Usage:
+//
+//	proofchain [-config file] [-tenant id] <command> <subcommand> [flags] [args]
+//
+// Commands:
+//
+//	attest <file>                      attest a document
+//	verify <ipfs-hash> | -file <file>  verify an event or a local document
+//	events list | export               list events or export them as JSON lines
+//	channels create | stream | settle  manage state channels
+//	vault upload | download            transfer files to and from the vault
+//	certificates issue                 issue a certificate
+//	schemas list | get | create | update | diff | check
+//
+// The API key and base URL are read from PROOFCHAIN_API_KEY and
+// PROOFCHAIN_BASE_URL, falling back to a JSON config file (default
+// $HOME/.config/proofchain/config.json, or PROOFCHAIN_CONFIG):
+//
+//	{"api_key": "...", "base_url": "https://api.proofchain.co.za", "tenant_id": "..."}
+//
+// Results are written to stdout as JSON.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain"
+)
+
+// config is the contents of the CLI config file.
+type config struct {
+	APIKey   string `json:"api_key"`
+	BaseURL  string `json:"base_url"`
+	TenantID string `json:"tenant_id"`
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, client *proofchain.Client, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"attest": {
+		"": {"attest [-user id] [-type event-type] [-meta json] [-encrypt] <file>", runAttest},
+	},
+	"verify": {
+		"": {"verify <ipfs-hash> | verify -file <file> [-hash ipfs-hash]", runVerify},
+	},
+	"events": {
+		"list":   {"events list [-user id] [-type t] [-status s] [-from date] [-to date] [-limit n] [-offset n]", runEventsList},
+		"export": {"events export [-user id] [-type t] [-from date] [-to date] [-o file]", runEventsExport},
+	},
+	"channels": {
+		"create": {"channels create [-description d] <name>", runChannelsCreate},
+		"stream": {"channels stream <channel-id> [-f events.jsonl]", runChannelsStream},
+		"settle": {"channels settle <channel-id>", runChannelsSettle},
+	},
+	"vault": {
+		"upload":   {"vault upload [-user id] [-folder id] [-public] [-encrypt] <file>", runVaultUpload},
+		"download": {"vault download [-o file] [-resume] <file-id>", runVaultDownload},
+	},
+	"certificates": {
+		"issue": {"certificates issue -name n [-email e] -title t [-description d] [-meta json]", runCertificatesIssue},
+	},
+	"schemas": {
+		"list":   {"schemas list [-status s] [-search q]", runSchemasList},
+		"get":    {"schemas get <name> [version]", runSchemasGet},
+		"create": {"schemas create <file.yaml>", runSchemasCreate},
+		"update": {"schemas update [-force] <name> <file.yaml>", runSchemasUpdate},
+		"diff":   {"schemas diff <name> <version-a> <version-b>", runSchemasDiff},
+		"check":  {"schemas check <name> <file.yaml>", runSchemasCheck},
+	},
+}
+
+func main() {
+	flags := flag.NewFlagSet("proofchain", flag.ExitOnError)
+	configPath := flags.String("config", "", "config file (default $PROOFCHAIN_CONFIG or ~/.config/proofchain/config.json)")
+	tenant := flags.String("tenant", "", "tenant ID for partner API keys")
+	flags.Usage = usage
+	flags.Parse(os.Args[1:])
+
+	args := flags.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, rest, ok := lookup(args)
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fatal(err)
+	}
+	if *tenant != "" {
+		cfg.TenantID = *tenant
+	}
+	if cfg.APIKey == "" {
+		fatal(errors.New("no API key: set PROOFCHAIN_API_KEY or api_key in the config file"))
+	}
+
+	var opts []proofchain.HTTPClientOption
+	if cfg.BaseURL != "" {
+		opts = append(opts, proofchain.WithBaseURL(cfg.BaseURL))
+	}
+	client := proofchain.NewClient(cfg.APIKey, opts...)
+	if cfg.TenantID != "" {
+		client = client.WithTenant(cfg.TenantID)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := cmd.run(ctx, client, rest); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "usage: proofchain", cmd.usage)
+			os.Exit(2)
+		}
+		fatal(err)
+	}
+}
+
+// lookup resolves the command for args, returning the remaining arguments.
+func lookup(args []string) (command, []string, bool) {
+	subs, ok := commands[args[0]]
+	if !ok {
+		return command{}, nil, false
+	}
+	if cmd, ok := subs[""]; ok {
+		return cmd, args[1:], true
+	}
+	if len(args) < 2 {
+		return command{}, nil, false
+	}
+	cmd, ok := subs[args[1]]
+	return cmd, args[2:], ok
+}
+
+// loadConfig reads the config file, if any, and applies environment
+// overrides.
+func loadConfig(path string) (*config, error) {
+	explicit := path != ""
+	if path == "" {
+		path = os.Getenv("PROOFCHAIN_CONFIG")
+		explicit = path != ""
+	}
+	if path == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".config", "proofchain", "config.json")
+		}
+	}
+
+	cfg := &config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("parse config %s: %w", path, err)
+			}
+		case !errors.Is(err, os.ErrNotExist) || explicit:
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+	}
+
+	if v := os.Getenv("PROOFCHAIN_API_KEY"); v != "" {
+		cfg.APIKey = v
+	}
+	if v := os.Getenv("PROOFCHAIN_BASE_URL"); v != "" {
+		cfg.BaseURL = v
+	}
+	if v := os.Getenv("PROOFCHAIN_TENANT_ID"); v != "" {
+		cfg.TenantID = v
+	}
+	return cfg, nil
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: proofchain [-config file] [-tenant id] <command> [args]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, name := range []string{"attest", "verify", "events", "channels", "vault", "certificates", "schemas"} {
+		for _, sub := range sortedSubcommands(commands[name]) {
+			fmt.Fprintln(os.Stderr, "  "+commands[name][sub].usage)
+		}
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "proofchain:", err)
+	os.Exit(1)
+}
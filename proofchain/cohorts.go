@@ -13,19 +13,20 @@ import (
 
 // CohortDefinition represents a cohort scoring definition.
 type CohortDefinition struct {
-	ID          string   `json:"id"`
-	TenantID    string   `json:"tenant_id"`
-	Name        string   `json:"name"`
-	Slug        string   `json:"slug"`
-	Description *string  `json:"description,omitempty"`
-	ScoringType string   `json:"scoring_type"`
-	Icon        *string  `json:"icon,omitempty"`
-	Color       *string  `json:"color,omitempty"`
-	Status      string   `json:"status"`
-	AvgScore    *float64 `json:"avg_score,omitempty"`
-	TotalUsers  *int     `json:"total_users,omitempty"`
-	CreatedAt   string   `json:"created_at"`
-	UpdatedAt   string   `json:"updated_at"`
+	ID            string                 `json:"id"`
+	TenantID      string                 `json:"tenant_id"`
+	Name          string                 `json:"name"`
+	Slug          string                 `json:"slug"`
+	Description   *string                `json:"description,omitempty"`
+	ScoringType   string                 `json:"scoring_type"`
+	ScoringConfig map[string]interface{} `json:"scoring_config,omitempty"`
+	Icon          *string                `json:"icon,omitempty"`
+	Color         *string                `json:"color,omitempty"`
+	Status        string                 `json:"status"`
+	AvgScore      *float64               `json:"avg_score,omitempty"`
+	TotalUsers    *int                   `json:"total_users,omitempty"`
+	CreatedAt     string                 `json:"created_at"`
+	UpdatedAt     string                 `json:"updated_at"`
 }
 
 // LeaderboardUserProfile contains user profile data in leaderboard entries.
@@ -70,6 +71,7 @@ type CohortLeaderboardResponse struct {
 	Leaderboard              []CohortLeaderboardEntry `json:"leaderboard"`
 	CurrentUser              *CohortLeaderboardEntry  `json:"current_user,omitempty"`
 	CurrentUserInLeaderboard bool                     `json:"current_user_in_leaderboard"`
+	NextCursor               *string                  `json:"next_cursor,omitempty"`
 }
 
 // UserCohortBreakdownEntry is a single cohort entry in a user breakdown.
@@ -91,13 +93,48 @@ type UserBreakdownResponse struct {
 	Cohorts []UserCohortBreakdownEntry `json:"cohorts"`
 }
 
+// CohortRecomputeResult is returned when a cohort recompute is queued.
+type CohortRecomputeResult struct {
+	CohortID string `json:"cohort_id"`
+	Status   string `json:"status"`
+	JobID    string `json:"job_id,omitempty"`
+}
+
+// =============================================================================
+// Request types
+// =============================================================================
+
+// CreateCohortRequest creates a cohort scoring definition.
+type CreateCohortRequest struct {
+	Name          string                 `json:"name"`
+	Slug          string                 `json:"slug,omitempty"` // derived from Name if empty
+	Description   string                 `json:"description,omitempty"`
+	ScoringType   string                 `json:"scoring_type"`
+	ScoringConfig map[string]interface{} `json:"scoring_config,omitempty"`
+	Icon          string                 `json:"icon,omitempty"`
+	Color         string                 `json:"color,omitempty"`
+	Status        string                 `json:"status,omitempty"` // "active" (default) or "draft"
+}
+
+// UpdateCohortRequest updates a cohort scoring definition. Nil fields are
+// left unchanged.
+type UpdateCohortRequest struct {
+	Name          *string                `json:"name,omitempty"`
+	Description   *string                `json:"description,omitempty"`
+	ScoringType   *string                `json:"scoring_type,omitempty"`
+	ScoringConfig map[string]interface{} `json:"scoring_config,omitempty"`
+	Icon          *string                `json:"icon,omitempty"`
+	Color         *string                `json:"color,omitempty"`
+	Status        *string                `json:"status,omitempty"`
+}
+
 // =============================================================================
 // Options
 // =============================================================================
 
 // ListCohortsOptions configures the List query.
 type ListCohortsOptions struct {
-	Status string // "active", "inactive", "draft"
+	Status string // "active", "inactive", "draft", "archived"
 	Limit  int
 	Offset int
 }
@@ -110,13 +147,29 @@ type CohortLeaderboardOptions struct {
 	TopN    int
 	Fresh   bool
 	UserID  string
+	// Offset skips the first Offset entries; Cursor continues from a previous
+	// response's NextCursor. Set at most one of them.
+	Offset int
+	Cursor string
+	// AroundUserID returns Window entries above and below this user instead
+	// of the top of the leaderboard. See AroundUser.
+	AroundUserID string
+	Window       int
+}
+
+// AroundUser sets the options to return window entries above and below
+// userID, for "your rank ±N" widgets.
+func (o *CohortLeaderboardOptions) AroundUser(userID string, window int) *CohortLeaderboardOptions {
+	o.AroundUserID = userID
+	o.Window = window
+	return o
 }
 
 // =============================================================================
 // Client
 // =============================================================================
 
-// CohortLeaderboardClient provides cohort leaderboard operations.
+// CohortLeaderboardClient provides cohort definition and leaderboard operations.
 type CohortLeaderboardClient struct {
 	http *HTTPClient
 }
@@ -182,6 +235,18 @@ func (c *CohortLeaderboardClient) GetLeaderboard(ctx context.Context, cohortID s
 		if opts.UserID != "" {
 			params.Set("user_id", opts.UserID)
 		}
+		if opts.Offset > 0 {
+			params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+		if opts.AroundUserID != "" {
+			params.Set("around_user", opts.AroundUserID)
+			if opts.Window > 0 {
+				params.Set("window", fmt.Sprintf("%d", opts.Window))
+			}
+		}
 	}
 
 	var response CohortLeaderboardResponse
@@ -210,3 +275,45 @@ func (c *CohortLeaderboardClient) GetUserBreakdown(ctx context.Context, userID s
 	}
 	return &response, nil
 }
+
+// Create creates a cohort scoring definition.
+func (c *CohortLeaderboardClient) Create(ctx context.Context, req *CreateCohortRequest) (*CohortDefinition, error) {
+	var definition CohortDefinition
+	err := c.http.Post(ctx, "/cohorts/definitions", req, &definition)
+	if err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// Update updates a cohort scoring definition. Changes to scoring take effect
+// on the next recompute.
+func (c *CohortLeaderboardClient) Update(ctx context.Context, cohortID string, req *UpdateCohortRequest) (*CohortDefinition, error) {
+	var definition CohortDefinition
+	err := c.http.Patch(ctx, "/cohorts/definitions/"+url.PathEscape(cohortID), req, &definition)
+	if err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// Archive archives a cohort definition. Archived cohorts are no longer scored
+// and are hidden from leaderboards and user breakdowns.
+func (c *CohortLeaderboardClient) Archive(ctx context.Context, cohortID string) (*CohortDefinition, error) {
+	var definition CohortDefinition
+	err := c.http.Post(ctx, "/cohorts/definitions/"+url.PathEscape(cohortID)+"/archive", nil, &definition)
+	if err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// Recompute queues a recompute of every user's score in a cohort.
+func (c *CohortLeaderboardClient) Recompute(ctx context.Context, cohortID string) (*CohortRecomputeResult, error) {
+	var result CohortRecomputeResult
+	err := c.http.Post(ctx, "/cohorts/definitions/"+url.PathEscape(cohortID)+"/recompute", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
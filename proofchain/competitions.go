@@ -0,0 +1,320 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// CompetitionSource identifies the leaderboard a competition ranks users by.
+type CompetitionSource string
+
+const (
+	CompetitionSourceCohort  CompetitionSource = "cohort"
+	CompetitionSourceFanpass CompetitionSource = "fanpass"
+)
+
+// CompetitionStatus represents the lifecycle state of a competition.
+type CompetitionStatus string
+
+const (
+	CompetitionStatusScheduled   CompetitionStatus = "scheduled"
+	CompetitionStatusActive      CompetitionStatus = "active"
+	CompetitionStatusFinalized   CompetitionStatus = "finalized"
+	CompetitionStatusDistributed CompetitionStatus = "distributed"
+	CompetitionStatusCancelled   CompetitionStatus = "cancelled"
+)
+
+// CompetitionPrize awards a reward definition to a contiguous range of final ranks.
+// RankFrom and RankTo are inclusive and 1-based; a single-rank prize sets both to the same value.
+type CompetitionPrize struct {
+	RankFrom           int                    `json:"rank_from"`
+	RankTo             int                    `json:"rank_to"`
+	RewardDefinitionID string                 `json:"reward_definition_id"`
+	TriggerData        map[string]interface{} `json:"trigger_data,omitempty"`
+}
+
+// Competition represents a time-boxed competition ranked by a cohort or fanpass leaderboard.
+type Competition struct {
+	ID                string                 `json:"id"`
+	TenantID          string                 `json:"tenant_id"`
+	Name              string                 `json:"name"`
+	Slug              string                 `json:"slug"`
+	Description       *string                `json:"description,omitempty"`
+	Source            CompetitionSource      `json:"source"`
+	CohortID          *string                `json:"cohort_id,omitempty"`
+	AggregationRuleID *string                `json:"aggregation_rule_id,omitempty"`
+	Filters           map[string]string      `json:"filters,omitempty"`
+	StartsAt          time.Time              `json:"starts_at"`
+	EndsAt            time.Time              `json:"ends_at"`
+	Prizes            []CompetitionPrize     `json:"prizes"`
+	AutoFinalize      bool                   `json:"auto_finalize"`
+	AutoDistribute    bool                   `json:"auto_distribute"`
+	Status            CompetitionStatus      `json:"status"`
+	FinalizedAt       *time.Time             `json:"finalized_at,omitempty"`
+	DistributedAt     *time.Time             `json:"distributed_at,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+// CompetitionStanding is a single ranked entry in a competition.
+type CompetitionStanding struct {
+	Rank               int                     `json:"rank"`
+	UserID             string                  `json:"user_id"`
+	Score              float64                 `json:"score"`
+	Percentile         float64                 `json:"percentile"`
+	RewardDefinitionID *string                 `json:"reward_definition_id,omitempty"`
+	User               *LeaderboardUserProfile `json:"user,omitempty"`
+}
+
+// CompetitionStandingsResponse contains the live or final standings of a competition.
+type CompetitionStandingsResponse struct {
+	CompetitionID string                `json:"competition_id"`
+	Status        CompetitionStatus     `json:"status"`
+	Final         bool                  `json:"final"`
+	SnapshotAt    *time.Time            `json:"snapshot_at,omitempty"`
+	TotalUsers    int                   `json:"total_users"`
+	Standings     []CompetitionStanding `json:"standings"`
+}
+
+// CompetitionPrizeAward is the outcome of distributing a prize to a single user.
+type CompetitionPrizeAward struct {
+	Rank               int     `json:"rank"`
+	UserID             string  `json:"user_id"`
+	RewardDefinitionID string  `json:"reward_definition_id"`
+	EarnedRewardID     *string `json:"earned_reward_id,omitempty"`
+	Status             string  `json:"status"`
+	Error              *string `json:"error,omitempty"`
+}
+
+// CompetitionDistributionResult is the result of distributing competition prizes.
+type CompetitionDistributionResult struct {
+	CompetitionID string                  `json:"competition_id"`
+	TotalAwarded  int                     `json:"total_awarded"`
+	TotalFailed   int                     `json:"total_failed"`
+	Awards        []CompetitionPrizeAward `json:"awards"`
+}
+
+// =============================================================================
+// Request types
+// =============================================================================
+
+// CreateCompetitionRequest defines a new competition.
+//
+// Set CohortID when Source is CompetitionSourceCohort. For CompetitionSourceFanpass,
+// AggregationRuleID optionally selects the fanpass aggregation rule.
+// When AutoFinalize is set the platform snapshots final standings at EndsAt, and
+// when AutoDistribute is also set it awards Prizes immediately afterwards.
+type CreateCompetitionRequest struct {
+	Name              string                 `json:"name"`
+	Slug              string                 `json:"slug,omitempty"`
+	Description       *string                `json:"description,omitempty"`
+	Source            CompetitionSource      `json:"source"`
+	CohortID          *string                `json:"cohort_id,omitempty"`
+	AggregationRuleID *string                `json:"aggregation_rule_id,omitempty"`
+	Filters           map[string]string      `json:"filters,omitempty"`
+	StartsAt          time.Time              `json:"starts_at"`
+	EndsAt            time.Time              `json:"ends_at"`
+	Prizes            []CompetitionPrize     `json:"prizes,omitempty"`
+	AutoFinalize      bool                   `json:"auto_finalize,omitempty"`
+	AutoDistribute    bool                   `json:"auto_distribute,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// UpdateCompetitionRequest updates a competition that has not been finalized.
+type UpdateCompetitionRequest struct {
+	Name           *string                `json:"name,omitempty"`
+	Description    *string                `json:"description,omitempty"`
+	Filters        map[string]string      `json:"filters,omitempty"`
+	StartsAt       *time.Time             `json:"starts_at,omitempty"`
+	EndsAt         *time.Time             `json:"ends_at,omitempty"`
+	Prizes         []CompetitionPrize     `json:"prizes,omitempty"`
+	AutoFinalize   *bool                  `json:"auto_finalize,omitempty"`
+	AutoDistribute *bool                  `json:"auto_distribute,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ListCompetitionsOptions configures the List query.
+type ListCompetitionsOptions struct {
+	Status string
+	Source string
+	Limit  int
+	Offset int
+}
+
+// CompetitionStandingsOptions configures the GetStandings query.
+type CompetitionStandingsOptions struct {
+	Limit  int
+	Offset int
+	UserID string
+}
+
+// =============================================================================
+// Client
+// =============================================================================
+
+// CompetitionsClient provides time-boxed competition operations built on
+// cohort and fanpass leaderboards.
+type CompetitionsClient struct {
+	http *HTTPClient
+}
+
+// NewCompetitionsClient creates a new competitions client.
+func NewCompetitionsClient(http *HTTPClient) *CompetitionsClient {
+	return &CompetitionsClient{http: http}
+}
+
+// List returns competitions.
+func (c *CompetitionsClient) List(ctx context.Context, opts *ListCompetitionsOptions) ([]Competition, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Status != "" {
+			params.Set("status", opts.Status)
+		}
+		if opts.Source != "" {
+			params.Set("source", opts.Source)
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		if opts.Offset > 0 {
+			params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+		}
+	}
+
+	var competitions []Competition
+	err := c.http.Get(ctx, "/competitions", params, &competitions)
+	if err != nil {
+		return nil, err
+	}
+	return competitions, nil
+}
+
+// Get returns a competition by ID.
+func (c *CompetitionsClient) Get(ctx context.Context, competitionID string) (*Competition, error) {
+	var competition Competition
+	err := c.http.Get(ctx, "/competitions/"+url.PathEscape(competitionID), nil, &competition)
+	if err != nil {
+		return nil, err
+	}
+	return &competition, nil
+}
+
+// Create defines a new competition.
+func (c *CompetitionsClient) Create(ctx context.Context, req *CreateCompetitionRequest) (*Competition, error) {
+	if err := validateCompetitionRequest(req); err != nil {
+		return nil, err
+	}
+
+	var competition Competition
+	err := c.http.Post(ctx, "/competitions", req, &competition)
+	if err != nil {
+		return nil, err
+	}
+	return &competition, nil
+}
+
+// Update updates a competition that has not been finalized.
+func (c *CompetitionsClient) Update(ctx context.Context, competitionID string, req *UpdateCompetitionRequest) (*Competition, error) {
+	var competition Competition
+	err := c.http.Patch(ctx, "/competitions/"+url.PathEscape(competitionID), req, &competition)
+	if err != nil {
+		return nil, err
+	}
+	return &competition, nil
+}
+
+// Cancel cancels a competition. Cancelled competitions are never finalized or distributed.
+func (c *CompetitionsClient) Cancel(ctx context.Context, competitionID string) (*Competition, error) {
+	var competition Competition
+	err := c.http.Post(ctx, "/competitions/"+url.PathEscape(competitionID)+"/cancel", nil, &competition)
+	if err != nil {
+		return nil, err
+	}
+	return &competition, nil
+}
+
+// Delete deletes a competition.
+func (c *CompetitionsClient) Delete(ctx context.Context, competitionID string) error {
+	return c.http.Delete(ctx, "/competitions/"+url.PathEscape(competitionID))
+}
+
+// GetStandings returns the competition standings. Before finalization these are
+// live leaderboard standings; afterwards they are the frozen final snapshot.
+func (c *CompetitionsClient) GetStandings(ctx context.Context, competitionID string, opts *CompetitionStandingsOptions) (*CompetitionStandingsResponse, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Limit > 0 {
+			params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		if opts.Offset > 0 {
+			params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+		}
+		if opts.UserID != "" {
+			params.Set("user_id", opts.UserID)
+		}
+	}
+
+	var response CompetitionStandingsResponse
+	err := c.http.Get(ctx, "/competitions/"+url.PathEscape(competitionID)+"/standings", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Finalize snapshots the final standings immediately instead of waiting for EndsAt.
+func (c *CompetitionsClient) Finalize(ctx context.Context, competitionID string) (*CompetitionStandingsResponse, error) {
+	var response CompetitionStandingsResponse
+	err := c.http.Post(ctx, "/competitions/"+url.PathEscape(competitionID)+"/finalize", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DistributePrizes awards the configured prizes to the top-ranked users of a
+// finalized competition. Distribution is idempotent: users that already
+// received their prize are skipped.
+func (c *CompetitionsClient) DistributePrizes(ctx context.Context, competitionID string) (*CompetitionDistributionResult, error) {
+	var result CompetitionDistributionResult
+	err := c.http.Post(ctx, "/competitions/"+url.PathEscape(competitionID)+"/distribute", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func validateCompetitionRequest(req *CreateCompetitionRequest) error {
+	var details []ValidationErrorDetail
+	switch req.Source {
+	case CompetitionSourceCohort:
+		if req.CohortID == nil || *req.CohortID == "" {
+			details = append(details, ValidationErrorDetail{Field: "cohort_id", Message: "required for cohort competitions"})
+		}
+	case CompetitionSourceFanpass:
+	default:
+		details = append(details, ValidationErrorDetail{Field: "source", Message: "must be \"cohort\" or \"fanpass\""})
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		details = append(details, ValidationErrorDetail{Field: "ends_at", Message: "must be after starts_at"})
+	}
+	for i, prize := range req.Prizes {
+		if prize.RankFrom < 1 || prize.RankTo < prize.RankFrom {
+			details = append(details, ValidationErrorDetail{Field: fmt.Sprintf("prizes[%d]", i), Message: "invalid rank range"})
+		}
+		if prize.RewardDefinitionID == "" {
+			details = append(details, ValidationErrorDetail{Field: fmt.Sprintf("prizes[%d].reward_definition_id", i), Message: "required"})
+		}
+	}
+	if len(details) > 0 {
+		return NewValidationError("invalid competition definition", details)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package proofchain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ConsistencyToken identifies a write so that subsequent reads can observe it.
+// It is returned by Events.Create and IngestionClient.Ingest and can be attached
+// to a context with ContextWithConsistencyToken.
+type ConsistencyToken string
+
+type consistencyTokenKey struct{}
+
+// consistencyRetryDelays are the backoff delays used when a read carrying a
+// consistency token does not yet see the written event.
+var consistencyRetryDelays = []time.Duration{
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	400 * time.Millisecond,
+	800 * time.Millisecond,
+	1600 * time.Millisecond,
+}
+
+// ContextWithConsistencyToken returns a context that makes reads observe the
+// write identified by token. Requests made with the returned context carry an
+// X-Consistency-Token header so the API can route them to an up-to-date replica,
+// and Events.Get retries briefly while the event is not yet visible.
+//
+// Example:
+//
+//	event, _ := client.Events.Create(ctx, req)
+//	readCtx := proofchain.ContextWithConsistencyToken(ctx, event.ConsistencyToken)
+//	events, _ := client.Events.List(readCtx, &proofchain.ListEventsRequest{UserID: req.UserID})
+func ContextWithConsistencyToken(ctx context.Context, token ConsistencyToken) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, consistencyTokenKey{}, token)
+}
+
+// ConsistencyTokenFromContext returns the consistency token attached to ctx, if any.
+func ConsistencyTokenFromContext(ctx context.Context) (ConsistencyToken, bool) {
+	token, ok := ctx.Value(consistencyTokenKey{}).(ConsistencyToken)
+	return token, ok && token != ""
+}
+
+// setConsistencyHeader adds the X-Consistency-Token header when the request
+// context carries a consistency token.
+func setConsistencyHeader(req *http.Request) {
+	if token, ok := ConsistencyTokenFromContext(req.Context()); ok {
+		req.Header.Set("X-Consistency-Token", string(token))
+	}
+}
+
+// retryUntilVisible calls fn, retrying with backoff while it returns a
+// NotFoundError and ctx carries a consistency token.
+func retryUntilVisible(ctx context.Context, fn func() error) error {
+	err := fn()
+	if _, ok := ConsistencyTokenFromContext(ctx); !ok {
+		return err
+	}
+
+	for _, delay := range consistencyRetryDelays {
+		if _, notFound := err.(*NotFoundError); !notFound {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return NewTimeoutError()
+		case <-time.After(delay):
+		}
+		err = fn()
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+)
+
+// ContractCallRequest describes a call to a smart contract function
+type ContractCallRequest struct {
+	// Address of the contract.
+	Address string `json:"address"`
+	// Method is the function name, or its full signature such as
+	// "stake(uint256,uint64)" when ABI is omitted or the name is overloaded.
+	Method string `json:"method"`
+	// ABI is the contract ABI, or a fragment containing Method, used to
+	// encode Args and decode the result. Optional when Method is a full
+	// signature and the result is not needed.
+	ABI json.RawMessage `json:"abi,omitempty"`
+	// Args are the function arguments in order. Pass integers wider than 53
+	// bits as decimal strings.
+	Args []interface{} `json:"args,omitempty"`
+	// Value is native currency to send with the call, in ether units, e.g.
+	// "0.01". Ignored by ReadContract.
+	Value   string `json:"value,omitempty"`
+	Network string `json:"network,omitempty"`
+	// GasLimit overrides gas estimation.
+	GasLimit *int64 `json:"gas_limit,omitempty"`
+}
+
+// ContractCallResult is the outcome of CallContract. For smart account
+// wallets the call is sent as a user operation, subject to the tenant's gas
+// policies.
+type ContractCallResult struct {
+	TxHash     string  `json:"tx_hash"`
+	UserOpHash *string `json:"user_op_hash,omitempty"`
+	Status     string  `json:"status"`
+	// Result holds the function's return values decoded with the ABI, as
+	// returned by simulating the call before it was sent.
+	Result  []interface{} `json:"result,omitempty"`
+	GasUsed string        `json:"gas_used,omitempty"`
+	Network string        `json:"network"`
+}
+
+// ContractReadResult is the outcome of ReadContract
+type ContractReadResult struct {
+	Result      []interface{} `json:"result"`
+	BlockNumber int64         `json:"block_number"`
+	Network     string        `json:"network"`
+}
+
+// CallContract sends a state-changing contract call from a managed wallet,
+// so wallets such as users' smart accounts can interact with custom
+// contracts without exporting keys.
+//
+// Example:
+//
+//	res, err := client.Wallets.CallContract(ctx, walletID, &proofchain.ContractCallRequest{
+//	    Address: stakingContract,
+//	    Method:  "stake(uint256)",
+//	    Args:    []interface{}{"1000000000000000000"},
+//	})
+func (w *WalletClient) CallContract(ctx context.Context, walletID string, req *ContractCallRequest) (*ContractCallResult, error) {
+	if err := validateContractCall(req); err != nil {
+		return nil, err
+	}
+	if req.Value != "" {
+		if v, ok := new(big.Rat).SetString(req.Value); !ok || v.Sign() < 0 {
+			return nil, NewValidationError("invalid call value", []ValidationErrorDetail{
+				{Field: "value", Message: "must be a non-negative decimal amount"},
+			})
+		}
+	}
+
+	var result ContractCallResult
+	err := w.http.Post(ctx, "/wallets/"+walletID+"/contract-call", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ReadContract calls a view or pure contract function and returns its
+// decoded result. No transaction is sent. ABI is required to decode the
+// result.
+func (w *WalletClient) ReadContract(ctx context.Context, req *ContractCallRequest) (*ContractReadResult, error) {
+	if err := validateContractCall(req); err != nil {
+		return nil, err
+	}
+	if len(req.ABI) == 0 {
+		return nil, NewValidationError("ABI is required to decode the result", []ValidationErrorDetail{
+			{Field: "abi", Message: "must not be empty"},
+		})
+	}
+
+	read := *req
+	read.Value = ""
+	read.GasLimit = nil
+
+	var result ContractReadResult
+	err := w.http.Post(ctx, "/wallets/contracts/read", &read, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func validateContractCall(req *ContractCallRequest) error {
+	if err := validateAddress(req.Network, req.Address); err != nil {
+		return err
+	}
+	if req.Method == "" {
+		return NewValidationError("method is required", []ValidationErrorDetail{
+			{Field: "method", Message: "must be a function name or signature"},
+		})
+	}
+	if len(req.ABI) > 0 && !json.Valid(req.ABI) {
+		return NewValidationError("invalid ABI", []ValidationErrorDetail{
+			{Field: "abi", Message: "must be valid JSON"},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package proofchain
+
+import "fmt"
+
+// Computation types accepted by data views.
+const (
+	ComputationTypeScore     = "score"
+	ComputationTypeAggregate = "aggregate"
+	ComputationTypeTier      = "tier"
+)
+
+// Aggregate operations accepted by aggregate computations.
+const (
+	AggregateSum   = "sum"
+	AggregateAvg   = "avg"
+	AggregateMin   = "min"
+	AggregateMax   = "max"
+	AggregateCount = "count"
+)
+
+// ComputationBuilder builds a validated DataViewComputation.
+type ComputationBuilder interface {
+	Build() (*DataViewComputation, error)
+}
+
+// ComputationPipeline builds several computations for a multi-step view, in
+// order. The result can be used as CreateDataViewRequest.Computation.
+//
+// Example:
+//
+//	pipeline, err := proofchain.ComputationPipeline(
+//		proofchain.NewScoreComputation().Events("purchase", "share").Decay(0.1).Window(90),
+//		proofchain.NewTierComputation("score").Tier("bronze", 0, 50).Tier("gold", 50, 100),
+//	)
+func ComputationPipeline(builders ...ComputationBuilder) ([]DataViewComputation, error) {
+	if len(builders) == 0 {
+		return nil, NewValidationError("pipeline is empty", nil)
+	}
+	pipeline := make([]DataViewComputation, 0, len(builders))
+	for i, b := range builders {
+		c, err := b.Build()
+		if err != nil {
+			return nil, fmt.Errorf("computation %d: %w", i, err)
+		}
+		pipeline = append(pipeline, *c)
+	}
+	return pipeline, nil
+}
+
+// -----------------------------------------------------------------------------
+// Score
+// -----------------------------------------------------------------------------
+
+// ScoreComputationBuilder builds a weighted, optionally decaying score over
+// event types.
+type ScoreComputationBuilder struct {
+	c DataViewComputation
+}
+
+// NewScoreComputation starts a score computation.
+func NewScoreComputation() *ScoreComputationBuilder {
+	return &ScoreComputationBuilder{c: DataViewComputation{Type: ComputationTypeScore}}
+}
+
+// Name sets the output name of the computation.
+func (b *ScoreComputationBuilder) Name(name string) *ScoreComputationBuilder {
+	b.c.Name = &name
+	return b
+}
+
+// Events sets the event types that contribute to the score.
+func (b *ScoreComputationBuilder) Events(eventTypes ...string) *ScoreComputationBuilder {
+	b.c.EventTypes = append(b.c.EventTypes, eventTypes...)
+	return b
+}
+
+// Weights sets per-event-type weights. Event types without a weight count 1.
+func (b *ScoreComputationBuilder) Weights(weights map[string]float64) *ScoreComputationBuilder {
+	for eventType, w := range weights {
+		b.Weight(eventType, w)
+	}
+	return b
+}
+
+// Weight sets the weight of a single event type.
+func (b *ScoreComputationBuilder) Weight(eventType string, weight float64) *ScoreComputationBuilder {
+	if b.c.EventWeights == nil {
+		b.c.EventWeights = make(map[string]float64)
+	}
+	b.c.EventWeights[eventType] = weight
+	return b
+}
+
+// Decay sets the daily decay rate applied to older events (0 to 1).
+func (b *ScoreComputationBuilder) Decay(rate float64) *ScoreComputationBuilder {
+	b.c.DecayRate = &rate
+	return b
+}
+
+// Window limits the computation to events from the last days days.
+func (b *ScoreComputationBuilder) Window(days int) *ScoreComputationBuilder {
+	b.c.TimeWindowDays = &days
+	return b
+}
+
+// MaxScore caps the score.
+func (b *ScoreComputationBuilder) MaxScore(max float64) *ScoreComputationBuilder {
+	b.c.MaxScore = &max
+	return b
+}
+
+// Build validates and returns the computation.
+func (b *ScoreComputationBuilder) Build() (*DataViewComputation, error) {
+	var details []ValidationErrorDetail
+	if len(b.c.EventTypes) == 0 {
+		details = append(details, ValidationErrorDetail{Field: "event_types", Message: "at least one event type is required"})
+	}
+	for eventType := range b.c.EventWeights {
+		if !containsString(b.c.EventTypes, eventType) {
+			details = append(details, ValidationErrorDetail{Field: "event_weights", Message: fmt.Sprintf("weight for %q which is not in event_types", eventType)})
+		}
+	}
+	if b.c.DecayRate != nil && (*b.c.DecayRate < 0 || *b.c.DecayRate > 1) {
+		details = append(details, ValidationErrorDetail{Field: "decay_rate", Message: "must be between 0 and 1"})
+	}
+	details = append(details, checkWindow(b.c.TimeWindowDays)...)
+	if b.c.MaxScore != nil && *b.c.MaxScore <= 0 {
+		details = append(details, ValidationErrorDetail{Field: "max_score", Message: "must be positive"})
+	}
+	return buildComputation(b.c, details)
+}
+
+// -----------------------------------------------------------------------------
+// Aggregate
+// -----------------------------------------------------------------------------
+
+// AggregateComputationBuilder builds an aggregate (sum, avg, ...) over a data
+// field.
+type AggregateComputationBuilder struct {
+	c DataViewComputation
+}
+
+// NewAggregateComputation starts an aggregate of field using operation (one
+// of the Aggregate* constants).
+func NewAggregateComputation(field, operation string) *AggregateComputationBuilder {
+	return &AggregateComputationBuilder{c: DataViewComputation{
+		Type:      ComputationTypeAggregate,
+		Field:     &field,
+		Operation: &operation,
+	}}
+}
+
+// Name sets the output name of the computation.
+func (b *AggregateComputationBuilder) Name(name string) *AggregateComputationBuilder {
+	b.c.Name = &name
+	return b
+}
+
+// Events restricts the aggregate to the given event types.
+func (b *AggregateComputationBuilder) Events(eventTypes ...string) *AggregateComputationBuilder {
+	b.c.EventTypes = append(b.c.EventTypes, eventTypes...)
+	return b
+}
+
+// GroupBy groups the aggregate by a data field.
+func (b *AggregateComputationBuilder) GroupBy(field string) *AggregateComputationBuilder {
+	b.c.GroupBy = &field
+	return b
+}
+
+// Window limits the computation to events from the last days days.
+func (b *AggregateComputationBuilder) Window(days int) *AggregateComputationBuilder {
+	b.c.TimeWindowDays = &days
+	return b
+}
+
+// Limit limits the number of groups returned.
+func (b *AggregateComputationBuilder) Limit(n int) *AggregateComputationBuilder {
+	b.c.Limit = &n
+	return b
+}
+
+// Build validates and returns the computation.
+func (b *AggregateComputationBuilder) Build() (*DataViewComputation, error) {
+	var details []ValidationErrorDetail
+	if *b.c.Field == "" && *b.c.Operation != AggregateCount {
+		details = append(details, ValidationErrorDetail{Field: "field", Message: "is required"})
+	}
+	switch *b.c.Operation {
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax, AggregateCount:
+	default:
+		details = append(details, ValidationErrorDetail{Field: "operation", Message: fmt.Sprintf("unknown operation %q", *b.c.Operation)})
+	}
+	details = append(details, checkWindow(b.c.TimeWindowDays)...)
+	if b.c.Limit != nil && *b.c.Limit <= 0 {
+		details = append(details, ValidationErrorDetail{Field: "limit", Message: "must be positive"})
+	}
+	return buildComputation(b.c, details)
+}
+
+// -----------------------------------------------------------------------------
+// Tier
+// -----------------------------------------------------------------------------
+
+// TierComputationBuilder builds a tier assignment from a score.
+type TierComputationBuilder struct {
+	c DataViewComputation
+}
+
+// NewTierComputation starts a tier computation over scoreSource, the name of
+// an earlier computation in the pipeline.
+func NewTierComputation(scoreSource string) *TierComputationBuilder {
+	return &TierComputationBuilder{c: DataViewComputation{
+		Type:        ComputationTypeTier,
+		ScoreSource: &scoreSource,
+	}}
+}
+
+// Name sets the output name of the computation.
+func (b *TierComputationBuilder) Name(name string) *TierComputationBuilder {
+	b.c.Name = &name
+	return b
+}
+
+// Tier adds a tier covering scores in [min, max).
+func (b *TierComputationBuilder) Tier(name string, min, max float64) *TierComputationBuilder {
+	b.c.Tiers = append(b.c.Tiers, TierDefinition{Name: name, Min: min, Max: max})
+	return b
+}
+
+// Build validates and returns the computation.
+func (b *TierComputationBuilder) Build() (*DataViewComputation, error) {
+	var details []ValidationErrorDetail
+	if *b.c.ScoreSource == "" {
+		details = append(details, ValidationErrorDetail{Field: "score_source", Message: "is required"})
+	}
+	if len(b.c.Tiers) == 0 {
+		details = append(details, ValidationErrorDetail{Field: "tiers", Message: "at least one tier is required"})
+	}
+	for _, t := range b.c.Tiers {
+		if t.Name == "" {
+			details = append(details, ValidationErrorDetail{Field: "tiers", Message: "tier name is required"})
+		}
+		if t.Min >= t.Max {
+			details = append(details, ValidationErrorDetail{Field: "tiers", Message: fmt.Sprintf("tier %q: min must be less than max", t.Name)})
+		}
+	}
+	return buildComputation(b.c, details)
+}
+
+func checkWindow(days *int) []ValidationErrorDetail {
+	if days != nil && *days <= 0 {
+		return []ValidationErrorDetail{{Field: "time_window_days", Message: "must be positive"}}
+	}
+	return nil
+}
+
+func buildComputation(c DataViewComputation, details []ValidationErrorDetail) (*DataViewComputation, error) {
+	if len(details) > 0 {
+		return nil, NewValidationError("invalid "+c.Type+" computation", details)
+	}
+	return &c, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sync"
 )
 
 // =============================================================================
@@ -131,6 +132,33 @@ type ViewTemplate struct {
 	Computation DataViewComputation `json:"computation"`
 }
 
+// DataViewBulkResult is the result of executing a view for many identifiers.
+type DataViewBulkResult struct {
+	ViewName string                            `json:"view_name"`
+	Results  map[string]*DataViewExecuteResult `json:"results"`
+	// Errors maps identifiers that could not be computed to the reason.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// DataViewMaterialization describes a view's server-side refresh schedule.
+type DataViewMaterialization struct {
+	ViewName        string  `json:"view_name"`
+	Schedule        string  `json:"schedule"` // cron expression, e.g. "*/15 * * * *"
+	Status          string  `json:"status"`
+	LastRefreshedAt *string `json:"last_refreshed_at,omitempty"`
+	NextRefreshAt   *string `json:"next_refresh_at,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+// MaterializedViewResult is a precomputed view result read from the cache.
+type MaterializedViewResult struct {
+	DataViewExecuteResult
+	// MaterializedAt is when the cached result was computed.
+	MaterializedAt string `json:"materialized_at"`
+	// Stale is true if the last scheduled refresh failed or is overdue.
+	Stale bool `json:"stale"`
+}
+
 // =============================================================================
 // Request types
 // =============================================================================
@@ -163,6 +191,14 @@ type DataViewPreviewRequest struct {
 	Limit          *int        `json:"limit,omitempty"`
 }
 
+// ExecuteBulkOptions configures ExecuteBulk.
+type ExecuteBulkOptions struct {
+	// ChunkSize is the number of identifiers per request (default and max 500).
+	ChunkSize int
+	// Concurrency is the number of chunks executed in parallel (default 4).
+	Concurrency int
+}
+
 // =============================================================================
 // Client
 // =============================================================================
@@ -180,7 +216,7 @@ func NewDataViewsClient(http *HTTPClient) *DataViewsClient {
 // List returns all available data views (own, public, builtin).
 func (d *DataViewsClient) List(ctx context.Context) (*DataViewListResponse, error) {
 	var response DataViewListResponse
-	err := d.http.Get(ctx, "/data-mesh/views", nil, &response)
+	err := d.http.GetCached(ctx, "/data-mesh/views", nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -288,3 +324,118 @@ func (d *DataViewsClient) GetTemplates(ctx context.Context) ([]ViewTemplate, err
 	}
 	return result.Templates, nil
 }
+
+// ExecuteBulk executes a custom view for many identifiers (user IDs or wallet
+// addresses). Identifiers are sent in chunks to the bulk endpoint with bounded
+// concurrency and the results are merged into a single map. A chunk whose
+// request fails records the error against each of its identifiers rather than
+// failing the whole call. If ctx is cancelled, identifiers not yet sent are
+// recorded with the context error and a TimeoutError is returned along with
+// the partial result.
+func (d *DataViewsClient) ExecuteBulk(ctx context.Context, viewName string, identifiers []string, opts *ExecuteBulkOptions) (*DataViewBulkResult, error) {
+	chunkSize, concurrency := 500, 4
+	if opts != nil {
+		if opts.ChunkSize > 0 && opts.ChunkSize < chunkSize {
+			chunkSize = opts.ChunkSize
+		}
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+	}
+
+	result := &DataViewBulkResult{
+		ViewName: viewName,
+		Results:  make(map[string]*DataViewExecuteResult, len(identifiers)),
+		Errors:   make(map[string]string),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for start := 0; start < len(identifiers); start += chunkSize {
+		end := start + chunkSize
+		if end > len(identifiers) {
+			end = len(identifiers)
+		}
+		chunk := identifiers[start:end]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			for _, id := range identifiers[start:] {
+				result.Errors[id] = ctx.Err().Error()
+			}
+			return result, NewTimeoutError()
+		}
+
+		wg.Add(1)
+		go func(chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var resp struct {
+				Results []DataViewExecuteResult `json:"results"`
+				Errors  map[string]string       `json:"errors"`
+			}
+			err := d.http.Post(ctx, "/data-mesh/views/custom/"+url.PathEscape(viewName)+"/execute-bulk", map[string]interface{}{
+				"identifiers": chunk,
+			}, &resp)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				for _, id := range chunk {
+					result.Errors[id] = err.Error()
+				}
+				return
+			}
+			for i := range resp.Results {
+				r := resp.Results[i]
+				result.Results[r.Identifier] = &r
+			}
+			for id, msg := range resp.Errors {
+				result.Errors[id] = msg
+			}
+		}(chunk)
+	}
+
+	wg.Wait()
+	if ctx.Err() != nil {
+		return result, NewTimeoutError()
+	}
+	return result, nil
+}
+
+// Materialize schedules server-side precomputation of a view. schedule is a
+// cron expression; results are refreshed for every identifier on each run and
+// can be read with GetMaterialized. Calling Materialize again replaces the
+// schedule.
+func (d *DataViewsClient) Materialize(ctx context.Context, viewName, schedule string) (*DataViewMaterialization, error) {
+	if schedule == "" {
+		return nil, NewValidationError("schedule is required", []ValidationErrorDetail{
+			{Field: "schedule", Message: "must be a cron expression"},
+		})
+	}
+
+	var result DataViewMaterialization
+	err := d.http.Post(ctx, "/data-mesh/views/custom/"+url.PathEscape(viewName)+"/materialize", map[string]interface{}{
+		"schedule": schedule,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMaterialized returns the cached result of a materialized view for an
+// identifier, with the time it was computed.
+func (d *DataViewsClient) GetMaterialized(ctx context.Context, viewName, identifier string) (*MaterializedViewResult, error) {
+	var result MaterializedViewResult
+	err := d.http.Get(ctx, "/data-mesh/views/custom/"+url.PathEscape(viewName)+"/materialized/"+url.PathEscape(identifier), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
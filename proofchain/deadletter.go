@@ -0,0 +1,130 @@
+package proofchain
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// DeadLetter is an event that failed ingestion, with the exact payload that
+// was sent and the reason it failed. Exactly one of Event and GRPCEvent is set.
+type DeadLetter struct {
+	// Event is set for events sent via IngestionClient.
+	Event *IngestEventRequest `json:"event,omitempty"`
+	// GRPCEvent is set for events sent via GRPCClient.
+	GRPCEvent *GRPCEvent `json:"grpc_event,omitempty"`
+	// SchemaIDs and ClientRef carry the event fields of the same name,
+	// which the event's own JSON encoding leaves out, so a dead letter read
+	// back with ReadDeadLetters replays exactly as it was sent.
+	SchemaIDs []string `json:"schema_ids,omitempty"`
+	ClientRef string   `json:"client_ref,omitempty"`
+	// Transport is "rest" or "grpc".
+	Transport string `json:"transport"`
+	// Err is the failure; ErrorMessage is its text, for serialization.
+	Err          error     `json:"-"`
+	ErrorMessage string    `json:"error"`
+	FailedAt     time.Time `json:"failed_at"`
+}
+
+func newDeadLetter(transport string, err error) *DeadLetter {
+	return &DeadLetter{
+		Transport:    transport,
+		Err:          err,
+		ErrorMessage: err.Error(),
+		FailedAt:     time.Now().UTC(),
+	}
+}
+
+// DeadLetterSink receives events that failed server-side so they can be
+// inspected or replayed. Implementations must be safe for concurrent use;
+// errors returned by Write are ignored by the SDK.
+type DeadLetterSink interface {
+	Write(letter *DeadLetter) error
+}
+
+// DeadLetterFunc adapts a function to a DeadLetterSink.
+type DeadLetterFunc func(letter *DeadLetter) error
+
+// Write calls f(letter).
+func (f DeadLetterFunc) Write(letter *DeadLetter) error {
+	return f(letter)
+}
+
+// ChannelDeadLetterSink sends dead letters to a channel. Write blocks while
+// the channel is full, so size it for the expected failure volume.
+type ChannelDeadLetterSink chan<- *DeadLetter
+
+// Write sends letter on the channel.
+func (s ChannelDeadLetterSink) Write(letter *DeadLetter) error {
+	s <- letter
+	return nil
+}
+
+// FileDeadLetterSink appends dead letters to a file as JSON lines.
+type FileDeadLetterSink struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewFileDeadLetterSink opens (or creates) path for appending dead letters.
+func NewFileDeadLetterSink(path string) (*FileDeadLetterSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileDeadLetterSink{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Write appends letter as a single JSON line.
+func (s *FileDeadLetterSink) Write(letter *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(letter)
+}
+
+// Close closes the underlying file.
+func (s *FileDeadLetterSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// ReadDeadLetters reads dead letters written by FileDeadLetterSink, with
+// SchemaIDs and ClientRef restored on their events and Err set from
+// ErrorMessage, ready to replay.
+func ReadDeadLetters(r io.Reader) ([]*DeadLetter, error) {
+	var letters []*DeadLetter
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var letter DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			return letters, fmt.Errorf("dead letter line %d: %w", line, err)
+		}
+		if letter.Event != nil {
+			letter.Event.SchemaIDs = letter.SchemaIDs
+		}
+		if letter.GRPCEvent != nil {
+			letter.GRPCEvent.ClientRef = letter.ClientRef
+		}
+		if letter.ErrorMessage != "" {
+			letter.Err = errors.New(letter.ErrorMessage)
+		}
+		letters = append(letters, &letter)
+	}
+	return letters, scanner.Err()
+}
+
+// isFailedStatus reports whether a per-event ingestion status means failure.
+func isFailedStatus(status string) bool {
+	return status == "failed" || status == "error" || status == "rejected"
+}
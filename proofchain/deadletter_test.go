@@ -0,0 +1,144 @@
+package proofchain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestReadDeadLettersRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	sink, err := NewFileDeadLetterSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest := &IngestionClient{deadLetters: sink}
+	rest.deadLetter(&IngestEventRequest{
+		UserID:    "user-1",
+		EventType: "invoice.paid",
+		Data:      map[string]interface{}{"amount": 100},
+		SchemaIDs: []string{"invoice", "audit"},
+		ClientRef: "row-1",
+	}, errors.New("request failed"))
+	grpcClient := &GRPCClient{deadLetters: sink}
+	grpcClient.deadLetter(&GRPCEvent{UserID: "user-2", EventType: "login", ClientRef: "row-2"}, errors.New("stream closed"))
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	letters, err := ReadDeadLetters(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters) != 2 {
+		t.Fatalf("read %d dead letters, want 2", len(letters))
+	}
+
+	event := letters[0].Event
+	if event == nil || letters[0].Transport != "rest" {
+		t.Fatalf("first letter = %+v, want a rest event", letters[0])
+	}
+	if len(event.SchemaIDs) != 2 || event.SchemaIDs[0] != "invoice" || event.SchemaIDs[1] != "audit" {
+		t.Errorf("SchemaIDs = %v, want [invoice audit]", event.SchemaIDs)
+	}
+	if event.ClientRef != "row-1" {
+		t.Errorf("ClientRef = %q, want row-1", event.ClientRef)
+	}
+	if letters[0].Err == nil || letters[0].Err.Error() != "request failed" {
+		t.Errorf("Err = %v, want request failed", letters[0].Err)
+	}
+
+	if ev := letters[1].GRPCEvent; ev == nil || ev.ClientRef != "row-2" {
+		t.Errorf("second letter GRPCEvent = %+v, want ClientRef row-2", ev)
+	}
+}
+
+// brokenStreamServer answers the first event it received, then fails the
+// stream without answering the rest.
+type brokenStreamServer struct {
+	pb.UnimplementedEventServiceServer
+}
+
+func (brokenStreamServer) StreamEvents(stream grpc.BidiStreamingServer[pb.EventRequest, pb.EventResponse]) error {
+	var first *pb.EventRequest
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if first == nil {
+			first = req
+		}
+	}
+	if first != nil {
+		if err := stream.Send(&pb.EventResponse{EventId: "evt-1", Status: "queued", ClientRef: first.ClientRef}); err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.Unavailable, "server shutting down")
+}
+
+func TestGRPCDeadLettersUnansweredEvents(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterEventServiceServer(srv, brokenStreamServer{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var mu sync.Mutex
+	var refs []string
+	c := &GRPCClient{
+		conns: []*grpc.ClientConn{conn},
+		deadLetters: DeadLetterFunc(func(letter *DeadLetter) error {
+			mu.Lock()
+			defer mu.Unlock()
+			refs = append(refs, letter.ClientRef)
+			return nil
+		}),
+	}
+
+	stats, err := c.StreamEventsSlice(context.Background(), []*GRPCEvent{
+		{UserID: "u", EventType: "e", ClientRef: "a"},
+		{UserID: "u", EventType: "e", ClientRef: "b"},
+		{UserID: "u", EventType: "e", ClientRef: "c"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalSuccess != 1 || stats.TotalFailed != 2 {
+		t.Errorf("success/failed = %d/%d, want 1/2", stats.TotalSuccess, stats.TotalFailed)
+	}
+	sort.Strings(refs)
+	if len(refs) != 2 || refs[0] != "b" || refs[1] != "c" {
+		t.Errorf("dead-lettered %v, want [b c]", refs)
+	}
+}
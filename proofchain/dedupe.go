@@ -0,0 +1,113 @@
+package proofchain
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IngestStatusDuplicate is the status reported for events dropped
+// client-side by a dedupe window; they are never sent to the API.
+const IngestStatusDuplicate = "duplicate"
+
+// WithDedupeWindow drops events with the same user, event type and data as
+// one accepted within the last d, so double-sends from upstream producers do
+// not consume attestation quota. Dropped events are reported with status
+// IngestStatusDuplicate and counted by DuplicatesDropped. An event that
+// fails to ingest is forgotten, so retrying it is not treated as a
+// duplicate.
+func WithDedupeWindow(d time.Duration) IngestionClientOption {
+	return func(c *IngestionClient) {
+		if d > 0 {
+			c.dedupe = newDedupeWindow(d)
+		}
+	}
+}
+
+// WithGRPCDedupeWindow drops duplicate events within a sliding window of d
+// before they are streamed; see WithDedupeWindow. Dropped events are
+// reported in StreamStats.TotalDuplicates.
+func WithGRPCDedupeWindow(d time.Duration) GRPCClientOption {
+	return func(c *GRPCClient) {
+		if d > 0 {
+			c.dedupe = newDedupeWindow(d)
+		}
+	}
+}
+
+type dedupeKey [sha256.Size]byte
+
+// dedupeWindow remembers the content hashes of recently accepted events.
+type dedupeWindow struct {
+	window     time.Duration
+	duplicates atomic.Int64
+
+	mu        sync.Mutex
+	seen      map[dedupeKey]time.Time
+	lastSweep time.Time
+}
+
+func newDedupeWindow(d time.Duration) *dedupeWindow {
+	return &dedupeWindow{window: d, seen: make(map[dedupeKey]time.Time), lastSweep: time.Now()}
+}
+
+// eventKey hashes the fields that identify a logical event. Data is hashed
+// in its JSON encoding, which orders map keys, before any redaction.
+func eventKey(userID, eventType, documentHash string, data map[string]interface{}) dedupeKey {
+	h := sha256.New()
+	for _, s := range []string{userID, eventType, documentHash} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	if len(data) > 0 {
+		encoded, _ := json.Marshal(data)
+		h.Write(encoded)
+	}
+	var key dedupeKey
+	h.Sum(key[:0])
+	return key
+}
+
+// admit reports whether the event with key should be sent, recording it if
+// so and counting it as a duplicate otherwise.
+func (w *dedupeWindow) admit(key dedupeKey) bool {
+	now := time.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if now.Sub(w.lastSweep) >= w.window {
+		for k, t := range w.seen {
+			if now.Sub(t) >= w.window {
+				delete(w.seen, k)
+			}
+		}
+		w.lastSweep = now
+	}
+
+	if t, ok := w.seen[key]; ok && now.Sub(t) < w.window {
+		w.duplicates.Add(1)
+		return false
+	}
+	w.seen[key] = now
+	return true
+}
+
+// forget removes key so a retry of a failed event is admitted.
+func (w *dedupeWindow) forget(key dedupeKey) {
+	w.mu.Lock()
+	delete(w.seen, key)
+	w.mu.Unlock()
+}
+
+// DuplicatesDropped returns the number of events dropped by the dedupe
+// window since the client was created. It is zero unless WithDedupeWindow
+// is set.
+func (c *IngestionClient) DuplicatesDropped() int64 {
+	if c.dedupe == nil {
+		return 0
+	}
+	return c.dedupe.duplicates.Load()
+}
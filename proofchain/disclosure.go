@@ -0,0 +1,253 @@
+package proofchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fieldLeafPrefix domain-separates field leaves from interior Merkle nodes.
+const fieldLeafPrefix = "proofchain:field:v2"
+
+// fieldSaltSize is the size in bytes of each field's salt.
+const fieldSaltSize = 16
+
+// FieldTree is a Merkle tree over the top-level fields of event data. Each
+// leaf commits to a field name, a random salt and the field's JSON value, so
+// revealing sibling hashes in a proof discloses nothing about other fields.
+//
+// Keep the tree (in particular Salts) with the event: it is needed to prove
+// fields later and cannot be recovered from the attested data.
+type FieldTree struct {
+	// Root is the hex Merkle root, attested as the event's document hash.
+	Root string `json:"root"`
+	// Salts maps each field name to its hex salt.
+	Salts map[string]string `json:"salts"`
+}
+
+// FieldDisclosure proves the values of selected fields of an attested event
+// without revealing the others.
+type FieldDisclosure struct {
+	EventID string `json:"event_id,omitempty"`
+	// Root is the field tree root the proofs lead to.
+	Root string `json:"root"`
+	// Fields holds the disclosed values exactly as committed.
+	Fields map[string]json.RawMessage `json:"fields"`
+	Salts  map[string]string          `json:"salts"`
+	// Proofs holds each field's sibling hashes from leaf to root.
+	Proofs map[string][]string `json:"proofs"`
+}
+
+// BuildFieldTree builds a field tree over data with fresh random salts.
+func BuildFieldTree(data map[string]interface{}) (*FieldTree, error) {
+	if len(data) == 0 {
+		return nil, errors.New("proofchain: cannot build a field tree over empty data")
+	}
+	tree := &FieldTree{Salts: make(map[string]string, len(data))}
+	for name := range data {
+		salt := make([]byte, fieldSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		tree.Salts[name] = hex.EncodeToString(salt)
+	}
+
+	leaves, _, err := fieldLeaves(data, tree.Salts)
+	if err != nil {
+		return nil, err
+	}
+	levels := merkleLevels(leaves)
+	tree.Root = hex.EncodeToString(levels[len(levels)-1][0])
+	return tree, nil
+}
+
+// CreateDisclosable creates an event whose document hash is the root of a
+// field tree over its data, so individual fields can later be disclosed with
+// ProveFields. Data fields listed in req.Redact are redacted before the tree
+// is built. The returned tree must be stored by the caller.
+func (r *EventsResource) CreateDisclosable(ctx context.Context, req *CreateEventRequest) (*Event, *FieldTree, error) {
+	data, err := redactData(req.Data, req.Redact, req.RedactionKey, req.RedactOptional)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree, err := BuildFieldTree(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source := req.Source
+	if source == "" {
+		source = "api"
+	}
+	payload := map[string]interface{}{
+		"event_type":    req.EventType,
+		"user_id":       req.UserID,
+		"event_source":  source,
+		"data":          data,
+		"document_hash": tree.Root,
+	}
+
+	var result Event
+	if err := r.http.Post(ctx, "/tenant/events", payload, &result); err != nil {
+		return nil, nil, err
+	}
+	return &result, tree, nil
+}
+
+// ProveFields produces a disclosure of fields of event, which must have been
+// created with CreateDisclosable using tree. The proof is checked against
+// event.DocumentHash before it is returned.
+func ProveFields(event *Event, tree *FieldTree, fields []string) (*FieldDisclosure, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("proofchain: no fields to prove")
+	}
+	leaves, names, err := fieldLeaves(event.Data, tree.Salts)
+	if err != nil {
+		return nil, err
+	}
+	levels := merkleLevels(leaves)
+	root := hex.EncodeToString(levels[len(levels)-1][0])
+	if root != trimHex(strings.ToLower(tree.Root)) {
+		return nil, errors.New("proofchain: event data does not match the field tree")
+	}
+	if event.DocumentHash != nil && trimHex(strings.ToLower(*event.DocumentHash)) != root {
+		return nil, errors.New("proofchain: event document hash is not the field tree root")
+	}
+
+	d := &FieldDisclosure{
+		EventID: event.ID,
+		Root:    root,
+		Fields:  make(map[string]json.RawMessage, len(fields)),
+		Salts:   make(map[string]string, len(fields)),
+		Proofs:  make(map[string][]string, len(fields)),
+	}
+	for _, name := range fields {
+		index := sort.SearchStrings(names, name)
+		if index == len(names) || names[index] != name {
+			return nil, fmt.Errorf("proofchain: field %q is not in the event data", name)
+		}
+		value, _ := json.Marshal(event.Data[name])
+		d.Fields[name] = value
+		d.Salts[name] = tree.Salts[name]
+		d.Proofs[name] = merkleProof(levels, index)
+	}
+	return d, nil
+}
+
+// VerifyFieldDisclosure checks every disclosed field against documentHash,
+// the attested document hash of the event obtained independently (for
+// example from a proof bundle).
+func VerifyFieldDisclosure(d *FieldDisclosure, documentHash string) error {
+	want := trimHex(strings.ToLower(documentHash))
+	if trimHex(strings.ToLower(d.Root)) != want {
+		return errors.New("proofchain: disclosure root does not match the document hash")
+	}
+	if len(d.Fields) == 0 {
+		return errors.New("proofchain: disclosure has no fields")
+	}
+	for name, value := range d.Fields {
+		salt, err := hex.DecodeString(d.Salts[name])
+		if err != nil || len(salt) != fieldSaltSize {
+			return fmt.Errorf("proofchain: field %q has no valid salt", name)
+		}
+		leaf := fieldLeaf(name, salt, value)
+		root, err := computeMerkleRoot(hex.EncodeToString(leaf), d.Proofs[name])
+		if err != nil {
+			return fmt.Errorf("proofchain: field %q: %w", name, err)
+		}
+		if root != want {
+			return fmt.Errorf("proofchain: field %q does not match the document hash", name)
+		}
+	}
+	return nil
+}
+
+// fieldLeaves returns the leaf hashes of data in sorted field order, and the
+// sorted field names.
+func fieldLeaves(data map[string]interface{}, salts map[string]string) ([][]byte, []string, error) {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	leaves := make([][]byte, len(names))
+	for i, name := range names {
+		salt, err := hex.DecodeString(salts[name])
+		if err != nil || len(salt) != fieldSaltSize {
+			return nil, nil, fmt.Errorf("proofchain: no valid salt for field %q", name)
+		}
+		value, err := json.Marshal(data[name])
+		if err != nil {
+			return nil, nil, fmt.Errorf("proofchain: field %q: %w", name, err)
+		}
+		leaves[i] = fieldLeaf(name, salt, value)
+	}
+	if len(leaves) == 0 {
+		return nil, nil, errors.New("proofchain: event has no data fields")
+	}
+	return leaves, names, nil
+}
+
+// fieldLeaf hashes prefix‖0‖salt‖len(name)‖name‖value. The salt has a fixed
+// size and the name a 4-byte big-endian length, so no two (salt, name,
+// value) triples share an encoding.
+func fieldLeaf(name string, salt []byte, value []byte) []byte {
+	var nameLen [4]byte
+	binary.BigEndian.PutUint32(nameLen[:], uint32(len(name)))
+
+	h := sha256.New()
+	h.Write([]byte(fieldLeafPrefix))
+	h.Write([]byte{0})
+	h.Write(salt)
+	h.Write(nameLen[:])
+	h.Write([]byte(name))
+	h.Write(value)
+	return h.Sum(nil)
+}
+
+// merkleLevels builds the tree bottom-up using the sorted-pair hashing of
+// computeMerkleRoot. An odd node at the end of a level is carried up
+// unchanged. The last level holds the root.
+func merkleLevels(leaves [][]byte) [][][]byte {
+	levels := [][][]byte{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			a, b := level[i], level[i+1]
+			if bytes.Compare(a, b) > 0 {
+				a, b = b, a
+			}
+			sum := sha256.Sum256(append(append([]byte{}, a...), b...))
+			next = append(next, sum[:])
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return levels
+}
+
+// merkleProof returns the sibling hashes for the leaf at index.
+func merkleProof(levels [][][]byte, index int) []string {
+	var proof []string
+	for _, level := range levels[:len(levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, hex.EncodeToString(level[sibling]))
+		}
+		index /= 2
+	}
+	return proof
+}
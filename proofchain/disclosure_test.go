@@ -0,0 +1,85 @@
+package proofchain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func disclosableEvent(t *testing.T, data map[string]interface{}) (*Event, *FieldTree) {
+	t.Helper()
+	tree, err := BuildFieldTree(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := tree.Root
+	return &Event{ID: "evt_1", Data: data, DocumentHash: &root}, tree
+}
+
+func TestFieldDisclosureRoundTrip(t *testing.T) {
+	event, tree := disclosableEvent(t, map[string]interface{}{
+		"amount":   150.5,
+		"currency": "ZAR",
+		"payee":    map[string]interface{}{"name": "Acme"},
+	})
+	d, err := ProveFields(event, tree, []string{"amount", "payee"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Fields["currency"]; ok {
+		t.Fatal("undisclosed field included in disclosure")
+	}
+	if err := VerifyFieldDisclosure(d, *event.DocumentHash); err != nil {
+		t.Fatalf("valid disclosure rejected: %v", err)
+	}
+}
+
+func TestFieldDisclosureTamperedValue(t *testing.T) {
+	event, tree := disclosableEvent(t, map[string]interface{}{"amount": 100, "currency": "ZAR"})
+	d, err := ProveFields(event, tree, []string{"amount"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Fields["amount"] = json.RawMessage("900")
+	if err := VerifyFieldDisclosure(d, *event.DocumentHash); err == nil {
+		t.Fatal("tampered value accepted")
+	}
+}
+
+func TestFieldDisclosureWrongDocumentHash(t *testing.T) {
+	event, tree := disclosableEvent(t, map[string]interface{}{"amount": 100, "currency": "ZAR"})
+	d, err := ProveFields(event, tree, []string{"amount"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := disclosableEvent(t, map[string]interface{}{"amount": 100, "currency": "ZAR"})
+	if err := VerifyFieldDisclosure(d, *other.DocumentHash); err == nil {
+		t.Fatal("disclosure accepted against another event's hash")
+	}
+}
+
+// Moving a byte of the field name into the salt must not let a value be
+// presented under another field name.
+func TestFieldDisclosureRenamedField(t *testing.T) {
+	event, tree := disclosableEvent(t, map[string]interface{}{"amount": 100, "currency": "ZAR"})
+	d, err := ProveFields(event, tree, []string{"amount"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	salt, _ := hex.DecodeString(d.Salts["amount"])
+	renamed := &FieldDisclosure{
+		Root:   d.Root,
+		Fields: map[string]json.RawMessage{"mount": d.Fields["amount"]},
+		Salts:  map[string]string{"mount": hex.EncodeToString(append(salt, 'a'))},
+		Proofs: map[string][]string{"mount": d.Proofs["amount"]},
+	}
+	if err := VerifyFieldDisclosure(renamed, *event.DocumentHash); err == nil {
+		t.Fatal("value accepted under a renamed field")
+	}
+
+	renamed.Salts["mount"] = d.Salts["amount"]
+	if err := VerifyFieldDisclosure(renamed, *event.DocumentHash); err == nil {
+		t.Fatal("value accepted under a renamed field with the original salt")
+	}
+}
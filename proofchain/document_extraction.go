@@ -0,0 +1,47 @@
+package proofchain
+
+import (
+	"context"
+)
+
+// Document extraction statuses.
+const (
+	ExtractionPending     = "pending"
+	ExtractionCompleted   = "completed"
+	ExtractionFailed      = "failed"
+	ExtractionUnsupported = "unsupported" // File type cannot be extracted
+)
+
+// DocumentExtraction is the metadata extracted from an attested document
+// when ExtractText is set. Extraction runs after attestation, so Status is
+// usually "pending" in the attestation result; poll GetExtractedContent for
+// the outcome.
+type DocumentExtraction struct {
+	Status    string  `json:"status"`
+	PageCount int     `json:"page_count,omitempty"`
+	Language  string  `json:"language,omitempty"` // ISO 639-1 code, e.g. "en"
+	Title     string  `json:"title,omitempty"`
+	Error     *string `json:"error,omitempty"`
+}
+
+// ExtractedContent is the text extracted from an attested document, with
+// its metadata. OCR is used for scanned pages.
+type ExtractedContent struct {
+	IPFSHash string `json:"ipfs_hash"`
+	DocumentExtraction
+	Text string `json:"text"`
+	// Pages holds the text of each page, for paged formats such as PDF.
+	Pages       []string   `json:"pages,omitempty"`
+	ExtractedAt *Timestamp `json:"extracted_at,omitempty"`
+}
+
+// GetExtractedContent returns the text and metadata extracted from a
+// document attested with ExtractText.
+func (r *DocumentsResource) GetExtractedContent(ctx context.Context, ipfsHash string) (*ExtractedContent, error) {
+	var result ExtractedContent
+	err := r.http.Get(ctx, "/tenant/documents/"+ipfsHash+"/content", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
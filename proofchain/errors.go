@@ -1,6 +1,7 @@
 package proofchain
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -36,6 +37,8 @@ func NewAuthenticationError(message string) *AuthenticationError {
 // AuthorizationError is returned when access is forbidden (403).
 type AuthorizationError struct {
 	APIError
+	// Permission is the missing scope, such as "wallets:write", when known.
+	Permission string `json:"permission,omitempty"`
 }
 
 // NewAuthorizationError creates a new AuthorizationError.
@@ -48,6 +51,14 @@ func NewAuthorizationError(message string) *AuthorizationError {
 	}
 }
 
+// NewMissingPermissionError creates an AuthorizationError for an API key
+// that lacks permission.
+func NewMissingPermissionError(permission string) *AuthorizationError {
+	err := NewAuthorizationError("missing permission: " + permission)
+	err.Permission = permission
+	return err
+}
+
 // NotFoundError is returned when a resource is not found (404).
 type NotFoundError struct {
 	APIError
@@ -152,3 +163,35 @@ func NewTimeoutError() *TimeoutError {
 		APIError: APIError{Message: "Request timed out"},
 	}
 }
+
+// QuotaExceededError is returned by a QuotaGuard when an operation would
+// exceed the tenant's monthly event quota.
+type QuotaExceededError struct {
+	APIError
+	Requested int `json:"requested"`
+	Remaining int `json:"remaining"`
+	Limit     int `json:"limit"`
+}
+
+// NewQuotaExceededError creates a new QuotaExceededError.
+func NewQuotaExceededError(requested, remaining, limit int) *QuotaExceededError {
+	return &QuotaExceededError{
+		APIError:  APIError{Message: fmt.Sprintf("Monthly event quota exceeded: %d events requested, %d of %d remaining", requested, remaining, limit)},
+		Requested: requested,
+		Remaining: remaining,
+		Limit:     limit,
+	}
+}
+
+// IsRetryable reports whether err is transient, so the same request may
+// succeed if retried later: network failures, timeouts, rate limiting and
+// server errors. Other errors, such as validation or authorization failures,
+// will fail again unchanged.
+func IsRetryable(err error) bool {
+	var netErr *NetworkError
+	var timeoutErr *TimeoutError
+	var rateErr *RateLimitError
+	var serverErr *ServerError
+	return errors.As(err, &netErr) || errors.As(err, &timeoutErr) ||
+		errors.As(err, &rateErr) || errors.As(err, &serverErr)
+}
@@ -0,0 +1,328 @@
+package proofchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// EventBuilder builds event payloads that are validated against a schema as
+// values are set, so payload mistakes surface before the API call.
+//
+// Example:
+//
+//	schema, _ := client.Schemas.Get(ctx, "purchase", nil)
+//	req, err := proofchain.NewEventBuilder(schema).
+//		User("user-123").
+//		Set("amount", 10.5).
+//		Set("currency", "ZAR").
+//		Build()
+type EventBuilder struct {
+	schema    *SchemaDetail
+	fields    map[string]SchemaField
+	eventType string
+	userID    string
+	source    string
+	timestamp string
+	data      map[string]interface{}
+	errors    []ValidationErrorDetail
+}
+
+// NewEventBuilder creates a builder for events conforming to schema. The event
+// type defaults to the schema's event_type, or its name if none is declared.
+func NewEventBuilder(schema *SchemaDetail) *EventBuilder {
+	b := &EventBuilder{
+		schema:    schema,
+		fields:    schemaFields(schema.SchemaDefinition),
+		eventType: schema.Name,
+		data:      make(map[string]interface{}),
+	}
+	if et, ok := schema.SchemaDefinition["event_type"].(string); ok && et != "" {
+		b.eventType = et
+	}
+	return b
+}
+
+// NewBuilder returns an EventBuilder for schema. See NewEventBuilder.
+func (r *EventsResource) NewBuilder(schema *SchemaDetail) *EventBuilder {
+	return NewEventBuilder(schema)
+}
+
+// EventType overrides the event type.
+func (b *EventBuilder) EventType(eventType string) *EventBuilder {
+	b.eventType = eventType
+	return b
+}
+
+// User sets the user the event belongs to.
+func (b *EventBuilder) User(userID string) *EventBuilder {
+	b.userID = userID
+	return b
+}
+
+// Source sets the event source.
+func (b *EventBuilder) Source(source string) *EventBuilder {
+	b.source = source
+	return b
+}
+
+// Timestamp sets the event time (ingestion only).
+func (b *EventBuilder) Timestamp(t time.Time) *EventBuilder {
+	b.timestamp = t.UTC().Format(time.RFC3339)
+	return b
+}
+
+// Set sets a data field, validating it against the schema. Errors are
+// collected and returned by Build.
+func (b *EventBuilder) Set(name string, value interface{}) *EventBuilder {
+	field, ok := b.fields[name]
+	if !ok {
+		if len(b.fields) > 0 && !b.additionalFieldsAllowed() {
+			b.errors = append(b.errors, ValidationErrorDetail{Field: name, Message: "field is not defined in schema " + b.schema.Name})
+			return b
+		}
+	} else if msg := checkSchemaValue(field, value); msg != "" {
+		b.errors = append(b.errors, ValidationErrorDetail{Field: name, Message: msg})
+		return b
+	}
+	b.data[name] = value
+	return b
+}
+
+// Err returns the validation errors collected so far, including missing
+// required fields, or nil if the payload is valid.
+func (b *EventBuilder) Err() error {
+	details := append([]ValidationErrorDetail(nil), b.errors...)
+	if b.userID == "" {
+		details = append(details, ValidationErrorDetail{Field: "user_id", Message: "user_id is required"})
+	}
+
+	names := make([]string, 0, len(b.fields))
+	for name := range b.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := b.fields[name]
+		if _, set := b.data[name]; set {
+			continue
+		}
+		if field.Default != nil {
+			continue
+		}
+		if field.Required {
+			details = append(details, ValidationErrorDetail{Field: name, Message: "required field is missing"})
+		}
+	}
+
+	if len(details) > 0 {
+		return NewValidationError(fmt.Sprintf("event does not match schema %s", b.schema.Name), details)
+	}
+	return nil
+}
+
+// Build returns a CreateEventRequest, or a *ValidationError listing every
+// problem found.
+func (b *EventBuilder) Build() (*CreateEventRequest, error) {
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+	return &CreateEventRequest{
+		EventType: b.eventType,
+		UserID:    b.userID,
+		Data:      b.payload(),
+		Source:    b.source,
+	}, nil
+}
+
+// BuildIngest returns an IngestEventRequest tagged with the schema, or a
+// *ValidationError listing every problem found.
+func (b *EventBuilder) BuildIngest() (*IngestEventRequest, error) {
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+	// Prefer the ID the server assigned; the name is ambiguous across
+	// versions of a schema
+	schemaID := b.schema.ID
+	if schemaID == "" {
+		schemaID = b.schema.Name
+	}
+	return &IngestEventRequest{
+		UserID:      b.userID,
+		EventType:   b.eventType,
+		Data:        b.payload(),
+		EventSource: b.source,
+		Timestamp:   b.timestamp,
+		SchemaIDs:   []string{schemaID},
+	}, nil
+}
+
+func (b *EventBuilder) payload() map[string]interface{} {
+	data := make(map[string]interface{}, len(b.fields))
+	for name, field := range b.fields {
+		if field.Default != nil {
+			data[name] = field.Default
+		}
+	}
+	for k, v := range b.data {
+		data[k] = v
+	}
+	return data
+}
+
+func (b *EventBuilder) additionalFieldsAllowed() bool {
+	return additionalFieldsAllowed(b.schema.SchemaDefinition)
+}
+
+// schemaFields extracts field definitions from a schema definition. Fields may
+// be declared either as a list or as a map keyed by field name.
+func schemaFields(def map[string]interface{}) map[string]SchemaField {
+	fields := make(map[string]SchemaField)
+	raw, ok := def["fields"]
+	if !ok {
+		return fields
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return fields
+	}
+
+	var list []SchemaField
+	if json.Unmarshal(encoded, &list) == nil {
+		for _, f := range list {
+			fields[f.Name] = f
+		}
+		return fields
+	}
+
+	var byName map[string]SchemaField
+	if json.Unmarshal(encoded, &byName) == nil {
+		for name, f := range byName {
+			f.Name = name
+			fields[name] = f
+		}
+	}
+	return fields
+}
+
+// checkSchemaValue returns a description of why value does not satisfy field,
+// or "" if it does.
+func checkSchemaValue(field SchemaField, value interface{}) string {
+	if value == nil {
+		if field.Required {
+			return "required field cannot be null"
+		}
+		return ""
+	}
+
+	switch field.Type {
+	case "string", "text":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected string, got %T", value)
+		}
+		if field.Pattern != nil {
+			re, err := regexp.Compile(*field.Pattern)
+			if err == nil && !re.MatchString(s) {
+				return fmt.Sprintf("value does not match pattern %s", *field.Pattern)
+			}
+		}
+	case "number", "float", "decimal":
+		n, ok := toFloat(value)
+		if !ok {
+			return fmt.Sprintf("expected number, got %T", value)
+		}
+		return checkRange(field, n)
+	case "integer", "int":
+		n, ok := toFloat(value)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Sprintf("expected integer, got %v", value)
+		}
+		return checkRange(field, n)
+	case "boolean", "bool":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected boolean, got %T", value)
+		}
+	case "enum":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected string, got %T", value)
+		}
+		for _, allowed := range field.Values {
+			if s == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("value must be one of %v", field.Values)
+	case "datetime", "timestamp", "date":
+		switch v := value.(type) {
+		case time.Time:
+		case string:
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				if _, err := time.Parse("2006-01-02", v); err != nil {
+					return "expected RFC3339 timestamp"
+				}
+			}
+		default:
+			return fmt.Sprintf("expected timestamp, got %T", value)
+		}
+	case "object", "map":
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Sprintf("expected object, got %T", value)
+		}
+	case "array", "list":
+		if _, ok := value.([]interface{}); !ok {
+			if _, ok := value.([]string); !ok {
+				return fmt.Sprintf("expected array, got %T", value)
+			}
+		}
+	}
+	return ""
+}
+
+func checkRange(field SchemaField, n float64) string {
+	if field.Min != nil && n < *field.Min {
+		return fmt.Sprintf("value must be >= %v", *field.Min)
+	}
+	if field.Max != nil && n > *field.Max {
+		return fmt.Sprintf("value must be <= %v", *field.Max)
+	}
+	return ""
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
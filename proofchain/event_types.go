@@ -0,0 +1,198 @@
+package proofchain
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// eventTypePattern is the event type naming convention: lowercase
+// snake_case segments separated by dots, such as "order.completed" or
+// "quest_step".
+var eventTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// EventType is an event type registered with the tenant
+type EventType struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	SchemaName  *string `json:"schema_name,omitempty"`
+	EventCount  int64   `json:"event_count"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// ValidateEventTypeName checks name against the event type naming
+// convention: lowercase snake_case segments separated by dots.
+func ValidateEventTypeName(name string) error {
+	if !eventTypePattern.MatchString(name) {
+		return NewValidationError("invalid event type name: "+name, []ValidationErrorDetail{
+			{Field: "event_type", Message: "must be lowercase snake_case segments separated by dots, e.g. order.completed"},
+		})
+	}
+	return nil
+}
+
+// RegisterEventType registers an event type with the tenant. schemaName
+// optionally names the schema events of this type must satisfy.
+func (r *EventsResource) RegisterEventType(ctx context.Context, name, description, schemaName string) (*EventType, error) {
+	if err := ValidateEventTypeName(name); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"name":        name,
+		"description": description,
+	}
+	if schemaName != "" {
+		body["schema_name"] = schemaName
+	}
+
+	var eventType EventType
+	err := r.http.Post(ctx, "/events/types", body, &eventType)
+	if err != nil {
+		return nil, err
+	}
+	return &eventType, nil
+}
+
+// ListEventTypes returns the tenant's registered event types
+func (r *EventsResource) ListEventTypes(ctx context.Context) ([]EventType, error) {
+	var types []EventType
+	err := r.http.GetCached(ctx, "/events/types", nil, &types)
+	return types, err
+}
+
+// EventTypeRegistry is a local copy of the tenant's registered event types,
+// used to reject unregistered types before they are ingested.
+type EventTypeRegistry struct {
+	events  *EventsResource
+	refresh time.Duration
+
+	mu     sync.RWMutex
+	names  map[string]bool
+	loaded time.Time
+}
+
+// NewEventTypeRegistry loads the registered event types. An unknown type
+// triggers a reload at most once per refresh interval (default 5m), so
+// newly registered types are picked up without restarting.
+//
+// Example:
+//
+//	registry, err := client.Events.NewEventTypeRegistry(ctx, 0)
+//	ingest := proofchain.NewIngestionClient(apiKey, proofchain.WithRegisteredEventTypesOnly(registry))
+func (r *EventsResource) NewEventTypeRegistry(ctx context.Context, refresh time.Duration) (*EventTypeRegistry, error) {
+	if refresh <= 0 {
+		refresh = 5 * time.Minute
+	}
+	registry := &EventTypeRegistry{events: r, refresh: refresh}
+	if err := registry.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Reload fetches the registered event types from the server, bypassing any
+// response cache, and invalidates cached results of ListEventTypes.
+func (reg *EventTypeRegistry) Reload(ctx context.Context) error {
+	var types []EventType
+	if err := reg.events.http.Get(ctx, "/events/types", nil, &types); err != nil {
+		return err
+	}
+	if cache := reg.events.http.cache; cache != nil {
+		cache.invalidate("/events/types")
+	}
+	names := make(map[string]bool, len(types))
+	for _, t := range types {
+		names[t.Name] = true
+	}
+
+	reg.mu.Lock()
+	reg.names = names
+	reg.loaded = time.Now()
+	reg.mu.Unlock()
+	return nil
+}
+
+// Check returns a validation error if eventType is not registered,
+// suggesting the closest registered name when it looks like a typo.
+func (reg *EventTypeRegistry) Check(eventType string) error {
+	if reg.has(eventType) {
+		return nil
+	}
+
+	reg.mu.RLock()
+	stale := time.Since(reg.loaded) >= reg.refresh
+	reg.mu.RUnlock()
+	if stale {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		err := reg.Reload(ctx)
+		cancel()
+		if err == nil && reg.has(eventType) {
+			return nil
+		}
+	}
+
+	msg := "event type is not registered"
+	if suggestion := reg.closest(eventType); suggestion != "" {
+		msg += "; did you mean " + suggestion + "?"
+	}
+	return NewValidationError("unregistered event type: "+eventType, []ValidationErrorDetail{
+		{Field: "event_type", Message: msg},
+	})
+}
+
+// Interceptor returns an EventInterceptor that rejects unregistered event
+// types.
+func (reg *EventTypeRegistry) Interceptor() EventInterceptor {
+	return func(event *IngestEventRequest) error {
+		return reg.Check(event.EventType)
+	}
+}
+
+func (reg *EventTypeRegistry) has(name string) bool {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	return reg.names[name]
+}
+
+// closest returns the registered name within two edits of name, if any.
+func (reg *EventTypeRegistry) closest(name string) string {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	best, bestDist := "", 3
+	for candidate := range reg.names {
+		if d := editDistance(name, candidate); d < bestDist || (d == bestDist && candidate < best) {
+			best, bestDist = candidate, d
+		}
+	}
+	return best
+}
+
+// WithRegisteredEventTypesOnly rejects events whose type is not in
+// registry; see EventTypeRegistry.Check.
+func WithRegisteredEventTypesOnly(registry *EventTypeRegistry) IngestionClientOption {
+	return WithEventInterceptor(registry.Interceptor())
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -41,6 +41,7 @@ type FanpassLeaderboardResponse struct {
 	Leaderboard              []FanpassLeaderboardEntry  `json:"leaderboard"`
 	CurrentUser              *FanpassLeaderboardEntry   `json:"current_user,omitempty"`
 	CurrentUserInLeaderboard bool                       `json:"current_user_in_leaderboard"`
+	NextCursor               *string                    `json:"next_cursor,omitempty"`
 }
 
 // FanpassUserComparisonResponse contains a user's comparison across all cohorts.
@@ -63,6 +64,22 @@ type FanpassLeaderboardOptions struct {
 	TopN              int
 	Fresh             bool
 	UserID            string
+	// Offset skips the first Offset entries; Cursor continues from a previous
+	// response's NextCursor. Set at most one of them.
+	Offset int
+	Cursor string
+	// AroundUserID returns Window entries above and below this user instead
+	// of the top of the leaderboard. See AroundUser.
+	AroundUserID string
+	Window       int
+}
+
+// AroundUser sets the options to return window entries above and below
+// userID, for "your rank ±N" widgets.
+func (o *FanpassLeaderboardOptions) AroundUser(userID string, window int) *FanpassLeaderboardOptions {
+	o.AroundUserID = userID
+	o.Window = window
+	return o
 }
 
 // =============================================================================
@@ -72,11 +89,18 @@ type FanpassLeaderboardOptions struct {
 // FanpassLeaderboardClient provides fanpass leaderboard operations.
 type FanpassLeaderboardClient struct {
 	http *HTTPClient
+
+	// AggregationRules manages the composite scoring rules referenced by
+	// FanpassLeaderboardOptions.AggregationRuleID.
+	AggregationRules *AggregationRulesClient
 }
 
 // NewFanpassLeaderboardClient creates a new fanpass leaderboard client.
 func NewFanpassLeaderboardClient(http *HTTPClient) *FanpassLeaderboardClient {
-	return &FanpassLeaderboardClient{http: http}
+	return &FanpassLeaderboardClient{
+		http:             http,
+		AggregationRules: NewAggregationRulesClient(http),
+	}
 }
 
 // GetLeaderboard returns the fanpass leaderboard with composite scores.
@@ -105,6 +129,18 @@ func (f *FanpassLeaderboardClient) GetLeaderboard(ctx context.Context, opts *Fan
 		if opts.UserID != "" {
 			params.Set("user_id", opts.UserID)
 		}
+		if opts.Offset > 0 {
+			params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+		if opts.AroundUserID != "" {
+			params.Set("around_user", opts.AroundUserID)
+			if opts.Window > 0 {
+				params.Set("window", fmt.Sprintf("%d", opts.Window))
+			}
+		}
 	}
 
 	var response FanpassLeaderboardResponse
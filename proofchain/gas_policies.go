@@ -0,0 +1,182 @@
+package proofchain
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// GasPolicy is a paymaster sponsorship policy for smart account user operations
+type GasPolicy struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Network string `json:"network"`
+	Active  bool   `json:"active"`
+	// PerUserDailyLimitUSD caps sponsored gas per end-user per UTC day.
+	PerUserDailyLimitUSD *float64 `json:"per_user_daily_limit_usd,omitempty"`
+	// GlobalDailyLimitUSD caps sponsored gas across all users per UTC day.
+	GlobalDailyLimitUSD *float64 `json:"global_daily_limit_usd,omitempty"`
+	// MaxGasPerOp caps the gas limit of a single sponsored user operation.
+	MaxGasPerOp *int64 `json:"max_gas_per_op,omitempty"`
+	// AllowedContracts restricts sponsorship to calls to these contracts.
+	// Empty allows any contract.
+	AllowedContracts []string `json:"allowed_contracts,omitempty"`
+	IsDefault        bool     `json:"is_default"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+// CreateGasPolicyRequest is the request to create a gas sponsorship policy
+type CreateGasPolicyRequest struct {
+	Name                 string   `json:"name"`
+	Network              string   `json:"network"`
+	PerUserDailyLimitUSD *float64 `json:"per_user_daily_limit_usd,omitempty"`
+	GlobalDailyLimitUSD  *float64 `json:"global_daily_limit_usd,omitempty"`
+	MaxGasPerOp          *int64   `json:"max_gas_per_op,omitempty"`
+	AllowedContracts     []string `json:"allowed_contracts,omitempty"`
+	IsDefault            bool     `json:"is_default,omitempty"`
+}
+
+// UpdateGasPolicyRequest is the request to update a gas sponsorship policy.
+// Nil fields are left unchanged.
+type UpdateGasPolicyRequest struct {
+	Name                 *string   `json:"name,omitempty"`
+	Active               *bool     `json:"active,omitempty"`
+	PerUserDailyLimitUSD *float64  `json:"per_user_daily_limit_usd,omitempty"`
+	GlobalDailyLimitUSD  *float64  `json:"global_daily_limit_usd,omitempty"`
+	MaxGasPerOp          *int64    `json:"max_gas_per_op,omitempty"`
+	AllowedContracts     *[]string `json:"allowed_contracts,omitempty"`
+	IsDefault            *bool     `json:"is_default,omitempty"`
+}
+
+// SponsorshipUsage reports sponsored gas consumption against policy budgets
+type SponsorshipUsage struct {
+	PeriodStart     string           `json:"period_start"`
+	PeriodEnd       string           `json:"period_end"`
+	TotalSpentUSD   float64          `json:"total_spent_usd"`
+	TotalUserOps    int              `json:"total_user_ops"`
+	RejectedUserOps int              `json:"rejected_user_ops"`
+	Policies        []GasPolicyUsage `json:"policies"`
+}
+
+// GasPolicyUsage is the consumption of a single policy
+type GasPolicyUsage struct {
+	PolicyID     string   `json:"policy_id"`
+	PolicyName   string   `json:"policy_name"`
+	SpentUSD     float64  `json:"spent_usd"`
+	BudgetUSD    *float64 `json:"budget_usd,omitempty"`
+	RemainingUSD *float64 `json:"remaining_usd,omitempty"`
+	UserOps      int      `json:"user_ops"`
+}
+
+// GasPoliciesClient manages gas sponsorship policies. It is available as
+// Client.Wallets.GasPolicies.
+type GasPoliciesClient struct {
+	http *HTTPClient
+}
+
+// NewGasPoliciesClient creates a new gas policies client
+func NewGasPoliciesClient(http *HTTPClient) *GasPoliciesClient {
+	return &GasPoliciesClient{http: http}
+}
+
+// List returns all gas sponsorship policies
+func (g *GasPoliciesClient) List(ctx context.Context) ([]GasPolicy, error) {
+	var policies []GasPolicy
+	err := g.http.Get(ctx, "/wallets/gas-policies", nil, &policies)
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// Get returns a gas sponsorship policy
+func (g *GasPoliciesClient) Get(ctx context.Context, policyID string) (*GasPolicy, error) {
+	var policy GasPolicy
+	err := g.http.Get(ctx, "/wallets/gas-policies/"+policyID, nil, &policy)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Create creates a gas sponsorship policy
+func (g *GasPoliciesClient) Create(ctx context.Context, req *CreateGasPolicyRequest) (*GasPolicy, error) {
+	if err := validateGasPolicy(req.Name, req.AllowedContracts, req.Network); err != nil {
+		return nil, err
+	}
+
+	var policy GasPolicy
+	err := g.http.Post(ctx, "/wallets/gas-policies", req, &policy)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Update updates a gas sponsorship policy. Fields are validated as in Create;
+// replacing AllowedContracts fetches the policy to check the addresses
+// against its network.
+func (g *GasPoliciesClient) Update(ctx context.Context, policyID string, req *UpdateGasPolicyRequest) (*GasPolicy, error) {
+	if req.Name != nil {
+		if err := validateGasPolicy(*req.Name, nil, ""); err != nil {
+			return nil, err
+		}
+	}
+	if req.AllowedContracts != nil && len(*req.AllowedContracts) > 0 {
+		current, err := g.Get(ctx, policyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range *req.AllowedContracts {
+			if err := validateAddress(current.Network, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var policy GasPolicy
+	err := g.http.Patch(ctx, "/wallets/gas-policies/"+policyID, req, &policy)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Delete deletes a gas sponsorship policy
+func (g *GasPoliciesClient) Delete(ctx context.Context, policyID string) error {
+	return g.http.Delete(ctx, "/wallets/gas-policies/"+policyID)
+}
+
+// GetSponsorshipUsage reports sponsored gas spent per policy between from and
+// to. Zero times default to the current UTC day.
+func (w *WalletClient) GetSponsorshipUsage(ctx context.Context, from, to time.Time) (*SponsorshipUsage, error) {
+	params := url.Values{}
+	if !from.IsZero() {
+		params.Set("from_date", from.Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		params.Set("to_date", to.Format(time.RFC3339))
+	}
+
+	var usage SponsorshipUsage
+	err := w.http.Get(ctx, "/wallets/gas-policies/usage", params, &usage)
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+func validateGasPolicy(name string, contracts []string, network string) error {
+	if name == "" {
+		return NewValidationError("name is required", []ValidationErrorDetail{
+			{Field: "name", Message: "is required"},
+		})
+	}
+	for _, c := range contracts {
+		if err := validateAddress(network, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -16,7 +16,11 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // registers the "gzip" compressor
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -31,6 +35,11 @@ type GRPCEvent struct {
 	DocumentHash string                 `json:"document_hash,omitempty"`
 	Data         map[string]interface{} `json:"data,omitempty"`
 	Timestamp    *time.Time             `json:"timestamp,omitempty"`
+
+	// ClientRef is the caller's reference for the event. It is sent with
+	// the event and passed to the ResultSink as echoed by the server; it is
+	// left empty in results from servers that do not echo it.
+	ClientRef string `json:"-"`
 }
 
 // GRPCResponse represents a response from the gRPC stream.
@@ -43,13 +52,23 @@ type GRPCResponse struct {
 
 // StreamStats contains statistics about a streaming session.
 type StreamStats struct {
-	TotalSent     int64
-	TotalSuccess  int64
-	TotalFailed   int64
-	TotalDropped  int64 // Events dropped due to buffer full (only for TrySend)
-	Duration      time.Duration
-	EventsPerSec  float64
-	ActiveStreams int
+	TotalSent       int64
+	TotalSuccess    int64
+	TotalFailed     int64
+	TotalDropped    int64 // Events dropped due to buffer full (only for TrySend)
+	TotalDuplicates int64 // Events dropped by WithGRPCDedupeWindow
+	TotalRejected   int64 // Events rejected by a WithGRPCEventInterceptor
+	Duration        time.Duration
+	EventsPerSec    float64
+	ActiveStreams   int
+
+	// BytesSent is the size of the event messages sent.
+	BytesSent int64
+	// Latency is the time from sending each event to receiving its
+	// response, over all streams.
+	Latency LatencySummary
+	// Streams breaks the session down per stream.
+	Streams []StreamBreakdown
 }
 
 // GRPCClientOption configures the gRPC client.
@@ -62,13 +81,53 @@ func WithGRPCEndpoint(endpoint string) GRPCClientOption {
 	}
 }
 
-// WithGRPCTimeout sets the connection timeout.
+// WithGRPCRegion points the client at the gRPC endpoint for region. It
+// replaces any earlier WithGRPCEndpoint.
+func WithGRPCRegion(region Region) GRPCClientOption {
+	return func(c *GRPCClient) {
+		ep, err := region.Endpoints()
+		if err != nil {
+			c.transport.err = err
+			return
+		}
+		c.endpoint = ep.GRPCEndpoint
+	}
+}
+
+// WithGRPCFailoverRegion makes Connect dial the gRPC endpoint for region
+// when the primary endpoint cannot be reached. Every Connect, including
+// reconnects, tries the primary first. While connections are failed over,
+// StreamEvents redials the primary at most every 30 seconds before it starts
+// streaming and moves them back once it answers; streams already running
+// are not moved.
+func WithGRPCFailoverRegion(region Region) GRPCClientOption {
+	return func(c *GRPCClient) {
+		ep, err := region.Endpoints()
+		if err != nil {
+			c.transport.err = err
+			return
+		}
+		c.failoverEndpoint = ep.GRPCEndpoint
+	}
+}
+
+// WithGRPCTimeout sets the connection timeout. It bounds dialing only; see
+// WithGRPCStreamTimeout for the lifetime of a stream.
 func WithGRPCTimeout(timeout time.Duration) GRPCClientOption {
 	return func(c *GRPCClient) {
 		c.timeout = timeout
 	}
 }
 
+// WithGRPCStreamTimeout bounds how long each event stream may stay open.
+// The default of zero leaves streams bounded only by the caller's context,
+// so long-running ingestion is not cut off by the connection timeout.
+func WithGRPCStreamTimeout(timeout time.Duration) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.streamTimeout = timeout
+	}
+}
+
 // WithTLS enables or disables TLS (enabled by default for port 443).
 func WithTLS(enabled bool) GRPCClientOption {
 	return func(c *GRPCClient) {
@@ -76,6 +135,34 @@ func WithTLS(enabled bool) GRPCClientOption {
 	}
 }
 
+// WithGRPCTLSConfig sets the TLS configuration for gRPC connections and
+// enables TLS regardless of the endpoint port.
+func WithGRPCTLSConfig(cfg *tls.Config) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.transport.tlsConfig = cfg
+		c.useTLS = true
+	}
+}
+
+// WithGRPCProxy tunnels gRPC connections through the HTTP or HTTPS proxy at
+// proxyURL using CONNECT. Credentials in the URL are sent as
+// Proxy-Authorization.
+func WithGRPCProxy(proxyURL string) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.transport.setProxy(proxyURL)
+	}
+}
+
+// WithGRPCClientCertificate presents the PEM certificate and key in certFile
+// and keyFile for mutual TLS, and enables TLS regardless of the endpoint
+// port. A load error is returned from Connect.
+func WithGRPCClientCertificate(certFile, keyFile string) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.transport.setClientCertificate(certFile, keyFile)
+		c.useTLS = true
+	}
+}
+
 // WithNumStreams sets the number of parallel streams for multi-stream mode.
 func WithNumStreams(n int) GRPCClientOption {
 	return func(c *GRPCClient) {
@@ -85,6 +172,61 @@ func WithNumStreams(n int) GRPCClientOption {
 	}
 }
 
+// WithGRPCCompression compresses stream messages with the named codec.
+// "gzip" is always available; other codecs such as "zstd" must be registered
+// with google.golang.org/grpc/encoding.RegisterCompressor before Connect.
+func WithGRPCCompression(name string) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.compression = name
+	}
+}
+
+// WithKeepaliveParams enables client-side keepalive pings so idle streams are
+// not dropped by load balancers and NAT middleboxes. The server may reject
+// pings more frequent than its enforcement policy (typically 5 minutes
+// without active streams).
+func WithKeepaliveParams(params keepalive.ClientParameters) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.keepalive = &params
+	}
+}
+
+// WithMaxMessageSize sets the maximum send and receive message size in bytes
+// (gRPC defaults to 4 MiB for receives).
+func WithMaxMessageSize(bytes int) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.maxMessageSize = bytes
+	}
+}
+
+// WithGRPCDeadLetterSink sends events that fail to send, or that the server
+// reports as failed, to sink. Server responses are matched to events in the
+// order they were sent on each stream.
+func WithGRPCDeadLetterSink(sink DeadLetterSink) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.deadLetters = sink
+	}
+}
+
+// WithGRPCMaxEventsPerSecond caps outbound throughput at n events per second,
+// shared across all streams of the client.
+func WithGRPCMaxEventsPerSecond(n int) GRPCClientOption {
+	return func(c *GRPCClient) {
+		if n > 0 {
+			c.limiter = newTokenBucket(n)
+		}
+	}
+}
+
+// WithDataOnly stops sending event Data flattened into string metadata
+// fields alongside the JSON-encoded Data. Only use it against servers that
+// read the Data field; older servers ignore Data and would see no event data.
+func WithDataOnly() GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.dataOnly = true
+	}
+}
+
 // GRPCClient provides high-performance gRPC streaming for event ingestion.
 // Supports single-stream and multi-stream modes for maximum throughput.
 //
@@ -97,8 +239,26 @@ type GRPCClient struct {
 	useTLS     bool
 	numStreams int
 
-	mu    sync.RWMutex
-	conns []*grpc.ClientConn
+	dataOnly       bool
+	compression    string
+	keepalive      *keepalive.ClientParameters
+	maxMessageSize int
+	deadLetters    DeadLetterSink
+	limiter        *tokenBucket
+	metrics        MetricsRecorder
+	clockSkew      *ClockSkew
+	dedupe         *dedupeWindow
+	interceptors   []EventInterceptor
+	results        ResultSink
+	streamTimeout  time.Duration
+	transport      transportConfig
+
+	failoverEndpoint string
+
+	mu            sync.RWMutex
+	conns         []*grpc.ClientConn
+	connEndpoints []string  // Endpoint each of conns is dialed to
+	lastProbe     time.Time // Last attempt to fail back to the primary
 }
 
 // NewGRPCClient creates a new gRPC streaming client.
@@ -122,8 +282,9 @@ func NewGRPCClient(apiKey string, opts ...GRPCClientOption) *GRPCClient {
 		opt(c)
 	}
 
-	// Auto-detect TLS based on port
-	if !strings.Contains(c.endpoint, ":443") && c.useTLS {
+	// Auto-detect TLS based on port, unless TLS was configured explicitly
+	explicitTLS := c.transport.tlsConfig != nil || c.transport.clientCert != nil
+	if !strings.Contains(c.endpoint, ":443") && c.useTLS && !explicitTLS {
 		c.useTLS = false
 	}
 
@@ -136,6 +297,10 @@ func (c *GRPCClient) Connect(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if len(c.conns) > 0 && c.metrics != nil {
+		c.metrics.IncStreamReconnect()
+	}
+
 	// Close existing connections
 	for _, conn := range c.conns {
 		if conn != nil {
@@ -144,9 +309,16 @@ func (c *GRPCClient) Connect(ctx context.Context) error {
 	}
 
 	c.conns = make([]*grpc.ClientConn, c.numStreams)
+	c.connEndpoints = make([]string, c.numStreams)
 
 	for i := 0; i < c.numStreams; i++ {
-		conn, err := c.dialEndpoint(ctx, c.endpoint)
+		endpoint := c.endpoint
+		conn, err := c.dialEndpoint(ctx, endpoint)
+		if err != nil && c.failoverEndpoint != "" && ctx.Err() == nil {
+			endpoint = c.failoverEndpoint
+			conn, err = c.dialEndpoint(ctx, endpoint)
+			c.lastProbe = time.Now()
+		}
 		if err != nil {
 			// Close already established connections
 			for j := 0; j < i; j++ {
@@ -157,11 +329,39 @@ func (c *GRPCClient) Connect(ctx context.Context) error {
 			return fmt.Errorf("failed to connect stream %d: %w", i, err)
 		}
 		c.conns[i] = conn
+		c.connEndpoints[i] = endpoint
 	}
 
 	return nil
 }
 
+// failBack redials the primary endpoint for connections on the failover
+// endpoint, when a probe is due, and replaces them if the primary answers.
+func (c *GRPCClient) failBack(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failoverEndpoint == "" || c.failoverEndpoint == c.endpoint || time.Since(c.lastProbe) < failoverProbeInterval {
+		return
+	}
+	c.lastProbe = time.Now()
+
+	probeCtx, cancel := context.WithTimeout(ctx, failoverProbeTimeout)
+	defer cancel()
+	for i, endpoint := range c.connEndpoints {
+		if endpoint != c.failoverEndpoint {
+			continue
+		}
+		conn, err := c.dialEndpoint(probeCtx, c.endpoint)
+		if err != nil {
+			return
+		}
+		c.conns[i].Close()
+		c.conns[i] = conn
+		c.connEndpoints[i] = c.endpoint
+	}
+}
+
 // Close closes all gRPC connections.
 func (c *GRPCClient) Close() error {
 	c.mu.Lock()
@@ -176,28 +376,57 @@ func (c *GRPCClient) Close() error {
 		}
 	}
 	c.conns = nil
+	c.connEndpoints = nil
 	return lastErr
 }
 
 func (c *GRPCClient) dialEndpoint(ctx context.Context, endpoint string) (*grpc.ClientConn, error) {
+	if c.transport.err != nil {
+		return nil, c.transport.err
+	}
+
 	var creds grpc.DialOption
 	if c.useTLS {
-		creds = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(c.transport.tls()))
 	} else {
 		creds = grpc.WithTransportCredentials(insecure.NewCredentials())
 	}
 
+	opts := []grpc.DialOption{creds, grpc.WithBlock()}
+	if c.transport.proxyURL != nil {
+		opts = append(opts, grpc.WithContextDialer(c.transport.dialProxy))
+	}
+
+	var callOpts []grpc.CallOption
+	if c.compression != "" && c.compression != "identity" {
+		if encoding.GetCompressor(c.compression) == nil {
+			return nil, fmt.Errorf("grpc compressor %q is not registered", c.compression)
+		}
+		callOpts = append(callOpts, grpc.UseCompressor(c.compression))
+	}
+	if c.maxMessageSize > 0 {
+		callOpts = append(callOpts,
+			grpc.MaxCallRecvMsgSize(c.maxMessageSize),
+			grpc.MaxCallSendMsgSize(c.maxMessageSize),
+		)
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if c.keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*c.keepalive))
+	}
+
 	dialCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	return grpc.DialContext(dialCtx, endpoint,
-		creds,
-		grpc.WithBlock(),
-	)
+	return grpc.DialContext(dialCtx, endpoint, opts...)
 }
 
 // StreamEvents streams events using bidirectional gRPC streaming.
 // In multi-stream mode, events are distributed across parallel streams.
+// If a ResultSink is configured and fails, the stats are returned together
+// with its error.
 //
 // Example:
 //
@@ -215,6 +444,8 @@ func (c *GRPCClient) dialEndpoint(ctx context.Context, endpoint string) (*grpc.C
 //
 //	stats, err := client.StreamEvents(ctx, events)
 func (c *GRPCClient) StreamEvents(ctx context.Context, events <-chan *GRPCEvent) (*StreamStats, error) {
+	c.failBack(ctx)
+
 	c.mu.RLock()
 	if len(c.conns) == 0 {
 		c.mu.RUnlock()
@@ -223,34 +454,76 @@ func (c *GRPCClient) StreamEvents(ctx context.Context, events <-chan *GRPCEvent)
 	numConns := len(c.conns)
 	c.mu.RUnlock()
 
-	// Add API key to context
+	// Add request headers and API key to context
+	for k, v := range HeadersFromContext(ctx) {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(k), v)
+	}
 	ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", c.apiKey)
 
+	var filtered *streamFilterCounts
+	if c.dedupe != nil || len(c.interceptors) > 0 {
+		events, filtered = c.filterStream(events)
+	}
+
 	start := time.Now()
 	var totalSent, totalSuccess, totalFailed int64
+	var sinkErr error
+
+	meters := make([]*streamMeter, numConns)
+	for i := range meters {
+		meters[i] = &streamMeter{}
+	}
 
 	if numConns == 1 {
 		// Single stream mode
-		sent, success, failed := c.runSingleStream(ctx, c.conns[0], events)
+		sent, success, failed, err := c.runSingleStream(ctx, c.conns[0], events, meters[0])
 		totalSent = sent
 		totalSuccess = success
 		totalFailed = failed
+		sinkErr = err
 	} else {
 		// Multi-stream mode - distribute events across streams
-		totalSent, totalSuccess, totalFailed = c.runMultiStream(ctx, events)
+		totalSent, totalSuccess, totalFailed, sinkErr = c.runMultiStream(ctx, events, meters)
+	}
+
+	var latency latencyHistogram
+	var bytesSent int64
+	streams := make([]StreamBreakdown, numConns)
+	for i, m := range meters {
+		latency.merge(&m.latency)
+		bytesSent += m.bytes
+		streams[i] = m.breakdown()
+	}
+
+	var totalDuplicates, totalRejected int64
+	if filtered != nil {
+		totalDuplicates = filtered.duplicates.Load()
+		totalRejected = filtered.rejected.Load()
+	}
+
+	if c.metrics != nil {
+		c.metrics.AddIngested("grpc", int(totalSuccess), int(totalFailed))
+		if totalDuplicates > 0 {
+			c.metrics.AddDropped("duplicate", int(totalDuplicates))
+		}
 	}
 
 	elapsed := time.Since(start)
 	rate := float64(totalSent) / elapsed.Seconds()
 
 	return &StreamStats{
-		TotalSent:     totalSent,
-		TotalSuccess:  totalSuccess,
-		TotalFailed:   totalFailed,
-		Duration:      elapsed,
-		EventsPerSec:  rate,
-		ActiveStreams: numConns,
-	}, nil
+		TotalSent:       totalSent,
+		TotalSuccess:    totalSuccess,
+		TotalFailed:     totalFailed,
+		TotalDuplicates: totalDuplicates,
+		TotalRejected:   totalRejected,
+		Duration:        elapsed,
+		EventsPerSec:    rate,
+		ActiveStreams:   numConns,
+		BytesSent:       bytesSent,
+		Latency:         latency.summary(),
+		Streams:         streams,
+	}, sinkErr
 }
 
 // StreamEventsSlice is a convenience method that streams a slice of events.
@@ -263,36 +536,50 @@ func (c *GRPCClient) StreamEventsSlice(ctx context.Context, events []*GRPCEvent)
 	return c.StreamEvents(ctx, ch)
 }
 
-func (c *GRPCClient) runSingleStream(ctx context.Context, conn *grpc.ClientConn, events <-chan *GRPCEvent) (sent, success, failed int64) {
+// runSingleStream streams events over conn, recording bytes, latency and
+// counts in meter. sinkErr is the first error returned by the result sink.
+func (c *GRPCClient) runSingleStream(ctx context.Context, conn *grpc.ClientConn, events <-chan *GRPCEvent, meter *streamMeter) (sent, success, failed int64, sinkErr error) {
+	defer func() {
+		meter.sent, meter.success, meter.failed = sent, success, failed
+	}()
+
 	// Create EventService client from the generated proto
 	client := pb.NewEventServiceClient(conn)
 
+	ctx, cancel := withOptionalTimeout(ctx, c.streamTimeout)
+	defer cancel()
+
 	// Open bidirectional stream
 	stream, err := client.StreamEvents(ctx)
 	if err != nil {
 		// If stream fails to open, count all events as failed
-		for range events {
+		for event := range events {
 			sent++
 			failed++
+			c.failEvent(event, err)
 		}
 		return
 	}
 
-	// Start goroutine to receive responses
+	// Start goroutine to receive responses. recvErr is set before recvDone
+	// is closed.
 	responseChan := make(chan *pb.EventResponse, 100000)
 	recvDone := make(chan struct{})
+	var recvErr error
 	go func() {
 		defer close(responseChan)
 		defer close(recvDone)
 		for {
 			resp, err := stream.Recv()
 			if err != nil {
-				// EOF is expected when server closes, other errors are logged
+				// EOF is expected when the server has answered everything;
+				// anything else leaves events unanswered
 				if err != io.EOF {
-					// Log but don't fail - server may have finished
+					recvErr = err
 				}
 				return
 			}
+			meter.recordResponse()
 			responseChan <- resp
 		}
 	}()
@@ -300,26 +587,60 @@ func (c *GRPCClient) runSingleStream(ctx context.Context, conn *grpc.ClientConn,
 	// Track send errors separately from server-side failures
 	var sendErrors int64
 
+	// Events successfully written to the stream, in order, so failed
+	// responses can be dead-lettered with their payload and forgotten by the
+	// dedupe window. byRef indexes them by ClientRef, or -1 for a ClientRef
+	// sent more than once.
+	var inFlight []*GRPCEvent
+	byRef := make(map[string]int)
+
 	// Send events
 	for event := range events {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, 1); err != nil {
+				sent++
+				sendErrors++
+				c.failEvent(event, err)
+				continue
+			}
+		}
+
 		// Convert GRPCEvent to proto EventRequest
 		req := &pb.EventRequest{
 			TenantId:     "", // Will be set from API key context
 			UserId:       event.UserID,
 			EventType:    event.EventType,
 			DocumentHash: event.DocumentHash,
+			ClientRef:    event.ClientRef,
 		}
 
 		// Add timestamp if provided
 		if event.Timestamp != nil {
+			ts := *event.Timestamp
+			if c.clockSkew != nil {
+				ts = c.clockSkew.Adjust(ts)
+			}
 			req.Timestamp = &pb.Timestamp{
-				Seconds: event.Timestamp.Unix(),
-				Nanos:   int32(event.Timestamp.Nanosecond()),
+				Seconds: ts.Unix(),
+				Nanos:   int32(ts.Nanosecond()),
 			}
 		}
 
-		// Convert Data map to Metadata
+		// Send Data as JSON so numeric and nested values survive ingestion
+		// exactly as they do on the REST path
 		if event.Data != nil {
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				sent++
+				sendErrors++
+				c.failEvent(event, err)
+				continue
+			}
+			req.Data = data
+		}
+
+		// Convert Data map to Metadata for servers that predate Data
+		if event.Data != nil && !c.dataOnly {
 			req.Metadata = &pb.Metadata{
 				Fields: make(map[string]string),
 			}
@@ -336,8 +657,22 @@ func (c *GRPCClient) runSingleStream(ctx context.Context, conn *grpc.ClientConn,
 		}
 
 		sent++ // Count all attempts
+		size := proto.Size(req)
 		if err := stream.Send(req); err != nil {
 			sendErrors++
+			c.failEvent(event, err)
+		} else {
+			meter.recordSend(size)
+			if c.deadLetters != nil || c.results != nil || c.dedupe != nil {
+				if event.ClientRef != "" {
+					if _, dup := byRef[event.ClientRef]; dup {
+						byRef[event.ClientRef] = -1
+					} else {
+						byRef[event.ClientRef] = len(inFlight)
+					}
+				}
+				inFlight = append(inFlight, event)
+			}
 		}
 	}
 
@@ -349,12 +684,70 @@ func (c *GRPCClient) runSingleStream(ctx context.Context, conn *grpc.ClientConn,
 
 	// Drain responses to get server-side success/failure counts
 	var serverSuccess, serverFailed int64
+	var results []IngestResult
+	answered := make([]bool, len(inFlight))
+	answeredRefs := make(map[string]bool)
+	i := 0
 	for resp := range responseChan {
+		// Match the response to its event by the echoed ClientRef. Servers
+		// that do not echo it answer in the order events were sent.
+		idx := i
+		if resp.ClientRef != "" {
+			answeredRefs[resp.ClientRef] = true
+			idx = -1
+			if j, ok := byRef[resp.ClientRef]; ok {
+				idx = j
+			}
+		}
+		if idx >= 0 && idx < len(answered) {
+			answered[idx] = true
+		}
+
+		if c.results != nil && sinkErr == nil {
+			results = append(results, IngestResult{
+				ClientRef:     resp.ClientRef,
+				EventID:       resp.EventId,
+				CertificateID: resp.CertificateId,
+				Status:        resp.Status,
+			})
+			if len(results) == resultSinkBatchSize {
+				sinkErr = c.writeResults(ctx, results)
+				results = nil
+			}
+		}
 		if resp.Status == "error" || resp.Status == "failed" {
 			serverFailed++
+			if idx >= 0 && idx < len(inFlight) {
+				c.failEvent(inFlight[idx], fmt.Errorf("event rejected by server: %s", resp.Status))
+			}
 		} else {
 			serverSuccess++
 		}
+		i++
+	}
+	if len(results) > 0 && sinkErr == nil {
+		sinkErr = c.writeResults(ctx, results)
+	}
+
+	// A broken stream leaves the events after the last response unanswered:
+	// they were not ingested, so fail them. Events whose response could not
+	// be matched, because their ClientRef was sent more than once, are
+	// treated as answered once any response carries that ClientRef.
+	if recvErr != nil {
+		unanswered := (sent - sendErrors) - (serverSuccess + serverFailed)
+		if unanswered > 0 {
+			serverFailed += unanswered
+		}
+		err := fmt.Errorf("stream closed before the server answered: %w", recvErr)
+		for j, event := range inFlight {
+			if answered[j] {
+				continue
+			}
+			if ref := event.ClientRef; ref != "" && byRef[ref] < 0 && answeredRefs[ref] {
+				continue
+			}
+			c.failEvent(event, err)
+		}
 	}
 
 	// Calculate final counts:
@@ -372,7 +765,35 @@ func (c *GRPCClient) runSingleStream(ctx context.Context, conn *grpc.ClientConn,
 	return
 }
 
-func (c *GRPCClient) runMultiStream(ctx context.Context, events <-chan *GRPCEvent) (totalSent, totalSuccess, totalFailed int64) {
+// writeResults delivers a chunk of stream results to the result sink.
+func (c *GRPCClient) writeResults(ctx context.Context, results []IngestResult) error {
+	if err := c.results.WriteResults(ctx, results); err != nil {
+		return fmt.Errorf("result sink: %w", err)
+	}
+	return nil
+}
+
+// failEvent records that event, already admitted by the dedupe window, was
+// not ingested: its key is forgotten so a retry is admitted, and the event is
+// dead-lettered.
+func (c *GRPCClient) failEvent(event *GRPCEvent, err error) {
+	if c.dedupe != nil {
+		c.dedupe.forget(eventKey(event.UserID, event.EventType, event.DocumentHash, event.Data))
+	}
+	c.deadLetter(event, err)
+}
+
+func (c *GRPCClient) deadLetter(event *GRPCEvent, err error) {
+	if c.deadLetters == nil {
+		return
+	}
+	letter := newDeadLetter("grpc", err)
+	letter.GRPCEvent = event
+	letter.ClientRef = event.ClientRef
+	c.deadLetters.Write(letter)
+}
+
+func (c *GRPCClient) runMultiStream(ctx context.Context, events <-chan *GRPCEvent, meters []*streamMeter) (totalSent, totalSuccess, totalFailed int64, sinkErr error) {
 	c.mu.RLock()
 	numConns := len(c.conns)
 	conns := make([]*grpc.ClientConn, numConns)
@@ -387,13 +808,17 @@ func (c *GRPCClient) runMultiStream(ctx context.Context, events <-chan *GRPCEven
 
 	var wg sync.WaitGroup
 	var sent, success, failed int64
+	var errOnce sync.Once
 
 	// Start stream workers
 	for i, conn := range conns {
 		wg.Add(1)
 		go func(idx int, conn *grpc.ClientConn, ch <-chan *GRPCEvent) {
 			defer wg.Done()
-			s, succ, f := c.runSingleStream(ctx, conn, ch)
+			s, succ, f, err := c.runSingleStream(ctx, conn, ch, meters[idx])
+			if err != nil {
+				errOnce.Do(func() { sinkErr = err })
+			}
 			atomic.AddInt64(&sent, s)
 			atomic.AddInt64(&success, succ)
 			atomic.AddInt64(&failed, f)
@@ -413,7 +838,7 @@ func (c *GRPCClient) runMultiStream(ctx context.Context, events <-chan *GRPCEven
 	}
 
 	wg.Wait()
-	return sent, success, failed
+	return sent, success, failed, sinkErr
 }
 
 // MultiStreamClient provides a higher-level API for multi-stream ingestion.
@@ -534,6 +959,9 @@ func (c *MultiStreamClient) TrySend(event *GRPCEvent) bool {
 		return true
 	default:
 		atomic.AddInt64(&c.dropped, 1)
+		if c.metrics != nil {
+			c.metrics.AddDropped("buffer_full", 1)
+		}
 		return false
 	}
 }
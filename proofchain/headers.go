@@ -0,0 +1,79 @@
+package proofchain
+
+import (
+	"context"
+	"net/http"
+)
+
+type headersKey struct{}
+
+// WithHeader adds a header sent with every request made by the client, e.g.
+// a gateway routing key. Authentication, Content-Type and User-Agent headers
+// set by the SDK take precedence.
+func WithHeader(key, value string) HTTPClientOption {
+	return func(c *HTTPClient) {
+		if c.headers == nil {
+			c.headers = make(map[string]string)
+		}
+		c.headers[key] = value
+	}
+}
+
+// ContextWithHeaders returns a context whose requests carry the given headers
+// in addition to any client-level headers, e.g. correlation IDs, on-behalf-of
+// user IDs or feature flags. Headers from nested calls are merged, with inner
+// values winning. Headers are honoured by Client, IngestionClient and (as
+// gRPC metadata) GRPCClient.
+//
+// Example:
+//
+//	ctx = proofchain.ContextWithHeaders(ctx, map[string]string{
+//		"X-Correlation-ID": requestID,
+//	})
+//	event, err := client.Events.Create(ctx, req)
+func ContextWithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	merged := make(map[string]string, len(headers))
+	for k, v := range HeadersFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, headersKey{}, merged)
+}
+
+// HeadersFromContext returns the headers attached with ContextWithHeaders.
+func HeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(headersKey{}).(map[string]string)
+	return headers
+}
+
+// hasHeader reports whether headers contains name, compared
+// case-insensitively as HTTP header names are.
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// setCustomHeaders applies client-level headers, then request-context
+// headers. It must run before the SDK sets its own headers.
+func (c *HTTPClient) setCustomHeaders(req *http.Request) {
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	setContextHeaders(req)
+}
+
+// setContextHeaders applies headers attached with ContextWithHeaders.
+func setContextHeaders(req *http.Request) {
+	for k, v := range HeadersFromContext(req.Context()) {
+		req.Header.Set(k, v)
+	}
+}
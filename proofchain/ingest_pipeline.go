@@ -0,0 +1,482 @@
+package proofchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IngestMode is the transport an IngestPipeline uses for a flush.
+type IngestMode string
+
+const (
+	IngestModeAuto  IngestMode = "auto"
+	IngestModeREST  IngestMode = "rest"
+	IngestModeBatch IngestMode = "batch"
+	IngestModeGRPC  IngestMode = "grpc"
+)
+
+const (
+	defaultPipelineBatchSize     = 500
+	defaultPipelineFlushInterval = 250 * time.Millisecond
+	defaultPipelineBatchRate     = 20   // events/sec above which batching is used
+	defaultPipelineGRPCRate      = 2000 // events/sec above which gRPC is used
+)
+
+// PipelineStats are cumulative statistics for an IngestPipeline.
+type PipelineStats struct {
+	Added       int64
+	Succeeded   int64
+	Failed      int64
+	RESTEvents  int64
+	BatchEvents int64
+	GRPCEvents  int64
+	// Rate is the observed event arrival rate (events/sec, smoothed).
+	Rate float64
+	// RESTLatency is the smoothed latency of per-event REST calls.
+	RESTLatency time.Duration
+	// Mode is the transport chosen for the most recent flush.
+	Mode IngestMode
+}
+
+// PipelineOption configures an IngestPipeline.
+type PipelineOption func(*IngestPipeline)
+
+// WithPipelineGRPC enables the gRPC transport for high-throughput periods.
+// The pipeline connects the client on first use. Events that set
+// EventSource, SchemaIDs or Hot are still sent over batched REST, since the
+// stream protocol cannot carry those fields.
+func WithPipelineGRPC(client *GRPCClient) PipelineOption {
+	return func(p *IngestPipeline) {
+		p.grpc = client
+	}
+}
+
+// WithPipelineMode forces a transport instead of choosing automatically.
+func WithPipelineMode(mode IngestMode) PipelineOption {
+	return func(p *IngestPipeline) {
+		p.mode = mode
+	}
+}
+
+// WithPipelineTargetRate sets the expected sustained throughput in
+// events/sec. It is used as a floor for the observed rate when choosing a
+// transport, so a pipeline expecting heavy load starts on batch or gRPC.
+func WithPipelineTargetRate(eventsPerSec float64) PipelineOption {
+	return func(p *IngestPipeline) {
+		p.targetRate = eventsPerSec
+	}
+}
+
+// WithPipelineThresholds sets the rates (events/sec) at which the pipeline
+// switches from per-event REST to batched REST, and from batched REST to gRPC.
+func WithPipelineThresholds(batchRate, grpcRate float64) PipelineOption {
+	return func(p *IngestPipeline) {
+		p.batchRate = batchRate
+		p.grpcRate = grpcRate
+	}
+}
+
+// WithPipelineBatchSize sets the maximum events per batch flush (max 1000).
+func WithPipelineBatchSize(n int) PipelineOption {
+	return func(p *IngestPipeline) {
+		if n > 0 && n <= 1000 {
+			p.batchSize = n
+		}
+	}
+}
+
+// WithPipelineFlushInterval sets how long events may wait in the buffer.
+func WithPipelineFlushInterval(d time.Duration) PipelineOption {
+	return func(p *IngestPipeline) {
+		p.flushInterval = d
+	}
+}
+
+// WithPipelineOnError registers a callback for events that could not be
+// ingested. When some events of a gRPC flush fail, which events is not
+// reported, so it is called once with a nil event and a summary error.
+func WithPipelineOnError(fn func(event *IngestEventRequest, err error)) PipelineOption {
+	return func(p *IngestPipeline) {
+		p.onError = fn
+	}
+}
+
+// IngestPipeline is a single entry point for event ingestion. Events added
+// with Add are buffered and sent via per-event REST, batched REST or gRPC
+// multi-stream depending on the observed arrival rate, REST latency and any
+// configured throughput target.
+//
+// Example:
+//
+//	p := proofchain.NewIngestPipeline(proofchain.NewIngestionClient(apiKey),
+//		proofchain.WithPipelineGRPC(proofchain.NewGRPCClient(apiKey, proofchain.WithNumStreams(4))),
+//		proofchain.WithPipelineOnError(func(e *proofchain.IngestEventRequest, err error) {
+//			log.Printf("ingest failed: %v", err)
+//		}),
+//	)
+//	for _, e := range events {
+//		p.Add(e)
+//	}
+//	stats, err := p.Close(ctx)
+type IngestPipeline struct {
+	ingestion *IngestionClient
+	grpc      *GRPCClient
+
+	mode          IngestMode
+	targetRate    float64
+	batchRate     float64
+	grpcRate      float64
+	batchSize     int
+	flushInterval time.Duration
+	onError       func(*IngestEventRequest, error)
+
+	events chan *IngestEventRequest
+	done   chan struct{}
+	closed atomic.Bool
+	added  atomic.Int64
+
+	grpcConnected bool
+
+	mu    sync.Mutex
+	stats PipelineStats
+	rate  float64
+	lat   time.Duration
+}
+
+// NewIngestPipeline creates and starts a pipeline. Call Close to flush.
+func NewIngestPipeline(ingestion *IngestionClient, opts ...PipelineOption) *IngestPipeline {
+	p := &IngestPipeline{
+		ingestion:     ingestion,
+		mode:          IngestModeAuto,
+		batchRate:     defaultPipelineBatchRate,
+		grpcRate:      defaultPipelineGRPCRate,
+		batchSize:     defaultPipelineBatchSize,
+		flushInterval: defaultPipelineFlushInterval,
+		done:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.events = make(chan *IngestEventRequest, p.batchSize*4)
+
+	go p.run()
+	return p
+}
+
+// Add queues an event, blocking if the buffer is full.
+func (p *IngestPipeline) Add(event *IngestEventRequest) error {
+	if p.closed.Load() {
+		return errors.New("ingest pipeline is closed")
+	}
+	p.added.Add(1)
+	p.events <- event
+	return nil
+}
+
+// Stats returns a snapshot of the pipeline statistics.
+func (p *IngestPipeline) Stats() PipelineStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Added = p.added.Load()
+	stats.Rate = p.rate
+	stats.RESTLatency = p.lat
+	return stats
+}
+
+// Close flushes buffered events, closes the gRPC client if one was used and
+// returns the final statistics. Add must not be called concurrently with Close.
+func (p *IngestPipeline) Close(ctx context.Context) (*PipelineStats, error) {
+	if p.closed.Swap(true) {
+		stats := p.Stats()
+		return &stats, nil
+	}
+	close(p.events)
+
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return nil, NewTimeoutError()
+	}
+
+	var err error
+	if p.grpc != nil && p.grpcConnected {
+		err = p.grpc.Close()
+	}
+	stats := p.Stats()
+	return &stats, err
+}
+
+func (p *IngestPipeline) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	var buf []*IngestEventRequest
+	arrived := 0
+	last := time.Now()
+
+	for {
+		select {
+		case event, ok := <-p.events:
+			if !ok {
+				p.flush(buf)
+				return
+			}
+			buf = append(buf, event)
+			arrived++
+			if p.chooseMode() == IngestModeREST || len(buf) >= p.batchSize {
+				p.flush(buf)
+				buf = nil
+			}
+		case now := <-ticker.C:
+			p.observeRate(arrived, now.Sub(last))
+			arrived, last = 0, now
+			if len(buf) > 0 {
+				p.flush(buf)
+				buf = nil
+			}
+		}
+	}
+}
+
+// observeRate folds the arrivals seen over elapsed into a smoothed rate.
+func (p *IngestPipeline) observeRate(arrived int, elapsed time.Duration) {
+	if elapsed <= 0 {
+		return
+	}
+	current := float64(arrived) / elapsed.Seconds()
+	p.mu.Lock()
+	p.rate = 0.7*p.rate + 0.3*current
+	p.mu.Unlock()
+}
+
+func (p *IngestPipeline) chooseMode() IngestMode {
+	if p.mode != IngestModeAuto {
+		return p.mode
+	}
+
+	p.mu.Lock()
+	rate, lat := p.rate, p.lat
+	p.mu.Unlock()
+	if rate < p.targetRate {
+		rate = p.targetRate
+	}
+
+	switch {
+	case p.grpc != nil && rate >= p.grpcRate:
+		return IngestModeGRPC
+	case rate >= p.batchRate:
+		return IngestModeBatch
+	case lat > 0 && rate*lat.Seconds() >= 1:
+		// Sequential REST calls can no longer keep up with arrivals
+		return IngestModeBatch
+	default:
+		return IngestModeREST
+	}
+}
+
+func (p *IngestPipeline) flush(buf []*IngestEventRequest) {
+	if len(buf) == 0 {
+		return
+	}
+
+	mode := p.chooseMode()
+	if mode == IngestModeGRPC && p.grpc == nil {
+		mode = IngestModeBatch
+	}
+	p.mu.Lock()
+	p.stats.Mode = mode
+	p.mu.Unlock()
+
+	ctx := context.Background()
+	switch mode {
+	case IngestModeGRPC:
+		p.flushGRPC(ctx, buf)
+	case IngestModeBatch:
+		p.flushBatch(ctx, buf)
+	default:
+		p.flushREST(ctx, buf)
+	}
+}
+
+func (p *IngestPipeline) flushREST(ctx context.Context, buf []*IngestEventRequest) {
+	for _, event := range buf {
+		start := time.Now()
+		_, err := p.ingestion.Ingest(ctx, event)
+		elapsed := time.Since(start)
+
+		p.mu.Lock()
+		if p.lat == 0 {
+			p.lat = elapsed
+		} else {
+			p.lat = (p.lat*7 + elapsed*3) / 10
+		}
+		p.stats.RESTEvents++
+		if err != nil {
+			p.stats.Failed++
+		} else {
+			p.stats.Succeeded++
+		}
+		p.mu.Unlock()
+
+		if err != nil {
+			p.fail(event, err)
+		}
+	}
+}
+
+func (p *IngestPipeline) flushBatch(ctx context.Context, buf []*IngestEventRequest) {
+	for start := 0; start < len(buf); start += p.batchSize {
+		end := start + p.batchSize
+		if end > len(buf) {
+			end = len(buf)
+		}
+		chunk := buf[start:end]
+
+		req := &BatchIngestRequest{Events: make([]IngestEventRequest, len(chunk))}
+		for i, e := range chunk {
+			req.Events[i] = *e
+		}
+
+		resp, err := p.ingestion.IngestBatch(ctx, req)
+
+		p.mu.Lock()
+		p.stats.BatchEvents += int64(len(chunk))
+		if err != nil {
+			p.stats.Failed += int64(len(chunk))
+		} else {
+			p.stats.Failed += int64(resp.Failed)
+			p.stats.Succeeded += int64(len(chunk) - resp.Failed)
+		}
+		p.mu.Unlock()
+
+		if err != nil {
+			for _, e := range chunk {
+				p.fail(e, err)
+			}
+			continue
+		}
+		if resp.Failed > 0 {
+			p.failBatchResults(chunk, resp)
+		}
+	}
+}
+
+// failBatchResults reports the events a batch response marked as failed.
+// Results are matched to events by position when the server returns one
+// result per event; otherwise the failures cannot be attributed and every
+// event in the chunk is reported.
+func (p *IngestPipeline) failBatchResults(chunk []*IngestEventRequest, resp *BatchIngestResponse) {
+	if len(resp.Results) != len(chunk) {
+		err := fmt.Errorf("%d of %d events failed in batch", resp.Failed, len(chunk))
+		for _, e := range chunk {
+			p.fail(e, err)
+		}
+		return
+	}
+	for i, r := range resp.Results {
+		if isFailedStatus(r.Status) {
+			p.fail(chunk[i], fmt.Errorf("event rejected by server: %s", r.Status))
+		}
+	}
+}
+
+func (p *IngestPipeline) flushGRPC(ctx context.Context, buf []*IngestEventRequest) {
+	if !p.grpcConnected {
+		if err := p.grpc.Connect(ctx); err != nil {
+			// Fall back to REST batching rather than losing events
+			p.flushBatch(ctx, buf)
+			return
+		}
+		p.grpcConnected = true
+	}
+
+	// Events using fields the stream protocol cannot carry go over REST so
+	// they are attested the same way whichever transport is in use
+	events := make([]*GRPCEvent, 0, len(buf))
+	var grpcSource, rest []*IngestEventRequest
+	for _, e := range buf {
+		event, ok, err := ingestToGRPCEvent(e)
+		switch {
+		case err != nil:
+			p.mu.Lock()
+			p.stats.Failed++
+			p.mu.Unlock()
+			p.fail(e, err)
+		case ok:
+			events = append(events, event)
+			grpcSource = append(grpcSource, e)
+		default:
+			rest = append(rest, e)
+		}
+	}
+	if len(rest) > 0 {
+		p.flushBatch(ctx, rest)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	stats, err := p.grpc.StreamEventsSlice(ctx, events)
+	if err != nil {
+		p.mu.Lock()
+		p.stats.GRPCEvents += int64(len(events))
+		p.stats.Failed += int64(len(events))
+		p.mu.Unlock()
+		for _, e := range grpcSource {
+			p.fail(e, err)
+		}
+		return
+	}
+
+	p.mu.Lock()
+	p.stats.GRPCEvents += int64(len(events))
+	p.stats.Succeeded += stats.TotalSuccess
+	p.stats.Failed += stats.TotalFailed
+	p.mu.Unlock()
+
+	if stats.TotalFailed > 0 {
+		p.fail(nil, fmt.Errorf("%d of %d events failed over gRPC", stats.TotalFailed, len(events)))
+	}
+}
+
+func (p *IngestPipeline) fail(event *IngestEventRequest, err error) {
+	if p.onError != nil {
+		p.onError(event, err)
+	}
+}
+
+// ingestToGRPCEvent converts e for the gRPC stream, with its Redact fields
+// already redacted. It reports false if e uses a field the stream protocol
+// cannot carry (event source, schema IDs or hot attestation) or has a
+// timestamp that is not RFC 3339, in which case e must be sent over REST.
+func ingestToGRPCEvent(e *IngestEventRequest) (*GRPCEvent, bool, error) {
+	if e.EventSource != "" || len(e.SchemaIDs) > 0 || e.Hot {
+		return nil, false, nil
+	}
+	data, err := e.RedactedData()
+	if err != nil {
+		return nil, false, err
+	}
+	event := &GRPCEvent{
+		UserID:    e.UserID,
+		EventType: e.EventType,
+		Data:      data,
+		ClientRef: e.ClientRef,
+	}
+	if e.Timestamp != "" {
+		t, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			return nil, false, nil
+		}
+		event.Timestamp = &t
+	}
+	return event, true, nil
+}
@@ -4,16 +4,24 @@ package proofchain
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	defaultIngestURL     = "https://ingest.proofchain.co.za"
 	defaultIngestTimeout = 30 * time.Second
+
+	// statusBatchSize is the maximum number of IDs per status lookup request.
+	statusBatchSize = 1000
+	// statusConcurrency is the number of status lookup requests in flight.
+	statusConcurrency = 4
 )
 
 // handleHTTPError parses an HTTP error response and returns the appropriate error type.
@@ -60,15 +68,30 @@ type IngestEventRequest struct {
 	Timestamp   string                 `json:"timestamp,omitempty"` // ISO8601/RFC3339 format
 	SchemaIDs   []string               `json:"-"`                   // Sent via header
 	Hot         bool                   `json:"hot,omitempty"`       // Immediate on-chain attestation
+
+	// Redact lists data fields (dot-separated for nested fields) that are
+	// replaced client-side by a keyed hash before sending; raw values never
+	// leave the process. See RedactValue. A path that is absent from Data
+	// is an error unless RedactOptional is set.
+	Redact         []string `json:"-"`
+	RedactionKey   []byte   `json:"-"`
+	RedactOptional bool     `json:"-"`
+
+	// ClientRef is the caller's reference for the event, such as a source
+	// row ID. It is echoed back in IngestEventResponse and passed to the
+	// ResultSink.
+	ClientRef string `json:"client_ref,omitempty"`
 }
 
 // IngestEventResponse is the response from ingesting an event.
 type IngestEventResponse struct {
-	EventID               string `json:"event_id"`
-	CertificateID         string `json:"certificate_id"`
-	Status                string `json:"status"`
-	QueuePosition         int    `json:"queue_position,omitempty"`
-	EstimatedConfirmation string `json:"estimated_confirmation,omitempty"`
+	EventID               string           `json:"event_id"`
+	CertificateID         string           `json:"certificate_id"`
+	Status                string           `json:"status"`
+	QueuePosition         int              `json:"queue_position,omitempty"`
+	EstimatedConfirmation string           `json:"estimated_confirmation,omitempty"`
+	ConsistencyToken      ConsistencyToken `json:"consistency_token,omitempty"`
+	ClientRef             string           `json:"client_ref,omitempty"`
 }
 
 // BatchIngestRequest is the request for ingesting multiple events.
@@ -82,6 +105,10 @@ type BatchIngestResponse struct {
 	Queued      int                   `json:"queued"`
 	Failed      int                   `json:"failed"`
 	Results     []IngestEventResponse `json:"results"`
+
+	// Duplicates is the number of events dropped client-side by the dedupe
+	// window; see WithDedupeWindow.
+	Duplicates int `json:"duplicates,omitempty"`
 }
 
 // IngestionClientOption is a function that configures the ingestion client.
@@ -94,6 +121,27 @@ func WithIngestURL(url string) IngestionClientOption {
 	}
 }
 
+// WithIngestRegion points the client at the ingestion hostname for region.
+// It replaces any earlier WithIngestURL.
+func WithIngestRegion(region Region) IngestionClientOption {
+	return func(c *IngestionClient) {
+		ep, err := region.Endpoints()
+		if err != nil {
+			c.configErr = err
+			return
+		}
+		c.ingestURL = ep.IngestURL
+	}
+}
+
+// WithIngestFailoverRegion fails over to region after sustained failures;
+// see WithFailoverRegion.
+func WithIngestFailoverRegion(region Region) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.failoverRegion = region
+	}
+}
+
 // WithIngestTimeout sets a custom timeout for ingestion requests.
 func WithIngestTimeout(timeout time.Duration) IngestionClientOption {
 	return func(c *IngestionClient) {
@@ -101,6 +149,59 @@ func WithIngestTimeout(timeout time.Duration) IngestionClientOption {
 	}
 }
 
+// WithIngestHTTPClient sends ingestion requests with httpClient, for example
+// one instrumented for tracing. The client is not modified: the ingestion
+// timeout and any TLS or proxy options are applied to a copy.
+func WithIngestHTTPClient(httpClient *http.Client) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithIngestTLSConfig sets the TLS configuration used for ingestion
+// requests; see WithTLSConfig.
+func WithIngestTLSConfig(cfg *tls.Config) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.transport.tlsConfig = cfg
+	}
+}
+
+// WithIngestProxy routes ingestion requests through the HTTP or HTTPS proxy
+// at proxyURL; see WithProxy.
+func WithIngestProxy(proxyURL string) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.transport.setProxy(proxyURL)
+	}
+}
+
+// WithIngestClientCertificate presents the PEM certificate and key in
+// certFile and keyFile for mutual TLS. If they cannot be loaded, every
+// request fails with the load error.
+func WithIngestClientCertificate(certFile, keyFile string) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.transport.setClientCertificate(certFile, keyFile)
+	}
+}
+
+// WithDeadLetterSink sends events that fail during IngestBatch, either
+// because the request failed or because the server rejected them, to sink.
+func WithDeadLetterSink(sink DeadLetterSink) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.deadLetters = sink
+	}
+}
+
+// WithMaxEventsPerSecond caps outbound throughput at n events per second
+// across Ingest and IngestBatch, blocking callers as needed. A batch counts
+// as one event per item.
+func WithMaxEventsPerSecond(n int) IngestionClientOption {
+	return func(c *IngestionClient) {
+		if n > 0 {
+			c.limiter = newTokenBucket(n)
+		}
+	}
+}
+
 // IngestionClient is a high-performance client for the Rust ingestion API.
 // Use this for maximum throughput when ingesting events.
 type IngestionClient struct {
@@ -108,6 +209,22 @@ type IngestionClient struct {
 	ingestURL  string
 	timeout    time.Duration
 	httpClient *http.Client
+	transport  transportConfig
+
+	deadLetters DeadLetterSink
+	limiter     *tokenBucket
+	metrics     MetricsRecorder
+	clockSkew   *ClockSkew
+
+	dedupe       *dedupeWindow
+	interceptors []EventInterceptor
+	results      ResultSink
+	quota        *QuotaGuard
+	stats        ingestStats
+
+	failoverRegion Region
+	failover       *regionFailover
+	configErr      error
 }
 
 // NewIngestionClient creates a new high-performance ingestion client.
@@ -134,19 +251,92 @@ func NewIngestionClient(apiKey string, opts ...IngestionClientOption) *Ingestion
 		opt(c)
 	}
 
-	c.httpClient.Timeout = c.timeout
+	// Work on a copy, so a client passed to WithIngestHTTPClient is not
+	// modified
+	hc := *c.httpClient
+	hc.Timeout = c.timeout
+	if c.transport.configured() {
+		hc.Transport = c.transport.roundTripper()
+	}
+	c.httpClient = &hc
+	if c.failoverRegion != "" {
+		if ep, err := c.failoverRegion.Endpoints(); err != nil {
+			c.configErr = err
+		} else {
+			c.failover = newRegionFailover(c.ingestURL, ep.IngestURL, c.httpClient)
+		}
+	}
 	return c
 }
 
+// url returns the full URL for path on the active region.
+func (c *IngestionClient) url(path string) string {
+	if c.failover != nil {
+		return c.failover.baseURL() + path
+	}
+	return c.ingestURL + path
+}
+
+// send sends req once, recording the outcome for metrics and failover.
+func (c *IngestionClient) send(req *http.Request) (*http.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	resp, err := timedDo(c.httpClient, c.metrics, req)
+	if c.failover != nil {
+		c.failover.record(req, resp, err)
+	}
+	return resp, err
+}
+
 // Ingest sends a single event to the high-performance Rust ingestion API.
 // Events are attested immediately upon ingestion.
 func (c *IngestionClient) Ingest(ctx context.Context, req *IngestEventRequest) (*IngestEventResponse, error) {
+	req, err := intercept(c.interceptors, req)
+	if err != nil {
+		return nil, err
+	}
+	if c.quota != nil {
+		if err := c.quota.Check(ctx, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	var key dedupeKey
+	if c.dedupe != nil {
+		key = eventKey(req.UserID, req.EventType, "", req.Data)
+		if !c.dedupe.admit(key) {
+			if c.metrics != nil {
+				c.metrics.AddDropped("duplicate", 1)
+			}
+			return &IngestEventResponse{Status: IngestStatusDuplicate}, nil
+		}
+	}
+
+	resp, err := c.ingest(ctx, req)
+	if err != nil && c.dedupe != nil {
+		c.dedupe.forget(key)
+	}
+	if c.metrics != nil {
+		if err != nil {
+			c.metrics.AddIngested("rest", 0, 1)
+		} else {
+			c.metrics.AddIngested("rest", 1, 0)
+		}
+	}
+	return resp, err
+}
+
+func (c *IngestionClient) ingest(ctx context.Context, req *IngestEventRequest) (*IngestEventResponse, error) {
 	source := req.EventSource
 	if source == "" {
 		source = "sdk"
 	}
 
-	data := req.Data
+	data, err := redactData(req.Data, req.Redact, req.RedactionKey, req.RedactOptional)
+	if err != nil {
+		return nil, err
+	}
 	if data == nil {
 		data = map[string]interface{}{}
 	}
@@ -157,39 +347,43 @@ func (c *IngestionClient) Ingest(ctx context.Context, req *IngestEventRequest) (
 		"data":         data,
 		"event_source": source,
 	}
-	if req.Timestamp != "" {
-		payload["timestamp"] = req.Timestamp
+	timestamp := adjustTimestamp(c.clockSkew, req.Timestamp)
+	if timestamp != "" {
+		payload["timestamp"] = timestamp
 	}
 	if req.Hot {
 		payload["hot"] = true
 	}
+	if req.ClientRef != "" {
+		payload["client_ref"] = req.ClientRef
+	}
 
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.ingestURL+"/events/ingest", bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.url("/events/ingest"), bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	setContextHeaders(httpReq)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("X-API-Key", c.apiKey)
 	httpReq.Header.Set("User-Agent", userAgent)
 
 	if len(req.SchemaIDs) > 0 {
-		schemas := ""
-		for i, id := range req.SchemaIDs {
-			if i > 0 {
-				schemas += ","
-			}
-			schemas += id
+		httpReq.Header.Set("X-Schemas", strings.Join(req.SchemaIDs, ","))
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, 1); err != nil {
+			return nil, err
 		}
-		httpReq.Header.Set("X-Schemas", schemas)
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -201,19 +395,24 @@ func (c *IngestionClient) Ingest(ctx context.Context, req *IngestEventRequest) (
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, handleHTTPError(resp.StatusCode, respBody)
+		return nil, diagnoseTimestampError(handleHTTPError(resp.StatusCode, respBody), timestamp, c.clockSkew)
 	}
 
 	var result struct {
-		EventID               string `json:"event_id"`
-		CertificateID         string `json:"certificate_id"`
-		Status                string `json:"status"`
-		QueuePosition         int    `json:"queue_position"`
-		EstimatedConfirmation string `json:"estimated_confirmation"`
+		EventID               string           `json:"event_id"`
+		CertificateID         string           `json:"certificate_id"`
+		Status                string           `json:"status"`
+		QueuePosition         int              `json:"queue_position"`
+		EstimatedConfirmation string           `json:"estimated_confirmation"`
+		ConsistencyToken      ConsistencyToken `json:"consistency_token"`
+		ClientRef             string           `json:"client_ref"`
 	}
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if result.ClientRef == "" {
+		result.ClientRef = req.ClientRef
+	}
 
 	return &IngestEventResponse{
 		EventID:               result.EventID,
@@ -221,23 +420,262 @@ func (c *IngestionClient) Ingest(ctx context.Context, req *IngestEventRequest) (
 		Status:                result.Status,
 		QueuePosition:         result.QueuePosition,
 		EstimatedConfirmation: result.EstimatedConfirmation,
+		ConsistencyToken:      result.ConsistencyToken,
+		ClientRef:             result.ClientRef,
 	}, nil
 }
 
 // IngestBatch sends multiple events in a single request (up to 1000 events).
 // More efficient than individual calls for bulk data.
+//
+// If a DeadLetterSink is configured, events that fail are written to it: all
+// events when the request itself fails, or those whose per-event result has a
+// failed status. Results are matched to events by ClientRef when every
+// event has a unique one, and by position otherwise.
+//
+// If a ResultSink is configured, the results are written to it before
+// IngestBatch returns. If that fails, the response is returned together
+// with the error, since the events were ingested.
 func (c *IngestionClient) IngestBatch(ctx context.Context, req *BatchIngestRequest) (*BatchIngestResponse, error) {
 	if len(req.Events) > 1000 {
 		return nil, NewValidationError("batch size cannot exceed 1000 events", nil)
 	}
 
+	if len(c.interceptors) > 0 {
+		intercepted := &BatchIngestRequest{Events: make([]IngestEventRequest, len(req.Events))}
+		for i := range req.Events {
+			event, err := intercept(c.interceptors, &req.Events[i])
+			if err != nil {
+				return nil, fmt.Errorf("event %d: %w", i, err)
+			}
+			intercepted.Events[i] = *event
+		}
+		req = intercepted
+	}
+
+	if c.quota != nil {
+		if err := c.quota.Check(ctx, len(req.Events)); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *BatchIngestResponse
+	var err error
+	if c.dedupe != nil {
+		resp, err = c.ingestBatchDeduped(ctx, req)
+	} else {
+		resp, err = c.ingestBatchObserved(ctx, req)
+	}
+	if err != nil || c.results == nil {
+		return resp, err
+	}
+	if err := c.writeBatchResults(ctx, req, resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// ingestBatchDeduped drops duplicates from req before sending it, and
+// reports them in the response at their original positions.
+func (c *IngestionClient) ingestBatchDeduped(ctx context.Context, req *BatchIngestRequest) (*BatchIngestResponse, error) {
+	var admitted BatchIngestRequest
+	var keys []dedupeKey
+	var positions []int
+	for i, e := range req.Events {
+		key := eventKey(e.UserID, e.EventType, "", e.Data)
+		if c.dedupe.admit(key) {
+			admitted.Events = append(admitted.Events, e)
+			keys = append(keys, key)
+			positions = append(positions, i)
+		}
+	}
+	duplicates := len(req.Events) - len(admitted.Events)
+	if duplicates > 0 && c.metrics != nil {
+		c.metrics.AddDropped("duplicate", duplicates)
+	}
+
+	resp := &BatchIngestResponse{}
+	if len(admitted.Events) > 0 {
+		var err error
+		resp, err = c.ingestBatchObserved(ctx, &admitted)
+		if err != nil {
+			for _, key := range keys {
+				c.dedupe.forget(key)
+			}
+			return nil, err
+		}
+		c.forgetFailed(keys, resp)
+	}
+	resp.Duplicates = duplicates
+	if duplicates == 0 {
+		return resp, nil
+	}
+
+	// Spread the results back over the original positions when the server
+	// returned one per event, filling the gaps with duplicates
+	if len(resp.Results) == len(admitted.Events) {
+		results := make([]IngestEventResponse, len(req.Events))
+		for i := range results {
+			results[i].Status = IngestStatusDuplicate
+		}
+		for i, r := range resp.Results {
+			results[positions[i]] = r
+		}
+		resp.Results = results
+	}
+	return resp, nil
+}
+
+// forgetFailed removes the keys of events the server rejected, so retrying
+// them is admitted. When the failures cannot be matched to events, every key
+// is forgotten.
+func (c *IngestionClient) forgetFailed(keys []dedupeKey, resp *BatchIngestResponse) {
+	if resp.Failed == 0 {
+		return
+	}
+	if len(resp.Results) != len(keys) {
+		for _, key := range keys {
+			c.dedupe.forget(key)
+		}
+		return
+	}
+	for i, r := range resp.Results {
+		if isFailedStatus(r.Status) {
+			c.dedupe.forget(keys[i])
+		}
+	}
+}
+
+// ingestBatchObserved sends req, recording metrics and dead-lettering
+// failed events.
+func (c *IngestionClient) ingestBatchObserved(ctx context.Context, req *BatchIngestRequest) (*BatchIngestResponse, error) {
+	resp, err := c.ingestBatch(ctx, req)
+	if c.metrics != nil {
+		if err != nil {
+			c.metrics.AddIngested("rest", 0, len(req.Events))
+		} else {
+			c.metrics.AddIngested("rest", len(req.Events)-resp.Failed, resp.Failed)
+		}
+	}
+	if c.deadLetters == nil {
+		return resp, err
+	}
+
+	if err != nil {
+		for i := range req.Events {
+			c.deadLetter(&req.Events[i], err)
+		}
+		return nil, err
+	}
+	if resp.Failed > 0 && len(resp.Results) == len(req.Events) {
+		for i, r := range resp.Results {
+			if isFailedStatus(r.Status) {
+				c.deadLetter(&req.Events[i], fmt.Errorf("event rejected by server: %s", r.Status))
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (c *IngestionClient) deadLetter(event *IngestEventRequest, err error) {
+	letter := newDeadLetter("rest", err)
+	e := *event
+	// Keep the data as it was sent so redacted fields never reach the sink
+	// in clear text; data that could not be redacted is left out
+	data, redactErr := event.RedactedData()
+	if redactErr != nil {
+		data = nil
+	}
+	e.Data = data
+	e.Redact = nil
+	e.RedactionKey = nil
+	letter.Event = &e
+	letter.SchemaIDs = event.SchemaIDs
+	c.deadLetters.Write(letter)
+}
+
+// ingestBatch sends req. The batch endpoint takes schema IDs from the
+// X-Schemas header, so events are sent in one request per distinct set of
+// SchemaIDs and the results merged back into their original positions.
+// Results are dropped when a request's results cannot be matched to its
+// events. An error is returned only if no request succeeded; events in a
+// request that failed after another succeeded are reported as failed.
+func (c *IngestionClient) ingestBatch(ctx context.Context, req *BatchIngestRequest) (*BatchIngestResponse, error) {
+	var order []string
+	groups := make(map[string][]int)
+	for i, e := range req.Events {
+		key := strings.Join(e.SchemaIDs, ",")
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+	if len(order) <= 1 {
+		schemas := ""
+		if len(order) == 1 {
+			schemas = order[0]
+		}
+		return c.sendBatch(ctx, req.Events, schemas)
+	}
+
+	merged := &BatchIngestResponse{Results: make([]IngestEventResponse, len(req.Events))}
+	attributed := true
+	var firstErr error
+	sent := false
+	for _, key := range order {
+		positions := groups[key]
+		events := make([]IngestEventRequest, len(positions))
+		for i, pos := range positions {
+			events[i] = req.Events[pos]
+		}
+		resp, err := c.sendBatch(ctx, events, key)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			merged.TotalEvents += len(events)
+			merged.Failed += len(events)
+			for i, pos := range positions {
+				merged.Results[pos] = IngestEventResponse{ClientRef: events[i].ClientRef, Status: "failed"}
+			}
+			continue
+		}
+		sent = true
+		merged.TotalEvents += resp.TotalEvents
+		merged.Queued += resp.Queued
+		merged.Failed += resp.Failed
+		if len(resp.Results) != len(positions) {
+			attributed = false
+			continue
+		}
+		for i, pos := range positions {
+			merged.Results[pos] = resp.Results[i]
+		}
+	}
+	if !sent {
+		return nil, firstErr
+	}
+	if !attributed {
+		merged.Results = nil
+	}
+	return merged, nil
+}
+
+// sendBatch sends events in a single batch request, with schemas, if set,
+// as the X-Schemas header.
+func (c *IngestionClient) sendBatch(ctx context.Context, reqEvents []IngestEventRequest, schemas string) (*BatchIngestResponse, error) {
+	req := &BatchIngestRequest{Events: reqEvents}
 	events := make([]map[string]interface{}, len(req.Events))
+	var timestamps []string
 	for i, e := range req.Events {
 		source := e.EventSource
 		if source == "" {
 			source = "sdk"
 		}
-		data := e.Data
+		data, err := redactData(e.Data, e.Redact, e.RedactionKey, e.RedactOptional)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
 		if data == nil {
 			data = map[string]interface{}{}
 		}
@@ -247,12 +685,16 @@ func (c *IngestionClient) IngestBatch(ctx context.Context, req *BatchIngestReque
 			"data":         data,
 			"event_source": source,
 		}
-		if e.Timestamp != "" {
-			event["timestamp"] = e.Timestamp
+		if timestamp := adjustTimestamp(c.clockSkew, e.Timestamp); timestamp != "" {
+			event["timestamp"] = timestamp
+			timestamps = append(timestamps, timestamp)
 		}
 		if e.Hot {
 			event["hot"] = true
 		}
+		if e.ClientRef != "" {
+			event["client_ref"] = e.ClientRef
+		}
 		events[i] = event
 	}
 
@@ -262,28 +704,45 @@ func (c *IngestionClient) IngestBatch(ctx context.Context, req *BatchIngestReque
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.ingestURL+"/events/ingest/batch", bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.url("/events/ingest/batch"), bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	setContextHeaders(httpReq)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("X-API-Key", c.apiKey)
 	httpReq.Header.Set("User-Agent", userAgent)
+	if schemas != "" {
+		httpReq.Header.Set("X-Schemas", schemas)
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, len(req.Events)); err != nil {
+			return nil, err
+		}
+	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	sentAt := time.Now()
+	resp, err := c.send(httpReq)
 	if err != nil {
+		c.stats.record(len(events), len(events), len(body), 0, false)
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		c.stats.record(len(events), len(events), len(body), 0, false)
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
+	latency := time.Since(sentAt)
 
 	if resp.StatusCode >= 400 {
-		return nil, handleHTTPError(resp.StatusCode, respBody)
+		c.stats.record(len(events), len(events), len(body), latency, true)
+		// The server does not say which timestamp it rejected; diagnose the
+		// one furthest from the local clock
+		return nil, diagnoseTimestampError(handleHTTPError(resp.StatusCode, respBody), furthestTimestamp(timestamps), c.clockSkew)
 	}
 
 	var result struct {
@@ -295,14 +754,17 @@ func (c *IngestionClient) IngestBatch(ctx context.Context, req *BatchIngestReque
 			EventID       string `json:"event_id"`
 			CertificateID string `json:"certificate_id"`
 			Status        string `json:"status"`
+			ClientRef     string `json:"client_ref"`
 		} `json:"results"`
 		Responses []struct {
 			EventID       string `json:"event_id"`
 			CertificateID string `json:"certificate_id"`
 			Status        string `json:"status"`
+			ClientRef     string `json:"client_ref"`
 		} `json:"responses"`
 	}
 	if err := json.Unmarshal(respBody, &result); err != nil {
+		c.stats.record(len(events), len(events), len(body), latency, true)
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -318,14 +780,18 @@ func (c *IngestionClient) IngestBatch(ctx context.Context, req *BatchIngestReque
 				EventID       string `json:"event_id"`
 				CertificateID string `json:"certificate_id"`
 				Status        string `json:"status"`
+				ClientRef     string `json:"client_ref"`
 			}{
 				EventID:       r.EventID,
 				CertificateID: r.CertificateID,
 				Status:        r.Status,
+				ClientRef:     r.ClientRef,
 			})
 		}
 	}
 
+	c.stats.record(len(events), result.Failed, len(body), latency, true)
+
 	response := &BatchIngestResponse{
 		TotalEvents: totalEvents,
 		Queued:      result.Queued,
@@ -338,23 +804,58 @@ func (c *IngestionClient) IngestBatch(ctx context.Context, req *BatchIngestReque
 			EventID:       r.EventID,
 			CertificateID: r.CertificateID,
 			Status:        r.Status,
+			ClientRef:     r.ClientRef,
 		}
 	}
+	response.Results = alignResults(req.Events, response.Results)
 
 	return response, nil
 }
 
+// alignResults reorders results to match events by their echoed ClientRef,
+// for servers that omit or reorder results, e.g. for events that fail
+// validation. Events the server returned no result for are reported as
+// failed. It returns results unchanged unless every event has a unique
+// ClientRef.
+func alignResults(events []IngestEventRequest, results []IngestEventResponse) []IngestEventResponse {
+	positions := make(map[string]int, len(events))
+	for i, e := range events {
+		if e.ClientRef == "" {
+			return results
+		}
+		if _, dup := positions[e.ClientRef]; dup {
+			return results
+		}
+		positions[e.ClientRef] = i
+	}
+
+	aligned := make([]IngestEventResponse, len(events))
+	for i, e := range events {
+		aligned[i] = IngestEventResponse{ClientRef: e.ClientRef, Status: "failed"}
+	}
+	for _, r := range results {
+		i, ok := positions[r.ClientRef]
+		if !ok {
+			// Not echoed; fall back to matching by position
+			return results
+		}
+		aligned[i] = r
+	}
+	return aligned
+}
+
 // GetEventStatus retrieves the status of an event by ID.
 func (c *IngestionClient) GetEventStatus(ctx context.Context, eventID string) (string, error) {
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", c.ingestURL+"/events/"+eventID+"/status", nil)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", c.url("/events/"+eventID+"/status"), nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
+	setContextHeaders(httpReq)
 	httpReq.Header.Set("X-API-Key", c.apiKey)
 	httpReq.Header.Set("User-Agent", userAgent)
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send(httpReq)
 	if err != nil {
 		return "", fmt.Errorf("request failed: %w", err)
 	}
@@ -378,3 +879,89 @@ func (c *IngestionClient) GetEventStatus(ctx context.Context, eventID string) (s
 
 	return result.Status, nil
 }
+
+// GetEventStatuses retrieves the status of many events, keyed by event ID.
+// IDs are looked up in batches of up to 1000 with a few requests in flight.
+// IDs the server does not know are omitted from the result. If any batch
+// fails, the first error is returned along with the statuses collected so
+// far.
+func (c *IngestionClient) GetEventStatuses(ctx context.Context, eventIDs []string) (map[string]string, error) {
+	statuses := make(map[string]string, len(eventIDs))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	sem := make(chan struct{}, statusConcurrency)
+
+	for start := 0; start < len(eventIDs); start += statusBatchSize {
+		end := start + statusBatchSize
+		if end > len(eventIDs) {
+			end = len(eventIDs)
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(ids []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			batch, err := c.getEventStatusBatch(ctx, ids)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for id, status := range batch {
+				statuses[id] = status
+			}
+		}(eventIDs[start:end])
+	}
+
+	wg.Wait()
+	return statuses, firstErr
+}
+
+func (c *IngestionClient) getEventStatusBatch(ctx context.Context, eventIDs []string) (map[string]string, error) {
+	body, err := json.Marshal(map[string]interface{}{"event_ids": eventIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.url("/events/status/batch"), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	setContextHeaders(httpReq)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-API-Key", c.apiKey)
+	httpReq.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.send(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, handleHTTPError(resp.StatusCode, respBody)
+	}
+
+	var result struct {
+		Statuses map[string]string `json:"statuses"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.Statuses, nil
+}
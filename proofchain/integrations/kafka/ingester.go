@@ -0,0 +1,145 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain"
+)
+
+// maxRESTBatch is the largest batch accepted by IngestionClient.IngestBatch.
+const maxRESTBatch = 1000
+
+// Ingester ingests a batch of events. It returns a *RejectedError when the
+// request succeeded but the server rejected some events, and any other error
+// when the batch may not have been ingested at all.
+type Ingester interface {
+	IngestEvents(ctx context.Context, events []proofchain.IngestEventRequest) error
+}
+
+// RejectedError reports events rejected by the server in an otherwise
+// successful batch.
+type RejectedError struct {
+	Total    int
+	Rejected int
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("%d of %d events rejected by server", e.Rejected, e.Total)
+}
+
+// NewRESTIngester returns an Ingester that uses the REST batch endpoint.
+func NewRESTIngester(client *proofchain.IngestionClient) Ingester {
+	return &restIngester{client: client}
+}
+
+type restIngester struct {
+	client *proofchain.IngestionClient
+}
+
+func (r *restIngester) IngestEvents(ctx context.Context, events []proofchain.IngestEventRequest) error {
+	rejected := 0
+	for start := 0; start < len(events); start += maxRESTBatch {
+		end := start + maxRESTBatch
+		if end > len(events) {
+			end = len(events)
+		}
+		resp, err := r.client.IngestBatch(ctx, &proofchain.BatchIngestRequest{Events: events[start:end]})
+		if err != nil {
+			return err
+		}
+		rejected += resp.Failed
+	}
+	if rejected > 0 {
+		return &RejectedError{Total: len(events), Rejected: rejected}
+	}
+	return nil
+}
+
+// NewGRPCIngester returns an Ingester that streams events over gRPC. The
+// client must already be connected. Events using fields the stream protocol
+// cannot carry (event source, schema IDs or hot attestation) or with a
+// timestamp that is not RFC 3339 are sent through rest instead, as
+// IngestPipeline does, so they are attested the same way whichever transport
+// is in use.
+func NewGRPCIngester(client *proofchain.GRPCClient, rest *proofchain.IngestionClient) Ingester {
+	return &grpcIngester{client: client, rest: &restIngester{client: rest}}
+}
+
+type grpcIngester struct {
+	client *proofchain.GRPCClient
+	rest   *restIngester
+}
+
+func (g *grpcIngester) IngestEvents(ctx context.Context, events []proofchain.IngestEventRequest) error {
+	var batch []*proofchain.GRPCEvent
+	var rest []proofchain.IngestEventRequest
+	for i, e := range events {
+		event, ok, err := toGRPCEvent(&e)
+		if err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
+		}
+		if ok {
+			batch = append(batch, event)
+		} else {
+			rest = append(rest, e)
+		}
+	}
+
+	rejected := 0
+	if len(rest) > 0 {
+		if g.rest.client == nil {
+			return fmt.Errorf("%d events cannot be streamed and no REST client is configured", len(rest))
+		}
+		err := g.rest.IngestEvents(ctx, rest)
+		var rejectedErr *RejectedError
+		if errors.As(err, &rejectedErr) {
+			rejected += rejectedErr.Rejected
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if len(batch) > 0 {
+		stats, err := g.client.StreamEventsSlice(ctx, batch)
+		if err != nil {
+			return err
+		}
+		if stats.TotalSuccess == 0 && stats.TotalFailed > 0 && len(rest) == 0 {
+			return fmt.Errorf("all %d events failed to stream", stats.TotalFailed)
+		}
+		rejected += int(stats.TotalFailed)
+	}
+	if rejected > 0 {
+		return &RejectedError{Total: len(events), Rejected: rejected}
+	}
+	return nil
+}
+
+// toGRPCEvent converts e for the gRPC stream, with its Redact fields already
+// redacted. It reports false if e must be sent over REST.
+func toGRPCEvent(e *proofchain.IngestEventRequest) (*proofchain.GRPCEvent, bool, error) {
+	if e.EventSource != "" || len(e.SchemaIDs) > 0 || e.Hot {
+		return nil, false, nil
+	}
+	data, err := e.RedactedData()
+	if err != nil {
+		return nil, false, err
+	}
+	event := &proofchain.GRPCEvent{
+		UserID:    e.UserID,
+		EventType: e.EventType,
+		Data:      data,
+		ClientRef: e.ClientRef,
+	}
+	if e.Timestamp != "" {
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			return nil, false, nil
+		}
+		event.Timestamp = &ts
+	}
+	return event, true, nil
+}
@@ -0,0 +1,270 @@
+// Package kafka bridges Kafka topics to ProofChain ingestion.
+//
+// A Bridge reads messages from a Consumer, maps each one to an
+// IngestEventRequest, ingests them in batches and commits offsets only once
+// the batch has been ingested, giving at-least-once delivery. The package does
+// not depend on a Kafka client library; Consumer is satisfied by a small
+// adapter around whichever client the application already uses. For
+// github.com/segmentio/kafka-go:
+//
+//	type readerConsumer struct{ r *kafkago.Reader }
+//
+//	func (c readerConsumer) FetchMessage(ctx context.Context) (kafka.Message, error) {
+//		m, err := c.r.FetchMessage(ctx)
+//		if err != nil {
+//			return kafka.Message{}, err
+//		}
+//		return kafka.Message{Topic: m.Topic, Partition: m.Partition, Offset: m.Offset,
+//			Key: m.Key, Value: m.Value, Time: m.Time, Raw: m}, nil
+//	}
+//
+//	func (c readerConsumer) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+//		raw := make([]kafkago.Message, len(msgs))
+//		for i, m := range msgs {
+//			raw[i] = m.Raw.(kafkago.Message)
+//		}
+//		return c.r.CommitMessages(ctx, raw...)
+//	}
+//
+// and then:
+//
+//	ingest := proofchain.NewIngestionClient(apiKey)
+//	bridge := kafka.NewBridge(readerConsumer{reader}, kafka.NewRESTIngester(ingest))
+//	err := bridge.Run(ctx)
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain"
+)
+
+const (
+	defaultBatchSize     = 500
+	defaultFlushInterval = time.Second
+)
+
+// Message is a Kafka message as seen by the bridge.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string][]byte
+	Time      time.Time
+	// Raw is the client library's own message value, for use by the
+	// Consumer adapter when committing.
+	Raw interface{}
+}
+
+// Consumer is the subset of a Kafka consumer used by the bridge.
+// FetchMessage must not commit the message; CommitMessages commits the given
+// messages' offsets. Both must return when ctx is done.
+type Consumer interface {
+	FetchMessage(ctx context.Context) (Message, error)
+	CommitMessages(ctx context.Context, msgs ...Message) error
+}
+
+// Mapper converts a message to an event. Returning a nil event and nil error
+// skips the message; its offset is still committed.
+type Mapper func(msg Message) (*proofchain.IngestEventRequest, error)
+
+// JSONMapper decodes the message value as an IngestEventRequest. It is the
+// default mapper.
+func JSONMapper(msg Message) (*proofchain.IngestEventRequest, error) {
+	var event proofchain.IngestEventRequest
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		return nil, err
+	}
+	if event.UserID == "" || event.EventType == "" {
+		return nil, errors.New("user_id and event_type are required")
+	}
+	return &event, nil
+}
+
+// Option configures a Bridge.
+type Option func(*Bridge)
+
+// WithMapper sets the function that converts messages to events.
+func WithMapper(mapper Mapper) Option {
+	return func(b *Bridge) {
+		b.mapper = mapper
+	}
+}
+
+// WithBatchSize sets the maximum number of events ingested per batch
+// (default 500).
+func WithBatchSize(n int) Option {
+	return func(b *Bridge) {
+		if n > 0 {
+			b.batchSize = n
+		}
+	}
+}
+
+// WithFlushInterval sets the maximum time a partial batch waits before it is
+// ingested (default 1s).
+func WithFlushInterval(d time.Duration) Option {
+	return func(b *Bridge) {
+		if d > 0 {
+			b.flushInterval = d
+		}
+	}
+}
+
+// WithMapErrorHandler sets the handler for messages the mapper rejects.
+// Returning nil skips the message and commits its offset, for example after
+// copying it to a dead-letter topic. By default a mapping error stops Run.
+func WithMapErrorHandler(fn func(ctx context.Context, msg Message, err error) error) Option {
+	return func(b *Bridge) {
+		b.onMapError = fn
+	}
+}
+
+// WithRejectedHandler sets the handler for batches in which the server
+// rejected some events. Returning nil commits the batch; configure a
+// DeadLetterSink on the ingestion client to keep the rejected events. By
+// default rejections stop Run without committing.
+func WithRejectedHandler(fn func(ctx context.Context, msgs []Message, err *RejectedError) error) Option {
+	return func(b *Bridge) {
+		b.onRejected = fn
+	}
+}
+
+// Bridge consumes messages from Kafka and ingests them into ProofChain.
+type Bridge struct {
+	consumer      Consumer
+	ingester      Ingester
+	mapper        Mapper
+	batchSize     int
+	flushInterval time.Duration
+	onMapError    func(ctx context.Context, msg Message, err error) error
+	onRejected    func(ctx context.Context, msgs []Message, err *RejectedError) error
+}
+
+// NewBridge creates a bridge from consumer to ingester.
+func NewBridge(consumer Consumer, ingester Ingester, opts ...Option) *Bridge {
+	b := &Bridge{
+		consumer:      consumer,
+		ingester:      ingester,
+		mapper:        JSONMapper,
+		batchSize:     defaultBatchSize,
+		flushInterval: defaultFlushInterval,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+type fetchResult struct {
+	msg Message
+	err error
+}
+
+// Run consumes messages until ctx is done or an error occurs. Offsets are
+// committed only after their batch has been ingested, so messages of a batch
+// that had not been committed when Run returned are delivered again on the
+// next run. Run returns ctx.Err() when ctx is done.
+func (b *Bridge) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fetched := make(chan fetchResult, b.batchSize)
+	go func() {
+		defer close(fetched)
+		for {
+			msg, err := b.consumer.FetchMessage(ctx)
+			select {
+			case fetched <- fetchResult{msg: msg, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var msgs []Message
+	var events []proofchain.IngestEventRequest
+	timer := time.NewTimer(b.flushInterval)
+	defer timer.Stop()
+
+	flush := func() error {
+		if len(msgs) > 0 {
+			if err := b.flush(ctx, msgs, events); err != nil {
+				return err
+			}
+		}
+		msgs, events = msgs[:0], events[:0]
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			if err := flush(); err != nil {
+				return err
+			}
+			timer.Reset(b.flushInterval)
+		case r, ok := <-fetched:
+			if !ok {
+				return ctx.Err()
+			}
+			if r.err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("kafka: fetch message: %w", r.err)
+			}
+
+			event, err := b.mapper(r.msg)
+			if err != nil {
+				if b.onMapError == nil {
+					return fmt.Errorf("kafka: map message %s/%d@%d: %w", r.msg.Topic, r.msg.Partition, r.msg.Offset, err)
+				}
+				if err := b.onMapError(ctx, r.msg, err); err != nil {
+					return err
+				}
+			}
+			msgs = append(msgs, r.msg)
+			if event != nil {
+				events = append(events, *event)
+			}
+			if len(msgs) >= b.batchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+				timer.Reset(b.flushInterval)
+			}
+		}
+	}
+}
+
+// flush ingests a batch and commits its messages.
+func (b *Bridge) flush(ctx context.Context, msgs []Message, events []proofchain.IngestEventRequest) error {
+	if len(events) > 0 {
+		err := b.ingester.IngestEvents(ctx, events)
+		var rejected *RejectedError
+		switch {
+		case errors.As(err, &rejected) && b.onRejected != nil:
+			if err := b.onRejected(ctx, msgs, rejected); err != nil {
+				return err
+			}
+		case err != nil:
+			return fmt.Errorf("kafka: ingest batch: %w", err)
+		}
+	}
+	if err := b.consumer.CommitMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("kafka: commit offsets: %w", err)
+	}
+	return nil
+}
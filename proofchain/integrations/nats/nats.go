@@ -0,0 +1,389 @@
+// Package nats bridges NATS JetStream streams to ProofChain ingestion.
+//
+// A Bridge fetches messages from a durable JetStream consumer, maps each one
+// to an IngestEventRequest, ingests them in batches and acknowledges each
+// message only after its event has been ingested. Events the API rejects are
+// published to a dead-letter subject before being acknowledged; when the API
+// reports rejections it cannot attribute to events, or rejects a whole batch
+// with a non-retryable error, the whole batch is. Batches that fail in transit
+// are negatively acknowledged for redelivery after a delay.
+//
+// The package does not depend on a NATS client library. Msg is satisfied by
+// jetstream.Msg from github.com/nats-io/nats.go/jetstream, and Fetcher by a
+// small adapter around a durable consumer:
+//
+//	type consumerFetcher struct{ c jetstream.Consumer }
+//
+//	func (f consumerFetcher) Fetch(ctx context.Context, max int) ([]nats.Msg, error) {
+//		batch, err := f.c.Fetch(max, jetstream.FetchMaxWait(time.Second))
+//		if err != nil {
+//			return nil, err
+//		}
+//		var msgs []nats.Msg
+//		for m := range batch.Messages() {
+//			msgs = append(msgs, m)
+//		}
+//		return msgs, batch.Error()
+//	}
+//
+// and then:
+//
+//	cons, _ := js.CreateOrUpdateConsumer(ctx, "EVENTS", jetstream.ConsumerConfig{
+//		Durable:   "proofchain",
+//		AckPolicy: jetstream.AckExplicitPolicy,
+//	})
+//	bridge := nats.NewBridge(consumerFetcher{cons}, proofchain.NewIngestionClient(apiKey),
+//		nats.WithDeadLetterSubject("events.dlq", func(ctx context.Context, subject string, data []byte) error {
+//			_, err := js.Publish(ctx, subject, data)
+//			return err
+//		}))
+//	err := bridge.Run(ctx)
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain"
+)
+
+const (
+	defaultBatchSize   = 500
+	defaultConcurrency = 1
+	defaultNakDelay    = 5 * time.Second
+	// maxBatchSize is the largest batch accepted by IngestionClient.IngestBatch.
+	maxBatchSize = 1000
+)
+
+// Msg is the subset of a JetStream message used by the bridge.
+type Msg interface {
+	Subject() string
+	Data() []byte
+	// Ack acknowledges the message.
+	Ack() error
+	// Nak asks the server to redeliver the message.
+	Nak() error
+	// NakWithDelay asks the server to redeliver the message after delay.
+	NakWithDelay(delay time.Duration) error
+	// Term tells the server never to redeliver the message.
+	Term() error
+}
+
+// Fetcher pulls messages from a durable pull consumer. Fetch returns up to
+// max messages, waiting a bounded time for them; an empty result with a nil
+// error is normal when the stream is idle.
+type Fetcher interface {
+	Fetch(ctx context.Context, max int) ([]Msg, error)
+}
+
+// Mapper converts a message to an event. Returning a nil event and nil error
+// skips the message; it is still acknowledged.
+type Mapper func(msg Msg) (*proofchain.IngestEventRequest, error)
+
+// JSONMapper decodes the message data as an IngestEventRequest. It is the
+// default mapper.
+func JSONMapper(msg Msg) (*proofchain.IngestEventRequest, error) {
+	var event proofchain.IngestEventRequest
+	if err := json.Unmarshal(msg.Data(), &event); err != nil {
+		return nil, err
+	}
+	if event.UserID == "" || event.EventType == "" {
+		return nil, errors.New("user_id and event_type are required")
+	}
+	return &event, nil
+}
+
+// PublishFunc publishes data to a subject, typically via JetStream so the
+// dead letter is persisted.
+type PublishFunc func(ctx context.Context, subject string, data []byte) error
+
+// DeadLetter is the payload published to the dead-letter subject.
+type DeadLetter struct {
+	// Subject is the subject the original message was received on.
+	Subject string `json:"subject"`
+	// Data is the original message data.
+	Data []byte `json:"data"`
+	// Event is the mapped event, if mapping succeeded.
+	Event    *proofchain.IngestEventRequest `json:"event,omitempty"`
+	Error    string                         `json:"error"`
+	FailedAt time.Time                      `json:"failed_at"`
+}
+
+// Option configures a Bridge.
+type Option func(*Bridge)
+
+// WithMapper sets the function that converts messages to events.
+func WithMapper(mapper Mapper) Option {
+	return func(b *Bridge) {
+		b.mapper = mapper
+	}
+}
+
+// WithBatchSize sets the number of messages fetched and ingested per batch
+// (default 500, maximum 1000).
+func WithBatchSize(n int) Option {
+	return func(b *Bridge) {
+		if n > 0 && n <= maxBatchSize {
+			b.batchSize = n
+		}
+	}
+}
+
+// WithConcurrency sets the number of batches fetched and ingested in
+// parallel (default 1). Ordering across batches is not preserved when n > 1.
+func WithConcurrency(n int) Option {
+	return func(b *Bridge) {
+		if n > 0 {
+			b.concurrency = n
+		}
+	}
+}
+
+// WithDeadLetterSubject publishes messages that cannot be mapped or whose
+// events the API rejects to subject, as a JSON DeadLetter, before
+// acknowledging them. Without it such messages are terminated.
+func WithDeadLetterSubject(subject string, publish PublishFunc) Option {
+	return func(b *Bridge) {
+		b.dlqSubject = subject
+		b.publish = publish
+	}
+}
+
+// WithNakDelay sets how long the server waits before redelivering a batch
+// that failed in transit (default 5s), so an unreachable API is not retried
+// in a tight loop.
+func WithNakDelay(d time.Duration) Option {
+	return func(b *Bridge) {
+		if d > 0 {
+			b.nakDelay = d
+		}
+	}
+}
+
+// Stats are cumulative statistics for a Bridge.
+type Stats struct {
+	Acked        int64
+	DeadLettered int64
+	Terminated   int64
+	Redelivered  int64
+}
+
+// Bridge consumes messages from JetStream and ingests them into ProofChain.
+type Bridge struct {
+	fetcher     Fetcher
+	client      *proofchain.IngestionClient
+	mapper      Mapper
+	batchSize   int
+	concurrency int
+	nakDelay    time.Duration
+	dlqSubject  string
+	publish     PublishFunc
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewBridge creates a bridge from fetcher to client. Per-event results from
+// the REST batch endpoint decide which messages are dead-lettered.
+func NewBridge(fetcher Fetcher, client *proofchain.IngestionClient, opts ...Option) *Bridge {
+	b := &Bridge{
+		fetcher:     fetcher,
+		client:      client,
+		mapper:      JSONMapper,
+		batchSize:   defaultBatchSize,
+		concurrency: defaultConcurrency,
+		nakDelay:    defaultNakDelay,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Stats returns the bridge's cumulative statistics.
+func (b *Bridge) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// Run consumes messages until ctx is done or a fetch or acknowledgement fails.
+// Messages not acknowledged when Run returns are redelivered by the server
+// once their ack wait expires. Run returns ctx.Err() when ctx is done.
+func (b *Bridge) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var runErr error
+	for i := 0; i < b.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.worker(ctx); err != nil {
+				once.Do(func() {
+					runErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if runErr != nil && !errors.Is(runErr, context.Canceled) {
+		return runErr
+	}
+	return ctx.Err()
+}
+
+func (b *Bridge) worker(ctx context.Context) error {
+	for ctx.Err() == nil {
+		msgs, err := b.fetcher.Fetch(ctx, b.batchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("nats: fetch: %w", err)
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+		if err := b.process(ctx, msgs); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// process maps, ingests and acknowledges one fetched batch.
+func (b *Bridge) process(ctx context.Context, msgs []Msg) error {
+	var pending []Msg
+	var events []proofchain.IngestEventRequest
+	for _, msg := range msgs {
+		event, err := b.mapper(msg)
+		if err != nil {
+			if err := b.reject(ctx, msg, nil, fmt.Errorf("map message: %w", err)); err != nil {
+				return err
+			}
+			continue
+		}
+		if event == nil {
+			if err := b.ack(msg); err != nil {
+				return err
+			}
+			continue
+		}
+		pending = append(pending, msg)
+		events = append(events, *event)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	resp, err := b.client.IngestBatch(ctx, &proofchain.BatchIngestRequest{Events: events})
+	if err != nil && ctx.Err() == nil && !proofchain.IsRetryable(err) {
+		// The API rejected the batch, e.g. as invalid; redelivering it
+		// would fail the same way
+		for i, msg := range pending {
+			if err := b.reject(ctx, msg, &events[i], err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		// The batch may not have been ingested; redeliver all of it once
+		// the API has had time to recover
+		for _, msg := range pending {
+			msg.NakWithDelay(b.nakDelay)
+		}
+		b.count(func(s *Stats) { s.Redelivered += int64(len(pending)) })
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	if resp.Failed > 0 && len(resp.Results) != len(events) {
+		// The rejected events cannot be told apart; dead-letter the batch
+		// rather than acknowledge events that were not ingested
+		cause := fmt.Errorf("%d of %d events rejected by server", resp.Failed, len(events))
+		for i, msg := range pending {
+			if err := b.reject(ctx, msg, &events[i], cause); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, msg := range pending {
+		if resp.Failed > 0 && isFailedStatus(resp.Results[i].Status) {
+			err := fmt.Errorf("event rejected by server: %s", resp.Results[i].Status)
+			if err := b.reject(ctx, msg, &events[i], err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := b.ack(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Bridge) ack(msg Msg) error {
+	if err := msg.Ack(); err != nil {
+		return fmt.Errorf("nats: ack %s: %w", msg.Subject(), err)
+	}
+	b.count(func(s *Stats) { s.Acked++ })
+	return nil
+}
+
+// reject dead-letters msg if a dead-letter subject is configured, then
+// acknowledges it; otherwise it terminates msg. If publishing fails the
+// message is redelivered rather than lost.
+func (b *Bridge) reject(ctx context.Context, msg Msg, event *proofchain.IngestEventRequest, cause error) error {
+	if b.publish == nil {
+		if err := msg.Term(); err != nil {
+			return fmt.Errorf("nats: term %s: %w", msg.Subject(), err)
+		}
+		b.count(func(s *Stats) { s.Terminated++ })
+		return nil
+	}
+
+	data, err := json.Marshal(&DeadLetter{
+		Subject:  msg.Subject(),
+		Data:     msg.Data(),
+		Event:    event,
+		Error:    cause.Error(),
+		FailedAt: time.Now().UTC(),
+	})
+	if err == nil {
+		err = b.publish(ctx, b.dlqSubject, data)
+	}
+	if err != nil {
+		msg.NakWithDelay(b.nakDelay)
+		b.count(func(s *Stats) { s.Redelivered++ })
+		return nil
+	}
+	if err := msg.Ack(); err != nil {
+		return fmt.Errorf("nats: ack %s: %w", msg.Subject(), err)
+	}
+	b.count(func(s *Stats) { s.DeadLettered++ })
+	return nil
+}
+
+func (b *Bridge) count(fn func(*Stats)) {
+	b.mu.Lock()
+	fn(&b.stats)
+	b.mu.Unlock()
+}
+
+func isFailedStatus(status string) bool {
+	return status == "failed" || status == "error" || status == "rejected"
+}
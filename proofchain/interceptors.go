@@ -0,0 +1,124 @@
+package proofchain
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// EventInterceptor inspects or modifies an event before it is sent, for
+// example to add common fields such as the app version, enforce naming
+// conventions, or block disallowed event types. Returning an error stops the
+// event from being sent. Interceptors receive a copy of the caller's event
+// with its own Data map, so changes do not leak back to the caller.
+type EventInterceptor func(event *IngestEventRequest) error
+
+// WithEventInterceptor adds fn to the interceptors run on every event sent
+// by Ingest and IngestBatch, in the order they were added. An event rejected
+// by an interceptor is not sent and its error is returned; in a batch it
+// fails the whole batch.
+//
+// Example:
+//
+//	proofchain.WithEventInterceptor(func(e *proofchain.IngestEventRequest) error {
+//	    e.Data["app_version"] = version
+//	    return nil
+//	})
+func WithEventInterceptor(fn EventInterceptor) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.interceptors = append(c.interceptors, fn)
+	}
+}
+
+// WithGRPCEventInterceptor adds fn to the interceptors run on every streamed
+// event; see WithEventInterceptor. The GRPCEvent's UserID, EventType, Data
+// and Timestamp are presented as an IngestEventRequest and copied back.
+// Rejected events are dead-lettered and counted in StreamStats.TotalRejected.
+func WithGRPCEventInterceptor(fn EventInterceptor) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.interceptors = append(c.interceptors, fn)
+	}
+}
+
+// intercept runs interceptors on a copy of req, returning req itself when
+// there are none.
+func intercept(interceptors []EventInterceptor, req *IngestEventRequest) (*IngestEventRequest, error) {
+	if len(interceptors) == 0 {
+		return req, nil
+	}
+	event := *req
+	event.Data = copyMap(req.Data)
+	for _, fn := range interceptors {
+		if err := fn(&event); err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+// interceptGRPC runs interceptors on a GRPCEvent through its
+// IngestEventRequest form.
+func interceptGRPC(interceptors []EventInterceptor, e *GRPCEvent) (*GRPCEvent, error) {
+	req := &IngestEventRequest{
+		UserID:    e.UserID,
+		EventType: e.EventType,
+		Data:      e.Data,
+	}
+	if e.Timestamp != nil {
+		req.Timestamp = e.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+
+	out, err := intercept(interceptors, req)
+	if err != nil {
+		return nil, err
+	}
+
+	event := *e
+	event.UserID = out.UserID
+	event.EventType = out.EventType
+	event.Data = out.Data
+	event.Timestamp = nil
+	if out.Timestamp != "" {
+		t, err := time.Parse(time.RFC3339Nano, out.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("interceptor set invalid timestamp: %w", err)
+		}
+		event.Timestamp = &t
+	}
+	return &event, nil
+}
+
+// streamFilterCounts counts events removed before streaming.
+type streamFilterCounts struct {
+	rejected   atomic.Int64
+	duplicates atomic.Int64
+}
+
+// filterStream runs interceptors and the dedupe window over events,
+// forwarding the events that remain to the returned channel, which is closed
+// once events is closed. Counts are final once the returned channel is
+// closed.
+func (c *GRPCClient) filterStream(events <-chan *GRPCEvent) (<-chan *GRPCEvent, *streamFilterCounts) {
+	out := make(chan *GRPCEvent, cap(events))
+	counts := &streamFilterCounts{}
+	go func() {
+		defer close(out)
+		for e := range events {
+			if len(c.interceptors) > 0 {
+				intercepted, err := interceptGRPC(c.interceptors, e)
+				if err != nil {
+					counts.rejected.Add(1)
+					c.deadLetter(e, err)
+					continue
+				}
+				e = intercepted
+			}
+			if c.dedupe != nil && !c.dedupe.admit(eventKey(e.UserID, e.EventType, e.DocumentHash, e.Data)) {
+				counts.duplicates.Add(1)
+				continue
+			}
+			out <- e
+		}
+	}()
+	return out, counts
+}
@@ -0,0 +1,198 @@
+package proofchain
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// LatencySummary summarizes acknowledgment latencies. Percentiles are
+// approximate, accurate to within about 9%.
+type LatencySummary struct {
+	Count int64
+	Mean  time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// latencyBucketsPerDoubling sets the histogram resolution: bucket bounds grow
+// by a factor of 2^(1/8), about 9%.
+const latencyBucketsPerDoubling = 8
+
+// latencyBuckets covers 1µs to about 70 minutes.
+const latencyBuckets = latencyBucketsPerDoubling * 32
+
+// latencyHistogram records latencies in exponential buckets, so percentiles
+// can be reported over millions of events in constant memory.
+type latencyHistogram struct {
+	mu     sync.Mutex
+	counts [latencyBuckets]int64
+	count  int64
+	sum    time.Duration
+	max    time.Duration
+}
+
+func latencyBucket(d time.Duration) int {
+	us := float64(d) / float64(time.Microsecond)
+	if us <= 1 {
+		return 0
+	}
+	i := int(math.Ceil(latencyBucketsPerDoubling * math.Log2(us)))
+	return min(i, latencyBuckets-1)
+}
+
+// latencyBucketBound returns the upper bound of bucket i.
+func latencyBucketBound(i int) time.Duration {
+	return time.Duration(math.Exp2(float64(i)/latencyBucketsPerDoubling) * float64(time.Microsecond))
+}
+
+func (h *latencyHistogram) record(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[latencyBucket(d)]++
+	h.count++
+	h.sum += d
+	h.max = max(h.max, d)
+}
+
+// merge adds the latencies recorded by other.
+func (h *latencyHistogram) merge(other *latencyHistogram) {
+	other.mu.Lock()
+	counts, count, sum, maxLatency := other.counts, other.count, other.sum, other.max
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, n := range counts {
+		h.counts[i] += n
+	}
+	h.count += count
+	h.sum += sum
+	h.max = max(h.max, maxLatency)
+}
+
+func (h *latencyHistogram) summary() LatencySummary {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.count == 0 {
+		return LatencySummary{}
+	}
+	return LatencySummary{
+		Count: h.count,
+		Mean:  h.sum / time.Duration(h.count),
+		P50:   h.percentile(0.50),
+		P95:   h.percentile(0.95),
+		P99:   h.percentile(0.99),
+		Max:   h.max,
+	}
+}
+
+// percentile returns the upper bound of the bucket containing the p-th
+// percentile, capped at the maximum recorded latency.
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	rank := int64(math.Ceil(p * float64(h.count)))
+	var seen int64
+	for i, n := range h.counts {
+		seen += n
+		if seen >= rank {
+			return min(latencyBucketBound(i), h.max)
+		}
+	}
+	return h.max
+}
+
+// streamMeter measures one gRPC stream: the bytes sent and the latency from
+// sending each event to receiving its response. Responses are matched to
+// events in the order they were sent.
+type streamMeter struct {
+	sent, success, failed int64
+	bytes                 int64
+	latency               latencyHistogram
+
+	mu      sync.Mutex
+	pending []time.Time // Send times of events awaiting a response
+}
+
+func (m *streamMeter) recordSend(size int) {
+	m.bytes += int64(size)
+	m.mu.Lock()
+	m.pending = append(m.pending, time.Now())
+	m.mu.Unlock()
+}
+
+func (m *streamMeter) recordResponse() {
+	m.mu.Lock()
+	if len(m.pending) == 0 {
+		m.mu.Unlock()
+		return
+	}
+	sentAt := m.pending[0]
+	m.pending = m.pending[1:]
+	m.mu.Unlock()
+	m.latency.record(time.Since(sentAt))
+}
+
+// StreamBreakdown is the share of a streaming session handled by one stream.
+type StreamBreakdown struct {
+	TotalSent    int64
+	TotalSuccess int64
+	TotalFailed  int64
+	BytesSent    int64
+	Latency      LatencySummary
+}
+
+func (m *streamMeter) breakdown() StreamBreakdown {
+	return StreamBreakdown{
+		TotalSent:    m.sent,
+		TotalSuccess: m.success,
+		TotalFailed:  m.failed,
+		BytesSent:    m.bytes,
+		Latency:      m.latency.summary(),
+	}
+}
+
+// IngestStats are cumulative statistics for an IngestionClient's batches.
+type IngestStats struct {
+	Batches   int64
+	Events    int64
+	Failed    int64
+	BytesSent int64
+	// Latency is per batch, from sending the request to receiving the
+	// response; every event in a batch is acknowledged together.
+	Latency LatencySummary
+}
+
+// ingestStats accumulates IngestStats.
+type ingestStats struct {
+	mu                             sync.Mutex
+	batches, events, failed, bytes int64
+	latency                        latencyHistogram
+}
+
+func (s *ingestStats) record(events, failed, bytes int, latency time.Duration, acked bool) {
+	s.mu.Lock()
+	s.batches++
+	s.events += int64(events)
+	s.failed += int64(failed)
+	s.bytes += int64(bytes)
+	s.mu.Unlock()
+	if acked {
+		s.latency.record(latency)
+	}
+}
+
+// Stats returns cumulative statistics for the IngestBatch calls made by this
+// client, for tuning batch size and concurrency.
+func (c *IngestionClient) Stats() IngestStats {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	return IngestStats{
+		Batches:   c.stats.batches,
+		Events:    c.stats.events,
+		Failed:    c.stats.failed,
+		BytesSent: c.stats.bytes,
+		Latency:   c.stats.latency.summary(),
+	}
+}
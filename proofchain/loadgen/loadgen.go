@@ -0,0 +1,352 @@
+// Package loadgen generates synthetic ProofChain event streams and drives
+// the ingestion clients at a target rate, for capacity testing before
+// launches.
+//
+// A Generator produces events with a configurable event type mix, user
+// cardinality and skew, payload size and timestamp pattern. Run sends them
+// through a Sender at a fixed rate and reports throughput and latency
+// percentiles:
+//
+//	gen := loadgen.NewGenerator(loadgen.Config{
+//	    EventTypes: []loadgen.EventTypeWeight{{"purchase", 0.2}, {"page_view", 0.8}},
+//	    Users:      50000,
+//	    UserSkew:   1.2,
+//	})
+//	ingest := proofchain.NewIngestionClient(apiKey)
+//	report, err := loadgen.Run(ctx, gen, loadgen.IngestSender(ingest), loadgen.RunConfig{
+//	    Rate:      2000,
+//	    Duration:  5 * time.Minute,
+//	    BatchSize: 100,
+//	})
+//	fmt.Println(report)
+//
+// Latency is measured from when each batch was scheduled to be sent, so a
+// saturated client shows up as rising latency rather than a lower rate.
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain"
+)
+
+// EventTypeWeight is an event type and its relative frequency.
+type EventTypeWeight struct {
+	EventType string
+	Weight    float64
+}
+
+// Config configures a Generator. Zero values select the defaults.
+type Config struct {
+	// EventTypes is the event type mix (default a single "load_test" type).
+	EventTypes []EventTypeWeight
+	// Users is the number of distinct user IDs (default 1000).
+	Users int
+	// UserPrefix prefixes generated user IDs (default "loadgen-user-").
+	UserPrefix string
+	// UserSkew, when greater than 1, draws users from a Zipf distribution
+	// with this exponent, so a few users produce most events. Otherwise
+	// users are drawn uniformly.
+	UserSkew float64
+	// PayloadBytes is the approximate size of each event's data (default
+	// 256), varied by up to PayloadJitter (a fraction, e.g. 0.2).
+	PayloadBytes  int
+	PayloadJitter float64
+	// Backfill, when set, spreads event timestamps uniformly over the past
+	// Backfill duration instead of leaving them to the server.
+	Backfill time.Duration
+	// Seed seeds the generator, for reproducible streams (default 1).
+	Seed int64
+}
+
+// Generator produces synthetic events. It is safe for concurrent use.
+type Generator struct {
+	cfg        Config
+	cumWeights []float64
+
+	mu   sync.Mutex
+	rng  *rand.Rand
+	zipf *rand.Zipf
+	seq  int64
+}
+
+// NewGenerator creates a generator for cfg.
+func NewGenerator(cfg Config) *Generator {
+	if len(cfg.EventTypes) == 0 {
+		cfg.EventTypes = []EventTypeWeight{{EventType: "load_test", Weight: 1}}
+	}
+	if cfg.Users <= 0 {
+		cfg.Users = 1000
+	}
+	if cfg.UserPrefix == "" {
+		cfg.UserPrefix = "loadgen-user-"
+	}
+	if cfg.PayloadBytes <= 0 {
+		cfg.PayloadBytes = 256
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = 1
+	}
+
+	g := &Generator{cfg: cfg, rng: rand.New(rand.NewSource(cfg.Seed))}
+	var total float64
+	for _, w := range cfg.EventTypes {
+		total += max(w.Weight, 0)
+		g.cumWeights = append(g.cumWeights, total)
+	}
+	if cfg.UserSkew > 1 {
+		g.zipf = rand.NewZipf(g.rng, cfg.UserSkew, 1, uint64(cfg.Users-1))
+	}
+	return g
+}
+
+// Next returns the next event.
+func (g *Generator) Next() *proofchain.IngestEventRequest {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.seq++
+	event := &proofchain.IngestEventRequest{
+		UserID:      g.cfg.UserPrefix + fmt.Sprint(g.user()),
+		EventType:   g.eventType(),
+		EventSource: "loadgen",
+		Data: map[string]interface{}{
+			"seq":     g.seq,
+			"amount":  float64(g.rng.Intn(100000)) / 100,
+			"payload": g.payload(),
+		},
+	}
+	if g.cfg.Backfill > 0 {
+		ago := time.Duration(g.rng.Int63n(int64(g.cfg.Backfill)))
+		event.Timestamp = time.Now().Add(-ago).UTC().Format(time.RFC3339Nano)
+	}
+	return event
+}
+
+// Batch returns the next n events.
+func (g *Generator) Batch(n int) []*proofchain.IngestEventRequest {
+	events := make([]*proofchain.IngestEventRequest, n)
+	for i := range events {
+		events[i] = g.Next()
+	}
+	return events
+}
+
+func (g *Generator) user() int {
+	if g.zipf != nil {
+		return int(g.zipf.Uint64())
+	}
+	return g.rng.Intn(g.cfg.Users)
+}
+
+func (g *Generator) eventType() string {
+	total := g.cumWeights[len(g.cumWeights)-1]
+	if total <= 0 {
+		return g.cfg.EventTypes[0].EventType
+	}
+	r := g.rng.Float64() * total
+	i := sort.SearchFloat64s(g.cumWeights, r)
+	for i < len(g.cumWeights)-1 && g.cumWeights[i] <= r {
+		i++
+	}
+	return g.cfg.EventTypes[i].EventType
+}
+
+// payload returns filler text sized to PayloadBytes with jitter, less the
+// size of the other data fields.
+func (g *Generator) payload() string {
+	size := g.cfg.PayloadBytes
+	if g.cfg.PayloadJitter > 0 {
+		jitter := int(float64(size) * g.cfg.PayloadJitter)
+		if jitter > 0 {
+			size += g.rng.Intn(2*jitter+1) - jitter
+		}
+	}
+	size = max(size-48, 0)
+
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	var b strings.Builder
+	b.Grow(size)
+	for i := 0; i < size; i++ {
+		b.WriteByte(alphabet[g.rng.Intn(len(alphabet))])
+	}
+	return b.String()
+}
+
+// Sender sends a batch of events, returning the number of events the server
+// reported as failed. An error fails the whole batch.
+type Sender func(ctx context.Context, events []*proofchain.IngestEventRequest) (failed int, err error)
+
+// IngestSender sends events with an IngestionClient: single events with
+// Ingest and larger batches with IngestBatch.
+func IngestSender(client *proofchain.IngestionClient) Sender {
+	return func(ctx context.Context, events []*proofchain.IngestEventRequest) (int, error) {
+		if len(events) == 1 {
+			_, err := client.Ingest(ctx, events[0])
+			return 0, err
+		}
+		req := &proofchain.BatchIngestRequest{Events: make([]proofchain.IngestEventRequest, len(events))}
+		for i, e := range events {
+			req.Events[i] = *e
+		}
+		resp, err := client.IngestBatch(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		return resp.Failed, nil
+	}
+}
+
+// GRPCSender sends each batch as a stream with a connected GRPCClient.
+func GRPCSender(client *proofchain.GRPCClient) Sender {
+	return func(ctx context.Context, events []*proofchain.IngestEventRequest) (int, error) {
+		batch := make([]*proofchain.GRPCEvent, len(events))
+		for i, e := range events {
+			batch[i] = &proofchain.GRPCEvent{
+				UserID:    e.UserID,
+				EventType: e.EventType,
+				Data:      e.Data,
+			}
+			if e.Timestamp != "" {
+				if t, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+					batch[i].Timestamp = &t
+				}
+			}
+		}
+		stats, err := client.StreamEventsSlice(ctx, batch)
+		if err != nil {
+			return 0, err
+		}
+		return int(stats.TotalFailed), nil
+	}
+}
+
+// RunConfig configures a load test run.
+type RunConfig struct {
+	// Rate is the target rate in events per second.
+	Rate float64
+	// Duration is how long to generate load. The run also ends when ctx is
+	// done.
+	Duration time.Duration
+	// BatchSize is the number of events per send (default 1).
+	BatchSize int
+	// Concurrency is the maximum number of sends in flight (default 8).
+	Concurrency int
+}
+
+// Report summarizes a load test run. Latencies are per send.
+type Report struct {
+	Sent       int64
+	Failed     int64
+	Duration   time.Duration
+	Throughput float64 // Events per second
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+	// Errors counts failed sends by error message.
+	Errors map[string]int
+}
+
+// String formats the report for logs.
+func (r *Report) String() string {
+	return fmt.Sprintf("sent=%d failed=%d duration=%s throughput=%.1f/s p50=%s p90=%s p99=%s max=%s",
+		r.Sent, r.Failed, r.Duration.Round(time.Millisecond), r.Throughput,
+		r.P50, r.P90, r.P99, r.Max)
+}
+
+// Run drives send with events from gen at cfg.Rate until cfg.Duration has
+// elapsed or ctx is done, then waits for sends in flight and reports.
+func Run(ctx context.Context, gen *Generator, send Sender, cfg RunConfig) (*Report, error) {
+	if cfg.Rate <= 0 {
+		return nil, fmt.Errorf("loadgen: rate must be positive")
+	}
+	if cfg.Duration <= 0 {
+		return nil, fmt.Errorf("loadgen: duration must be positive")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 8
+	}
+
+	type job struct {
+		events    []*proofchain.IngestEventRequest
+		scheduled time.Time
+	}
+	jobs := make(chan job, cfg.Concurrency)
+
+	var mu sync.Mutex
+	report := &Report{Errors: make(map[string]int)}
+	var latencies []time.Duration
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				failed, err := send(ctx, j.events)
+				latency := time.Since(j.scheduled)
+
+				mu.Lock()
+				report.Sent += int64(len(j.events))
+				if err != nil {
+					report.Failed += int64(len(j.events))
+					report.Errors[err.Error()]++
+				} else {
+					report.Failed += int64(failed)
+				}
+				latencies = append(latencies, latency)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	interval := time.Duration(float64(time.Second) * float64(cfg.BatchSize) / cfg.Rate)
+	start := time.Now()
+	deadline := start.Add(cfg.Duration)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+schedule:
+	for next := start; next.Before(deadline); next = next.Add(interval) {
+		timer.Reset(time.Until(next))
+		select {
+		case <-ctx.Done():
+			break schedule
+		case <-timer.C:
+		}
+		select {
+		case jobs <- job{events: gen.Batch(cfg.BatchSize), scheduled: next}:
+		case <-ctx.Done():
+			break schedule
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	report.Duration = time.Since(start)
+	report.Throughput = float64(report.Sent) / report.Duration.Seconds()
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		report.P50 = percentile(latencies, 0.50)
+		report.P90 = percentile(latencies, 0.90)
+		report.P99 = percentile(latencies, 0.99)
+		report.Max = latencies[len(latencies)-1]
+	}
+	return report, nil
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(float64(len(sorted))*p+0.5) - 1
+	return sorted[min(max(i, 0), len(sorted)-1)]
+}
@@ -0,0 +1,72 @@
+package proofchain
+
+import (
+	"net/http"
+	"time"
+)
+
+// MetricsRecorder receives instrumentation from the SDK. Implementations must
+// be safe for concurrent use and should not block. The metrics subpackage
+// provides a Prometheus implementation.
+type MetricsRecorder interface {
+	// ObserveRequest records one HTTP attempt. endpoint is the route
+	// template of the request, such as "/certificates/:id", or "other" for
+	// paths the SDK does not call itself; status is 0 when no response was
+	// received.
+	ObserveRequest(method, endpoint string, status int, duration time.Duration)
+	// IncRetry records a retried attempt; reason is "network" or "rate_limit".
+	IncRetry(endpoint, reason string)
+	// IncRateLimited records a 429 response.
+	IncRateLimited(endpoint string)
+	// AddIngested records events accepted and rejected by an ingestion
+	// transport ("rest" or "grpc").
+	AddIngested(transport string, succeeded, failed int)
+	// IncStreamReconnect records a gRPC client reconnecting its streams.
+	IncStreamReconnect()
+	// AddDropped records events dropped client-side; reason is "buffer_full"
+	// or "duplicate".
+	AddDropped(reason string, n int)
+}
+
+// WithMetrics reports request counts, latencies, retries and rate-limit hits
+// to recorder.
+func WithMetrics(recorder MetricsRecorder) HTTPClientOption {
+	return func(c *HTTPClient) {
+		c.metrics = recorder
+	}
+}
+
+// WithIngestMetrics reports ingestion requests and throughput to recorder.
+func WithIngestMetrics(recorder MetricsRecorder) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.metrics = recorder
+	}
+}
+
+// WithGRPCMetrics reports streaming throughput, reconnects and dropped events
+// to recorder.
+func WithGRPCMetrics(recorder MetricsRecorder) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.metrics = recorder
+	}
+}
+
+// timedDo sends req with client, reporting the attempt to recorder if set.
+func timedDo(client *http.Client, recorder MetricsRecorder, req *http.Request) (*http.Response, error) {
+	if recorder == nil {
+		return client.Do(req)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	status := 0
+	if err == nil {
+		status = resp.StatusCode
+	}
+	endpoint := endpointLabel(req.URL.EscapedPath())
+	recorder.ObserveRequest(req.Method, endpoint, status, time.Since(start))
+	if status == http.StatusTooManyRequests {
+		recorder.IncRateLimited(endpoint)
+	}
+	return resp, err
+}
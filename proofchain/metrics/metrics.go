@@ -0,0 +1,289 @@
+// Package metrics exports ProofChain SDK instrumentation to Prometheus.
+//
+// It is a separate module, so the core SDK does not depend on the Prometheus
+// client:
+//
+//	go get github.com/ProofChainZA/proofchain-go/proofchain/metrics
+//
+// A Collector implements both proofchain.MetricsRecorder and
+// prometheus.Collector, so it is registered with the application's existing
+// registry and scraped from its existing /metrics endpoint:
+//
+//	m, err := metrics.Register(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := proofchain.NewClient(apiKey, proofchain.WithMetrics(m))
+//	ingest := proofchain.NewIngestionClient(apiKey, proofchain.WithIngestMetrics(m))
+//
+// Exported series (with the default "proofchain_sdk" namespace):
+//
+//	proofchain_sdk_requests_total{method,endpoint,status}
+//	proofchain_sdk_request_duration_seconds{method,endpoint}  (histogram)
+//	proofchain_sdk_retries_total{endpoint,reason}
+//	proofchain_sdk_rate_limited_total{endpoint}
+//	proofchain_sdk_ingested_events_total{transport,result}
+//	proofchain_sdk_grpc_stream_reconnects_total
+//	proofchain_sdk_dropped_events_total{reason}
+//	proofchain_sdk_offline_queue_depth
+//
+// endpoint is the route template of the request, such as
+// "/certificates/:id", so its cardinality is bounded by the SDK's routes.
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ProofChainZA/proofchain-go/proofchain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultBuckets are the request latency histogram buckets, in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Option configures a Collector.
+type Option func(*Collector)
+
+// WithNamespace sets the metric name prefix (default "proofchain_sdk").
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the request latency histogram buckets, in seconds.
+func WithBuckets(buckets []float64) Option {
+	return func(c *Collector) {
+		c.buckets = append([]float64(nil), buckets...)
+		sort.Float64s(c.buckets)
+	}
+}
+
+// Collector collects SDK metrics. It is safe for concurrent use and may be
+// shared by several clients.
+type Collector struct {
+	namespace string
+	buckets   []float64
+	descs     descs
+
+	mu          sync.Mutex
+	requests    *counterVec
+	latency     map[string]*histogram
+	retries     *counterVec
+	rateLimited *counterVec
+	ingested    *counterVec
+	reconnects  float64
+	dropped     *counterVec
+	queueDepth  float64
+}
+
+type descs struct {
+	requests    *prometheus.Desc
+	latency     *prometheus.Desc
+	retries     *prometheus.Desc
+	rateLimited *prometheus.Desc
+	ingested    *prometheus.Desc
+	reconnects  *prometheus.Desc
+	dropped     *prometheus.Desc
+	queueDepth  *prometheus.Desc
+}
+
+var (
+	_ proofchain.MetricsRecorder    = (*Collector)(nil)
+	_ proofchain.QueueDepthRecorder = (*Collector)(nil)
+	_ prometheus.Collector          = (*Collector)(nil)
+)
+
+// New creates a Collector.
+func New(opts ...Option) *Collector {
+	c := &Collector{
+		namespace:   "proofchain_sdk",
+		buckets:     DefaultBuckets,
+		requests:    newCounterVec("method", "endpoint", "status"),
+		latency:     make(map[string]*histogram),
+		retries:     newCounterVec("endpoint", "reason"),
+		rateLimited: newCounterVec("endpoint"),
+		ingested:    newCounterVec("transport", "result"),
+		dropped:     newCounterVec("reason"),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(c.namespace, "", name), help, labels, nil)
+	}
+	c.descs = descs{
+		requests:    desc("requests_total", "HTTP request attempts by endpoint and status (0 = no response).", c.requests.names...),
+		latency:     desc("request_duration_seconds", "HTTP request latency by endpoint.", "method", "endpoint"),
+		retries:     desc("retries_total", "Retried HTTP requests by reason.", c.retries.names...),
+		rateLimited: desc("rate_limited_total", "HTTP 429 responses by endpoint.", c.rateLimited.names...),
+		ingested:    desc("ingested_events_total", "Events sent for ingestion by transport and result.", c.ingested.names...),
+		reconnects:  desc("grpc_stream_reconnects_total", "gRPC stream reconnects."),
+		dropped:     desc("dropped_events_total", "Events dropped client-side by reason.", c.dropped.names...),
+		queueDepth:  desc("offline_queue_depth", "Events waiting in the offline queue."),
+	}
+	return c
+}
+
+// Register creates a Collector and registers it with reg.
+func Register(reg prometheus.Registerer, opts ...Option) (*Collector, error) {
+	c := New(opts...)
+	if err := reg.Register(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ObserveRequest implements proofchain.MetricsRecorder.
+func (c *Collector) ObserveRequest(method, endpoint string, status int, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests.add(1, method, endpoint, strconv.Itoa(status))
+	key := labelKey(method, endpoint)
+	h, ok := c.latency[key]
+	if !ok {
+		h = &histogram{labels: []string{method, endpoint}, counts: make([]uint64, len(c.buckets))}
+		c.latency[key] = h
+	}
+	h.observe(c.buckets, duration.Seconds())
+}
+
+// IncRetry implements proofchain.MetricsRecorder.
+func (c *Collector) IncRetry(endpoint, reason string) {
+	c.mu.Lock()
+	c.retries.add(1, endpoint, reason)
+	c.mu.Unlock()
+}
+
+// IncRateLimited implements proofchain.MetricsRecorder.
+func (c *Collector) IncRateLimited(endpoint string) {
+	c.mu.Lock()
+	c.rateLimited.add(1, endpoint)
+	c.mu.Unlock()
+}
+
+// AddIngested implements proofchain.MetricsRecorder.
+func (c *Collector) AddIngested(transport string, succeeded, failed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if succeeded > 0 {
+		c.ingested.add(float64(succeeded), transport, "success")
+	}
+	if failed > 0 {
+		c.ingested.add(float64(failed), transport, "failure")
+	}
+}
+
+// IncStreamReconnect implements proofchain.MetricsRecorder.
+func (c *Collector) IncStreamReconnect() {
+	c.mu.Lock()
+	c.reconnects++
+	c.mu.Unlock()
+}
+
+// AddDropped implements proofchain.MetricsRecorder.
+func (c *Collector) AddDropped(reason string, n int) {
+	c.mu.Lock()
+	c.dropped.add(float64(n), reason)
+	c.mu.Unlock()
+}
+
+// SetOfflineQueueDepth implements proofchain.QueueDepthRecorder.
+func (c *Collector) SetOfflineQueueDepth(depth int) {
+	c.mu.Lock()
+	c.queueDepth = float64(depth)
+	c.mu.Unlock()
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.descs.requests
+	ch <- c.descs.latency
+	ch <- c.descs.retries
+	ch <- c.descs.rateLimited
+	ch <- c.descs.ingested
+	ch <- c.descs.reconnects
+	ch <- c.descs.dropped
+	ch <- c.descs.queueDepth
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests.collect(ch, c.descs.requests)
+	for _, h := range c.latency {
+		buckets := make(map[float64]uint64, len(c.buckets))
+		var cumulative uint64
+		for i, upper := range c.buckets {
+			cumulative += h.counts[i]
+			buckets[upper] = cumulative
+		}
+		ch <- prometheus.MustNewConstHistogram(c.descs.latency, h.count, h.sum, buckets, h.labels...)
+	}
+	c.retries.collect(ch, c.descs.retries)
+	c.rateLimited.collect(ch, c.descs.rateLimited)
+	c.ingested.collect(ch, c.descs.ingested)
+	ch <- prometheus.MustNewConstMetric(c.descs.reconnects, prometheus.CounterValue, c.reconnects)
+	c.dropped.collect(ch, c.descs.dropped)
+	ch <- prometheus.MustNewConstMetric(c.descs.queueDepth, prometheus.GaugeValue, c.queueDepth)
+}
+
+type counterEntry struct {
+	labels []string
+	value  float64
+}
+
+type counterVec struct {
+	names  []string
+	values map[string]*counterEntry
+}
+
+func newCounterVec(names ...string) *counterVec {
+	return &counterVec{names: names, values: make(map[string]*counterEntry)}
+}
+
+func (v *counterVec) add(n float64, labels ...string) {
+	key := labelKey(labels...)
+	e, ok := v.values[key]
+	if !ok {
+		e = &counterEntry{labels: labels}
+		v.values[key] = e
+	}
+	e.value += n
+}
+
+func (v *counterVec) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc) {
+	for _, e := range v.values {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, e.value, e.labels...)
+	}
+}
+
+type histogram struct {
+	labels []string
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(buckets []float64, v float64) {
+	for i, upper := range buckets {
+		if v <= upper {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+func labelKey(labels ...string) string {
+	return strings.Join(labels, "\xff")
+}
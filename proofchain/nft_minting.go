@@ -0,0 +1,130 @@
+package proofchain
+
+import (
+	"context"
+)
+
+// DeployNFTCollectionRequest is the request to deploy an NFT collection
+// contract owned by the tenant
+type DeployNFTCollectionRequest struct {
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol"`
+	Network string `json:"network"`
+	// BaseURI is prepended to token IDs when minting without a MetadataURI.
+	BaseURI *string `json:"base_uri,omitempty"`
+	// Soulbound makes every token in the collection non-transferable.
+	Soulbound bool   `json:"soulbound,omitempty"`
+	MaxSupply *int64 `json:"max_supply,omitempty"`
+	// RoyaltyBps is the ERC-2981 royalty in basis points (100 = 1%).
+	RoyaltyBps       *int    `json:"royalty_bps,omitempty"`
+	RoyaltyRecipient *string `json:"royalty_recipient,omitempty"`
+}
+
+// NFTCollection is an NFT collection contract deployed through ProofChain
+type NFTCollection struct {
+	ID               string  `json:"id"`
+	ContractAddress  string  `json:"contract_address"` // Empty until deployed
+	Name             string  `json:"name"`
+	Symbol           string  `json:"symbol"`
+	Network          string  `json:"network"`
+	Soulbound        bool    `json:"soulbound"`
+	MaxSupply        *int64  `json:"max_supply,omitempty"`
+	Minted           int64   `json:"minted"`
+	Status           string  `json:"status"` // "pending", "deployed" or "failed"
+	DeploymentTxHash *string `json:"deployment_tx_hash,omitempty"`
+	CreatedAt        string  `json:"created_at"`
+}
+
+// MintNFTRequest is the request to mint an NFT to a managed wallet. Set one
+// of MetadataURI and Metadata; Metadata is pinned to IPFS and its URI used.
+type MintNFTRequest struct {
+	ContractAddress string                 `json:"contract_address"`
+	ToWalletID      string                 `json:"to_wallet_id"`
+	Network         string                 `json:"network,omitempty"`
+	MetadataURI     string                 `json:"metadata_uri,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	// Soulbound locks this token to the recipient even if the collection
+	// allows transfers. Requires a collection deployed by ProofChain.
+	Soulbound bool `json:"soulbound,omitempty"`
+}
+
+// MintNFTResult is the result of minting an NFT
+type MintNFTResult struct {
+	TxHash      string `json:"tx_hash"`
+	TokenID     string `json:"token_id"`
+	MetadataURI string `json:"metadata_uri"`
+	Status      string `json:"status"`
+	// NFT is the wallet's record of the minted token, as returned by GetNFTs.
+	NFT *NFT `json:"nft,omitempty"`
+}
+
+// DeployNFTCollection deploys a new NFT collection contract for minting
+// rewards. Deployment is asynchronous; poll GetNFTCollection until Status
+// is "deployed" before minting.
+func (w *WalletClient) DeployNFTCollection(ctx context.Context, req *DeployNFTCollectionRequest) (*NFTCollection, error) {
+	if req.Name == "" || req.Symbol == "" {
+		return nil, NewValidationError("collection name and symbol are required", []ValidationErrorDetail{
+			{Field: "name", Message: "must not be empty"},
+			{Field: "symbol", Message: "must not be empty"},
+		})
+	}
+	if req.RoyaltyBps != nil && (*req.RoyaltyBps < 0 || *req.RoyaltyBps > 10000) {
+		return nil, NewValidationError("invalid royalty", []ValidationErrorDetail{
+			{Field: "royalty_bps", Message: "must be between 0 and 10000"},
+		})
+	}
+	if req.RoyaltyRecipient != nil {
+		if err := validateAddress(req.Network, *req.RoyaltyRecipient); err != nil {
+			return nil, err
+		}
+	}
+
+	var collection NFTCollection
+	err := w.http.Post(ctx, "/wallets/nft-collections", req, &collection)
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// GetNFTCollection returns an NFT collection by ID
+func (w *WalletClient) GetNFTCollection(ctx context.Context, collectionID string) (*NFTCollection, error) {
+	var collection NFTCollection
+	err := w.http.Get(ctx, "/wallets/nft-collections/"+collectionID, nil, &collection)
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// ListNFTCollections returns the tenant's NFT collections
+func (w *WalletClient) ListNFTCollections(ctx context.Context) ([]NFTCollection, error) {
+	var collections []NFTCollection
+	err := w.http.Get(ctx, "/wallets/nft-collections", nil, &collections)
+	return collections, err
+}
+
+// MintNFT mints a token from a collection directly to a managed wallet,
+// unlike AddNFT, which only records an NFT the wallet already holds.
+func (w *WalletClient) MintNFT(ctx context.Context, req *MintNFTRequest) (*MintNFTResult, error) {
+	if err := validateAddress(req.Network, req.ContractAddress); err != nil {
+		return nil, err
+	}
+	if req.ToWalletID == "" {
+		return nil, NewValidationError("recipient wallet is required", []ValidationErrorDetail{
+			{Field: "to_wallet_id", Message: "must not be empty"},
+		})
+	}
+	if (req.MetadataURI == "") == (req.Metadata == nil) {
+		return nil, NewValidationError("exactly one of metadata URI and metadata is required", []ValidationErrorDetail{
+			{Field: "metadata_uri", Message: "set either metadata_uri or metadata"},
+		})
+	}
+
+	var result MintNFTResult
+	err := w.http.Post(ctx, "/wallets/nfts/mint", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
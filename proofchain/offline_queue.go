@@ -0,0 +1,481 @@
+package proofchain
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	offlineQueueFile       = "queue.jsonl"
+	offlineQueueOffsetFile = "queue.offset"
+
+	defaultOfflineBatchSize     = 100
+	defaultOfflineRetryInterval = 5 * time.Second
+	defaultOfflineMaxRetry      = 5 * time.Minute
+)
+
+// QueueDepthRecorder is implemented by MetricsRecorders that track the depth
+// of an OfflineQueue. It is optional; recorders without it are not told.
+type QueueDepthRecorder interface {
+	SetOfflineQueueDepth(depth int)
+}
+
+// OfflineQueueStats are statistics for an OfflineQueue.
+type OfflineQueueStats struct {
+	// Depth is the number of events waiting on disk.
+	Depth int
+	// Queued is the number of events persisted since the queue was opened.
+	Queued int64
+	// Flushed is the number of persisted events delivered since the queue
+	// was opened, including events the server rejected.
+	Flushed int64
+	// Online is false while the last attempt failed for connectivity reasons.
+	Online bool
+}
+
+// OfflineQueueOption configures an OfflineQueue.
+type OfflineQueueOption func(*OfflineQueue)
+
+// WithOfflineBatchSize sets how many persisted events are sent per request
+// when flushing (default 100, maximum 1000).
+func WithOfflineBatchSize(n int) OfflineQueueOption {
+	return func(q *OfflineQueue) {
+		if n > 0 && n <= 1000 {
+			q.batchSize = n
+		}
+	}
+}
+
+// WithOfflineRetryInterval sets the initial and maximum delay between flush
+// attempts while offline (default 5s, doubling up to 5m).
+func WithOfflineRetryInterval(initial, max time.Duration) OfflineQueueOption {
+	return func(q *OfflineQueue) {
+		if initial > 0 {
+			q.retryInterval = initial
+		}
+		if max >= q.retryInterval {
+			q.maxRetryInterval = max
+		}
+	}
+}
+
+// offlineRecord is an event as persisted by OfflineQueue. Data is stored
+// already redacted so raw values of redacted fields never reach the disk.
+type offlineRecord struct {
+	Event     IngestEventRequest `json:"event"`
+	SchemaIDs []string           `json:"schema_ids,omitempty"`
+	QueuedAt  time.Time          `json:"queued_at"`
+}
+
+// OfflineQueue sends events through an IngestionClient and persists them to
+// a local directory whenever the API is unreachable, for devices on
+// unreliable links. Persisted events are flushed in order in the background
+// once connectivity returns; while any are pending, new events are queued
+// behind them so ordering is preserved. The queue survives restarts.
+//
+// Delivery of persisted events is at-least-once: an event whose request
+// reached the server just as the connection dropped may be sent again.
+//
+// Only connectivity failures (network errors, timeouts and 502/503/504
+// responses) cause events to be queued. Other errors are returned to the
+// caller, or, for persisted events, sent to the client's DeadLetterSink.
+type OfflineQueue struct {
+	client           *IngestionClient
+	dir              string
+	batchSize        int
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+
+	mu      sync.Mutex
+	file    *os.File
+	size    int64 // bytes in the queue file
+	offset  int64 // bytes already flushed
+	depth   int
+	queued  int64
+	flushed int64
+	online  bool
+	flushMu sync.Mutex // serializes flush
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewOfflineQueue opens (or creates) a queue in dir and starts flushing any
+// events left from a previous run.
+func NewOfflineQueue(client *IngestionClient, dir string, opts ...OfflineQueueOption) (*OfflineQueue, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	q := &OfflineQueue{
+		client:           client,
+		dir:              dir,
+		batchSize:        defaultOfflineBatchSize,
+		retryInterval:    defaultOfflineRetryInterval,
+		maxRetryInterval: defaultOfflineMaxRetry,
+		online:           true,
+		wake:             make(chan struct{}, 1),
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, offlineQueueFile), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	q.file = f
+	if err := q.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	q.reportDepth()
+
+	go q.run()
+	if q.depth > 0 {
+		q.signal()
+	}
+	return q, nil
+}
+
+// Ingest sends req, or persists it if the API is unreachable or earlier
+// events are still queued. A nil response with a nil error means the event
+// was queued.
+func (q *OfflineQueue) Ingest(ctx context.Context, req *IngestEventRequest) (*IngestEventResponse, error) {
+	q.mu.Lock()
+	pending := q.depth > 0
+	q.mu.Unlock()
+
+	if !pending {
+		resp, err := q.client.Ingest(ctx, req)
+		if err == nil || !isConnectivityError(ctx, err) {
+			return resp, err
+		}
+		q.setOnline(false)
+	}
+	if err := q.enqueue(req); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// Stats returns the queue statistics.
+func (q *OfflineQueue) Stats() OfflineQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return OfflineQueueStats{Depth: q.depth, Queued: q.queued, Flushed: q.flushed, Online: q.online}
+}
+
+// Depth returns the number of events waiting on disk.
+func (q *OfflineQueue) Depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.depth
+}
+
+// Flush attempts to send all persisted events now and returns the first
+// connectivity error, if any. Events remain queued on error.
+func (q *OfflineQueue) Flush(ctx context.Context) error {
+	for {
+		more, err := q.flush(ctx)
+		if err != nil || !more {
+			return err
+		}
+	}
+}
+
+// Close stops the background flusher and closes the queue file. Persisted
+// events are kept for the next NewOfflineQueue.
+func (q *OfflineQueue) Close() error {
+	select {
+	case <-q.stop:
+	default:
+		close(q.stop)
+	}
+	<-q.done
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.file.Close()
+}
+
+func (q *OfflineQueue) enqueue(req *IngestEventRequest) error {
+	data, err := req.RedactedData()
+	if err != nil {
+		return err
+	}
+	record := offlineRecord{Event: *req, SchemaIDs: req.SchemaIDs, QueuedAt: time.Now().UTC()}
+	record.Event.Data = data
+	record.Event.Redact = nil
+	record.Event.RedactionKey = nil
+
+	line, err := json.Marshal(&record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	q.mu.Lock()
+	n, err := q.file.Write(line)
+	q.size += int64(n)
+	if err == nil {
+		err = q.file.Sync()
+	}
+	if err == nil {
+		q.depth++
+		q.queued++
+	}
+	q.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	q.reportDepth()
+	q.signal()
+	return nil
+}
+
+func (q *OfflineQueue) run() {
+	defer close(q.done)
+	delay := q.retryInterval
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-q.wake:
+		case <-timer.C:
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-q.stop:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		err := q.Flush(ctx)
+		cancel()
+
+		if err != nil {
+			delay *= 2
+			if delay > q.maxRetryInterval {
+				delay = q.maxRetryInterval
+			}
+		} else {
+			delay = q.retryInterval
+		}
+		timer.Reset(delay)
+	}
+}
+
+// flush sends one batch from the head of the queue, reporting whether more
+// events remain.
+func (q *OfflineQueue) flush(ctx context.Context) (bool, error) {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	records, next, err := q.readBatch()
+	if err != nil || len(records) == 0 {
+		return false, err
+	}
+
+	// IngestBatch sends one request per set of SchemaIDs, so events are
+	// validated against the schemas they were queued with
+	events := make([]IngestEventRequest, len(records))
+	for i, r := range records {
+		events[i] = r.Event
+		events[i].SchemaIDs = r.SchemaIDs
+	}
+	_, err = q.client.IngestBatch(ctx, &BatchIngestRequest{Events: events})
+	if err != nil && ctx.Err() != nil {
+		return false, err
+	}
+	if err != nil && isConnectivityError(ctx, err) {
+		q.setOnline(false)
+		return false, err
+	}
+	// The API answered, but a 5xx, 429 or any other error short of a
+	// validation rejection may succeed later: keep the batch and let run
+	// back off before retrying it.
+	q.setOnline(true)
+	var validationErr *ValidationError
+	if err != nil && !errors.As(err, &validationErr) {
+		return false, err
+	}
+	// Delivered, or rejected as invalid (and dead-lettered by the client):
+	// either way the events leave the queue.
+
+	if err := q.advance(next, len(records)); err != nil {
+		return false, err
+	}
+	return q.Depth() > 0, nil
+}
+
+// readBatch reads up to batchSize records after the flushed offset and
+// returns the offset following them.
+func (q *OfflineQueue) readBatch() ([]offlineRecord, int64, error) {
+	q.mu.Lock()
+	offset, size := q.offset, q.size
+	q.mu.Unlock()
+	if offset >= size {
+		return nil, offset, nil
+	}
+
+	section := io.NewSectionReader(q.file, offset, size-offset)
+	reader := bufio.NewReader(section)
+	var records []offlineRecord
+	next := offset
+	for len(records) < q.batchSize {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, offset, err
+		}
+		next += int64(len(line))
+		var r offlineRecord
+		if err := json.Unmarshal(line, &r); err != nil {
+			// Skip a record torn by a crash mid-write
+			continue
+		}
+		records = append(records, r)
+	}
+	if len(records) == 0 && next > offset {
+		// Only torn records; consume them
+		return nil, next, q.advance(next, 0)
+	}
+	return records, next, nil
+}
+
+// advance marks the queue flushed up to offset, truncating the file once it
+// is fully drained.
+func (q *OfflineQueue) advance(offset int64, count int) error {
+	q.mu.Lock()
+	q.offset = offset
+	q.depth -= count
+	if q.depth < 0 {
+		q.depth = 0
+	}
+	q.flushed += int64(count)
+	var err error
+	if q.offset >= q.size {
+		if err = q.file.Truncate(0); err == nil {
+			q.offset, q.size, q.depth = 0, 0, 0
+		}
+	}
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(q.dir, offlineQueueOffsetFile), []byte(strconv.FormatInt(q.offset, 10)))
+	}
+	q.mu.Unlock()
+
+	q.reportDepth()
+	return err
+}
+
+// load restores the flushed offset and counts pending records.
+func (q *OfflineQueue) load() error {
+	info, err := q.file.Stat()
+	if err != nil {
+		return err
+	}
+	q.size = info.Size()
+
+	data, err := os.ReadFile(filepath.Join(q.dir, offlineQueueOffsetFile))
+	switch {
+	case err == nil:
+		q.offset, _ = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+	if q.offset < 0 || q.offset > q.size {
+		q.offset = 0
+	}
+
+	// A crash mid-append can leave a partial last line. Cut it off, or the
+	// next enqueue would be appended to it and lost as one unparseable line.
+	end := q.offset
+	reader := bufio.NewReader(io.NewSectionReader(q.file, q.offset, q.size-q.offset))
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			q.depth++
+			end += int64(len(line))
+		}
+		if err != nil {
+			break
+		}
+	}
+	if end < q.size {
+		if err := q.file.Truncate(end); err != nil {
+			return err
+		}
+		q.size = end
+	}
+	return nil
+}
+
+func (q *OfflineQueue) setOnline(online bool) {
+	q.mu.Lock()
+	q.online = online
+	q.mu.Unlock()
+}
+
+func (q *OfflineQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *OfflineQueue) reportDepth() {
+	if r, ok := q.client.metrics.(QueueDepthRecorder); ok {
+		r.SetOfflineQueueDepth(q.Depth())
+	}
+}
+
+// isConnectivityError reports whether err means the API could not be
+// reached, as opposed to a rejected request or a cancelled ctx.
+func isConnectivityError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	var urlErr *url.Error
+	var networkErr *NetworkError
+	var timeoutErr *TimeoutError
+	var serverErr *ServerError
+	switch {
+	case errors.As(err, &netErr), errors.As(err, &urlErr), errors.As(err, &networkErr), errors.As(err, &timeoutErr):
+		return true
+	case errors.As(err, &serverErr):
+		return serverErr.StatusCode == 502 || serverErr.StatusCode == 503 || serverErr.StatusCode == 504
+	}
+	return false
+}
+
+// writeFileAtomic replaces path with data via a temporary file and rename.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
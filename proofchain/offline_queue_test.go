@@ -0,0 +1,52 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestOfflineQueueReplayKeepsSchemaIDs(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]int) // X-Schemas -> events
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var events []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received[r.Header.Get("X-Schemas")] += len(events)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]int{"total_events": len(events), "queued": len(events)})
+	}))
+	defer srv.Close()
+
+	q, err := NewOfflineQueue(NewIngestionClient("test-key", WithIngestURL(srv.URL)), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	for _, e := range []IngestEventRequest{
+		{UserID: "u1", EventType: "invoice", SchemaIDs: []string{"invoice", "audit"}},
+		{UserID: "u2", EventType: "page_view"},
+		{UserID: "u3", EventType: "invoice", SchemaIDs: []string{"invoice", "audit"}},
+	} {
+		if err := q.enqueue(&e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if received["invoice,audit"] != 2 || received[""] != 1 || len(received) != 2 {
+		t.Fatalf("events by X-Schemas = %v, want invoice,audit:2 and none:1", received)
+	}
+}
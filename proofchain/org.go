@@ -0,0 +1,123 @@
+package proofchain
+
+import (
+	"context"
+	"net/url"
+)
+
+// ============================================================================
+// Types
+// ============================================================================
+
+// OrgTenant is a child tenant of an organization.
+type OrgTenant struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	Status    string     `json:"status"`
+	Tier      string     `json:"tier,omitempty"`
+	CreatedAt Timestamp  `json:"created_at"`
+	LastEvent *Timestamp `json:"last_event_at,omitempty"`
+}
+
+// OrgTenantUsage is usage for a single child tenant.
+type OrgTenantUsage struct {
+	TenantID           string  `json:"tenant_id"`
+	TenantName         string  `json:"tenant_name"`
+	Events             int64   `json:"events"`
+	StorageBytes       int64   `json:"storage_bytes"`
+	Settlements        int64   `json:"settlements"`
+	PendingSettlements int64   `json:"pending_settlements"`
+	GasUsed            int64   `json:"gas_used"`
+	UsagePercentage    float64 `json:"usage_percentage,omitempty"`
+}
+
+// OrgUsageTotals aggregates usage across all child tenants.
+type OrgUsageTotals struct {
+	Tenants            int   `json:"tenants"`
+	Events             int64 `json:"events"`
+	StorageBytes       int64 `json:"storage_bytes"`
+	Settlements        int64 `json:"settlements"`
+	PendingSettlements int64 `json:"pending_settlements"`
+	GasUsed            int64 `json:"gas_used"`
+}
+
+// OrgUsageSummary is an organization-wide usage report.
+type OrgUsageSummary struct {
+	OrganizationID string           `json:"organization_id"`
+	PeriodStart    *Timestamp       `json:"period_start,omitempty"`
+	PeriodEnd      *Timestamp       `json:"period_end,omitempty"`
+	Totals         OrgUsageTotals   `json:"totals"`
+	Tenants        []OrgTenantUsage `json:"tenants"`
+}
+
+// OrgUsageOptions filters an organization usage report.
+type OrgUsageOptions struct {
+	FromDate string // YYYY-MM-DD
+	ToDate   string // YYYY-MM-DD
+}
+
+// ============================================================================
+// Client
+// ============================================================================
+
+// OrgClient handles organization-level reporting across child tenants.
+// It requires an API key with organization scope.
+type OrgClient struct {
+	http *HTTPClient
+}
+
+// NewOrgClient creates a new OrgClient.
+func NewOrgClient(http *HTTPClient) *OrgClient {
+	return &OrgClient{http: http}
+}
+
+// ListTenants lists the child tenants of the organization.
+func (c *OrgClient) ListTenants(ctx context.Context) ([]OrgTenant, error) {
+	var result struct {
+		Tenants []OrgTenant `json:"tenants"`
+	}
+	err := c.http.Get(ctx, "/org/tenants", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Tenants, nil
+}
+
+// UsageSummary returns events, storage and settlements aggregated across all
+// child tenants, with a per-tenant breakdown. Totals are computed from the
+// breakdown when the API does not return them.
+func (c *OrgClient) UsageSummary(ctx context.Context, opts *OrgUsageOptions) (*OrgUsageSummary, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.FromDate != "" {
+			params.Set("from_date", opts.FromDate)
+		}
+		if opts.ToDate != "" {
+			params.Set("to_date", opts.ToDate)
+		}
+	}
+
+	var result OrgUsageSummary
+	err := c.http.Get(ctx, "/org/usage", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Totals.Tenants == 0 && len(result.Tenants) > 0 {
+		result.Totals = sumOrgUsage(result.Tenants)
+	}
+	return &result, nil
+}
+
+func sumOrgUsage(tenants []OrgTenantUsage) OrgUsageTotals {
+	totals := OrgUsageTotals{Tenants: len(tenants)}
+	for _, t := range tenants {
+		totals.Events += t.Events
+		totals.StorageBytes += t.StorageBytes
+		totals.Settlements += t.Settlements
+		totals.PendingSettlements += t.PendingSettlements
+		totals.GasUsed += t.GasUsed
+	}
+	return totals
+}
@@ -98,15 +98,31 @@ type Badge struct {
 
 // Achievement represents an achievement that can be earned
 type Achievement struct {
-	ID            string                 `json:"id"`
-	TenantID      string                 `json:"tenant_id"`
-	AchievementID string                 `json:"achievement_id"`
-	Name          string                 `json:"name"`
-	Description   *string                `json:"description,omitempty"`
-	Category      *string                `json:"category,omitempty"`
-	PointsReward  int                    `json:"points_reward"`
-	Requirements  map[string]interface{} `json:"requirements"`
-	CreatedAt     time.Time              `json:"created_at"`
+	ID            string                   `json:"id"`
+	TenantID      string                   `json:"tenant_id"`
+	AchievementID string                   `json:"achievement_id"`
+	Name          string                   `json:"name"`
+	Description   *string                  `json:"description,omitempty"`
+	Category      *string                  `json:"category,omitempty"`
+	PointsReward  int                      `json:"points_reward"`
+	Requirements  map[string]interface{}   `json:"requirements"`
+	EventBinding  *AchievementEventBinding `json:"event_binding,omitempty"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// AchievementEventBinding makes the server track achievement progress from
+// matching events
+type AchievementEventBinding struct {
+	EventTypes  []string `json:"event_types"`
+	CountTarget int      `json:"count_target"`
+}
+
+// AchievementRecomputeResult is the result of recomputing a user's achievements
+type AchievementRecomputeResult struct {
+	UserID       string            `json:"user_id"`
+	Updated      int               `json:"updated"`
+	Completed    int               `json:"completed"`
+	Achievements []UserAchievement `json:"achievements"`
 }
 
 // UserBadge represents a badge earned by a user
@@ -141,6 +157,18 @@ type PassportHistory struct {
 	CreatedAt    time.Time   `json:"created_at"`
 }
 
+// TemplateImportResult is the result of importing a template definition
+type TemplateImportResult struct {
+	TemplateID string `json:"template_id"`
+	Slug       string `json:"slug"`
+	// Action is "created", "updated" or "unchanged"
+	Action               string   `json:"action"`
+	FieldsUpserted       int      `json:"fields_upserted"`
+	BadgesUpserted       int      `json:"badges_upserted"`
+	AchievementsUpserted int      `json:"achievements_upserted"`
+	Warnings             []string `json:"warnings,omitempty"`
+}
+
 // =============================================================================
 // Request Types
 // =============================================================================
@@ -322,6 +350,17 @@ func (p *PassportClient) AddPoints(ctx context.Context, userID string, points in
 	return &passport, nil
 }
 
+// GetPointsLedger returns the passport's points ledger: every credit and
+// debit with its reason, actor and resulting balance.
+func (p *PassportClient) GetPointsLedger(ctx context.Context, userID string, opts *PointsLedgerOptions) (*PointsLedger, error) {
+	var ledger PointsLedger
+	err := p.http.Get(ctx, "/passports/"+url.PathEscape(userID)+"/points-ledger", pointsLedgerParams(opts), &ledger)
+	if err != nil {
+		return nil, err
+	}
+	return &ledger, nil
+}
+
 // LevelUp levels up a passport
 func (p *PassportClient) LevelUp(ctx context.Context, userID string) (*Passport, error) {
 	var passport Passport
@@ -416,6 +455,25 @@ func (p *PassportClient) DeleteTemplate(ctx context.Context, templateID string)
 	return p.http.Delete(ctx, "/passports/templates/"+templateID)
 }
 
+// ExportTemplate exports a template with its fields, badges and achievements
+// as a YAML document suitable for version control. References between
+// objects use slugs rather than tenant-specific IDs.
+func (p *PassportClient) ExportTemplate(ctx context.Context, templateID string) ([]byte, error) {
+	return p.http.GetRaw(ctx, "/passports/templates/"+templateID+"/export")
+}
+
+// ImportTemplate creates or updates a template from a YAML document produced
+// by ExportTemplate. Objects are matched by slug, so importing the same
+// document again is a no-op and documents can be promoted between tenants.
+func (p *PassportClient) ImportTemplate(ctx context.Context, yaml []byte) (*TemplateImportResult, error) {
+	var result TemplateImportResult
+	err := p.http.RequestMultipart(ctx, "/passports/templates/import", nil, "file", "template.yaml", yaml, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ---------------------------------------------------------------------------
 // Badges
 // ---------------------------------------------------------------------------
@@ -496,6 +554,44 @@ func (p *PassportClient) UpdateAchievementProgress(ctx context.Context, userID s
 	return &achievement, nil
 }
 
+// BindAchievementToEvents makes the server increment achievement progress
+// whenever an event of one of eventTypes arrives for a user. The achievement
+// completes after countTarget matching events. Binding again replaces the
+// previous binding.
+func (p *PassportClient) BindAchievementToEvents(ctx context.Context, achievementID string, eventTypes []string, countTarget int) (*Achievement, error) {
+	if len(eventTypes) == 0 || countTarget <= 0 {
+		return nil, NewValidationError("invalid achievement binding", []ValidationErrorDetail{
+			{Field: "event_types", Message: "at least one event type and a positive count target are required"},
+		})
+	}
+
+	var achievement Achievement
+	err := p.http.Put(ctx, "/passports/achievements/"+achievementID+"/event-binding", &AchievementEventBinding{
+		EventTypes:  eventTypes,
+		CountTarget: countTarget,
+	}, &achievement)
+	if err != nil {
+		return nil, err
+	}
+	return &achievement, nil
+}
+
+// UnbindAchievementFromEvents removes an achievement's event binding
+func (p *PassportClient) UnbindAchievementFromEvents(ctx context.Context, achievementID string) error {
+	return p.http.Delete(ctx, "/passports/achievements/"+achievementID+"/event-binding")
+}
+
+// RecomputeAchievements recomputes a user's event-bound achievements from
+// their event history, e.g. after a binding is added or events are backfilled
+func (p *PassportClient) RecomputeAchievements(ctx context.Context, userID string) (*AchievementRecomputeResult, error) {
+	var result AchievementRecomputeResult
+	err := p.http.Post(ctx, "/passports/"+url.PathEscape(userID)+"/achievements/recompute", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ---------------------------------------------------------------------------
 // History
 // ---------------------------------------------------------------------------
@@ -0,0 +1,160 @@
+package proofchain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/url"
+)
+
+// Payout run statuses.
+const (
+	PayoutRunCompleted = "completed"
+	PayoutRunPartial   = "partial" // Some transfers failed; see Results
+	PayoutRunFailed    = "failed"
+	PayoutRunPending   = "pending"
+	PayoutRunDryRun    = "dry_run"
+)
+
+// BatchTransferOptions configures a payout run
+type BatchTransferOptions struct {
+	// RunID makes the run idempotent: resubmitting the same RunID returns the
+	// existing run instead of paying twice. When empty, BatchTransfer
+	// generates one and stores it here before sending, so a run that fails
+	// with an error can be retried safely by passing the same options again.
+	RunID string
+	// DryRun validates the run and estimates gas and token requirements
+	// without sending any transactions.
+	DryRun bool
+	// DisableMulticall sends one transaction per transfer even when the
+	// sender is a smart account that could batch them into one user
+	// operation.
+	DisableMulticall bool
+}
+
+// BatchTransferItemResult is the outcome of one transfer in a payout run
+type BatchTransferItemResult struct {
+	Index     int     `json:"index"` // Position in the submitted transfers
+	ToAddress string  `json:"to_address"`
+	Amount    string  `json:"amount"`
+	Token     string  `json:"token"`
+	TxHash    string  `json:"tx_hash,omitempty"`
+	Status    string  `json:"status"`
+	Error     *string `json:"error,omitempty"`
+}
+
+// PayoutEstimate is the cost of a payout run, computed for dry runs
+type PayoutEstimate struct {
+	TotalGas        string  `json:"total_gas"`
+	TotalGasCostUSD float64 `json:"total_gas_cost_usd"`
+	// TokenTotals is the total amount per token, in token units.
+	TokenTotals map[string]string `json:"token_totals"`
+	// Shortfalls lists tokens whose sender balance does not cover the run,
+	// keyed by token, as the missing amount in token units.
+	Shortfalls map[string]string `json:"shortfalls,omitempty"`
+	Sponsored  bool              `json:"sponsored"`
+}
+
+// BatchTransferResult is the result of a payout run
+type BatchTransferResult struct {
+	RunID     string                    `json:"run_id"`
+	Status    string                    `json:"status"`
+	Total     int                       `json:"total"`
+	Succeeded int                       `json:"succeeded"`
+	Failed    int                       `json:"failed"`
+	Multicall bool                      `json:"multicall"`
+	Results   []BatchTransferItemResult `json:"results"`
+	Estimate  *PayoutEstimate           `json:"estimate,omitempty"`
+}
+
+// FailedTransfers returns the results of transfers that did not succeed,
+// for retrying in a new run.
+func (r *BatchTransferResult) FailedTransfers() []BatchTransferItemResult {
+	var failed []BatchTransferItemResult
+	for _, item := range r.Results {
+		if isFailedStatus(item.Status) {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// BatchTransfer executes a payout run: many transfers submitted as one
+// idempotent run, batched into a multicall for smart account senders.
+// Failures of individual transfers do not fail the run; check Status and
+// FailedTransfers. Pass non-nil opts when relying on a generated RunID, so
+// the ID is available for a retry if the call returns an error.
+//
+// Example:
+//
+//	est, err := client.Wallets.BatchTransfer(ctx, payouts, &proofchain.BatchTransferOptions{DryRun: true})
+//	// check est.Estimate.Shortfalls, then
+//	run, err := client.Wallets.BatchTransfer(ctx, payouts, &proofchain.BatchTransferOptions{RunID: "payout-2026-10"})
+func (w *WalletClient) BatchTransfer(ctx context.Context, transfers []TransferRequest, opts *BatchTransferOptions) (*BatchTransferResult, error) {
+	if opts == nil {
+		opts = &BatchTransferOptions{}
+	}
+	if len(transfers) == 0 {
+		return nil, NewValidationError("at least one transfer is required", nil)
+	}
+	for i, t := range transfers {
+		if err := validateTransfer(i, &t); err != nil {
+			return nil, err
+		}
+	}
+
+	runID := opts.RunID
+	if runID == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("generate run ID: %w", err)
+		}
+		runID = "run_" + hex.EncodeToString(b)
+		opts.RunID = runID
+	}
+
+	body := map[string]interface{}{
+		"run_id":    runID,
+		"transfers": transfers,
+		"dry_run":   opts.DryRun,
+		"multicall": !opts.DisableMulticall,
+	}
+
+	var result BatchTransferResult
+	err := w.http.Post(ctx, "/wallets/transfers/batch", body, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.RunID == "" {
+		result.RunID = runID
+	}
+	return &result, nil
+}
+
+// GetBatchTransfer returns the current state of a payout run, for example
+// to resume reporting after a crash.
+func (w *WalletClient) GetBatchTransfer(ctx context.Context, runID string) (*BatchTransferResult, error) {
+	var result BatchTransferResult
+	err := w.http.Get(ctx, "/wallets/transfers/batch/"+url.PathEscape(runID), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func validateTransfer(index int, t *TransferRequest) error {
+	field := fmt.Sprintf("transfers[%d]", index)
+	if validateAddress(t.Network, t.ToAddress) != nil {
+		return NewValidationError("invalid recipient in "+field, []ValidationErrorDetail{
+			{Field: field + ".to_address", Message: "must be a valid address for the network"},
+		})
+	}
+	if amount, ok := new(big.Rat).SetString(t.Amount); !ok || amount.Sign() <= 0 {
+		return NewValidationError("invalid amount in "+field, []ValidationErrorDetail{
+			{Field: field + ".amount", Message: "must be a positive decimal amount"},
+		})
+	}
+	return nil
+}
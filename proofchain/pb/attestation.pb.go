@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.11
-// 	protoc        v4.25.1
+// 	protoc        (unknown)
 // source: attestation.proto
 
 package pb
@@ -126,6 +126,8 @@ type EventRequest struct {
 	Metadata      *Metadata              `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Timestamp     *Timestamp             `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Signature     string                 `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	Data          []byte                 `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`                            // JSON-encoded event data (preserves types and nesting)
+	ClientRef     string                 `protobuf:"bytes,9,opt,name=client_ref,json=clientRef,proto3" json:"client_ref,omitempty"` // Caller's reference, echoed in EventResponse
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,6 +211,20 @@ func (x *EventRequest) GetSignature() string {
 	return ""
 }
 
+func (x *EventRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *EventRequest) GetClientRef() string {
+	if x != nil {
+		return x.ClientRef
+	}
+	return ""
+}
+
 type EventResponse struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	EventId               string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
@@ -218,6 +234,7 @@ type EventResponse struct {
 	MerkleRoot            string                 `protobuf:"bytes,5,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
 	QueuePosition         int32                  `protobuf:"varint,6,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
 	EstimatedConfirmation string                 `protobuf:"bytes,7,opt,name=estimated_confirmation,json=estimatedConfirmation,proto3" json:"estimated_confirmation,omitempty"`
+	ClientRef             string                 `protobuf:"bytes,8,opt,name=client_ref,json=clientRef,proto3" json:"client_ref,omitempty"` // EventRequest.client_ref, if set
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -301,6 +318,13 @@ func (x *EventResponse) GetEstimatedConfirmation() string {
 	return ""
 }
 
+func (x *EventResponse) GetClientRef() string {
+	if x != nil {
+		return x.ClientRef
+	}
+	return ""
+}
+
 type BatchEventRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
@@ -1681,7 +1705,7 @@ const file_attestation_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\";\n" +
 	"\tTimestamp\x12\x18\n" +
 	"\aseconds\x18\x01 \x01(\x03R\aseconds\x12\x14\n" +
-	"\x05nanos\x18\x02 \x01(\x05R\x05nanos\"\x8f\x02\n" +
+	"\x05nanos\x18\x02 \x01(\x05R\x05nanos\"\xc2\x02\n" +
 	"\fEventRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
@@ -1690,7 +1714,10 @@ const file_attestation_proto_rawDesc = "" +
 	"\rdocument_hash\x18\x04 \x01(\tR\fdocumentHash\x121\n" +
 	"\bmetadata\x18\x05 \x01(\v2\x15.attestation.MetadataR\bmetadata\x124\n" +
 	"\ttimestamp\x18\x06 \x01(\v2\x16.attestation.TimestampR\ttimestamp\x12\x1c\n" +
-	"\tsignature\x18\a \x01(\tR\tsignature\"\x85\x02\n" +
+	"\tsignature\x18\a \x01(\tR\tsignature\x12\x12\n" +
+	"\x04data\x18\b \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"client_ref\x18\t \x01(\tR\tclientRef\"\xa4\x02\n" +
 	"\rEventResponse\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12%\n" +
 	"\x0ecertificate_id\x18\x02 \x01(\tR\rcertificateId\x12\x1b\n" +
@@ -1699,7 +1726,9 @@ const file_attestation_proto_rawDesc = "" +
 	"\vmerkle_root\x18\x05 \x01(\tR\n" +
 	"merkleRoot\x12%\n" +
 	"\x0equeue_position\x18\x06 \x01(\x05R\rqueuePosition\x125\n" +
-	"\x16estimated_confirmation\x18\a \x01(\tR\x15estimatedConfirmation\"c\n" +
+	"\x16estimated_confirmation\x18\a \x01(\tR\x15estimatedConfirmation\x12\x1d\n" +
+	"\n" +
+	"client_ref\x18\b \x01(\tR\tclientRef\"c\n" +
 	"\x11BatchEventRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x121\n" +
 	"\x06events\x18\x02 \x03(\v2\x19.attestation.EventRequestR\x06events\"\xa1\x01\n" +
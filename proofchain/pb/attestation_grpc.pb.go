@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.6.0
-// - protoc             v4.25.1
+// - protoc             (unknown)
 // source: attestation.proto
 
 package pb
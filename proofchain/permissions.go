@@ -0,0 +1,167 @@
+package proofchain
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// permissionRefresh is how long WithPermissionCheck trusts the permissions
+// it fetched before fetching them again.
+const permissionRefresh = 5 * time.Minute
+
+// APIKeyPermissions describes the API key a client authenticates with.
+type APIKeyPermissions struct {
+	KeyID     string `json:"key_id"`
+	Name      string `json:"name"`
+	KeyPrefix string `json:"key_prefix"`
+	// Permissions are scopes of the form "resource:action", such as
+	// "wallets:write". An empty list means the key is unrestricted.
+	Permissions []string   `json:"permissions"`
+	ExpiresAt   *Timestamp `json:"expires_at,omitempty"`
+}
+
+// Allows reports whether the key grants permission, directly or through a
+// "*" or "resource:*" wildcard.
+func (p *APIKeyPermissions) Allows(permission string) bool {
+	if len(p.Permissions) == 0 {
+		return true
+	}
+	resource, _, _ := strings.Cut(permission, ":")
+	for _, granted := range p.Permissions {
+		if granted == permission || granted == "*" || granted == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAPIKeyPermissions returns the scopes of the API key in use.
+func (r *TenantResource) GetAPIKeyPermissions(ctx context.Context) (*APIKeyPermissions, error) {
+	var result APIKeyPermissions
+	err := r.http.Get(ctx, "/tenant/api-keys/me", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WithPermissionCheck checks each request against the scopes of the API key
+// before sending it, so a missing scope fails with an AuthorizationError
+// naming it, such as "missing permission: wallets:write", rather than an
+// opaque 403. The scopes are fetched with Tenant.GetAPIKeyPermissions on
+// first use and refreshed every five minutes or when the key changes. If
+// they cannot be fetched, requests are sent unchecked.
+//
+// The required scope is derived from the first path segment and the method:
+// GET requests and the read-only POST endpoints in readOnlyRoutes, such as
+// searches, quotes and validations, are "read" and everything else "write".
+// Endpoints outside the scoped resources, and clients using WithUserToken,
+// are not checked.
+func WithPermissionCheck() HTTPClientOption {
+	return func(c *HTTPClient) {
+		c.permissions = &permissionCache{}
+	}
+}
+
+// permissionResources maps the first path segment of scoped endpoints to
+// the resource named in their permissions.
+var permissionResources = map[string]string{
+	"events":       "events",
+	"documents":    "documents",
+	"end-users":    "users",
+	"wallets":      "wallets",
+	"quests":       "quests",
+	"rewards":      "rewards",
+	"certificates": "certificates",
+	"channels":     "channels",
+	"passports":    "passports",
+	"passport-v2":  "passports",
+	"credentials":  "credentials",
+	"vault":        "vault",
+	"webhooks":     "webhooks",
+	"schemas":      "schemas",
+	"segments":     "segments",
+	"cohorts":      "cohorts",
+	"competitions": "competitions",
+}
+
+// readOnlyRoutes lists the route templates of scoped endpoints that take a
+// POST body but only read; add new ones here.
+var readOnlyRoutes = map[string]bool{
+	"/certificates/search":          true,
+	"/rewards/definitions/validate": true,
+	"/schemas/validate":             true,
+	"/schemas/validate/batch":       true,
+	"/tenant/vault/search":          true,
+	"/wallets/contracts/read":       true,
+	"/wallets/swaps/quote":          true,
+	"/wallets/verify-signature":     true,
+}
+
+// requiredPermission returns the scope needed to call method on path, or ""
+// if the endpoint is not scoped.
+func requiredPermission(method, path string) string {
+	resource, ok := permissionResources[cacheResource(strings.TrimPrefix(path, "/tenant"))]
+	if !ok {
+		return ""
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return resource + ":read"
+	}
+	route, _, _ := strings.Cut(path, "?")
+	if method == http.MethodPost && readOnlyRoutes[endpointLabel(route)] {
+		return resource + ":read"
+	}
+	return resource + ":write"
+}
+
+// permissionCache holds the fetched scopes of the client's API key.
+type permissionCache struct {
+	mu        sync.Mutex
+	apiKey    string
+	perms     *APIKeyPermissions // nil if the last fetch failed
+	fetchedAt time.Time
+}
+
+// checkPermission returns an AuthorizationError if WithPermissionCheck is
+// enabled and the API key lacks the scope for method on path.
+func (c *HTTPClient) checkPermission(ctx context.Context, method, path string) error {
+	if c.permissions == nil || c.userToken != "" {
+		return nil
+	}
+	required := requiredPermission(method, path)
+	if required == "" {
+		return nil
+	}
+	perms := c.permissions.get(ctx, c)
+	if perms == nil || perms.Allows(required) {
+		return nil
+	}
+	return NewMissingPermissionError(required)
+}
+
+func (p *permissionCache) get(ctx context.Context, c *HTTPClient) *APIKeyPermissions {
+	apiKey := c.APIKey()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.apiKey == apiKey && time.Since(p.fetchedAt) < permissionRefresh {
+		return p.perms
+	}
+
+	var perms APIKeyPermissions
+	if err := c.Get(ctx, "/tenant/api-keys/me", nil, &perms); err != nil {
+		if ctx.Err() != nil {
+			return nil // Not the endpoint's fault; try again next time
+		}
+		p.perms = nil
+	} else {
+		p.perms = &perms
+	}
+	p.apiKey = apiKey
+	p.fetchedAt = time.Now()
+	return p.perms
+}
@@ -0,0 +1,27 @@
+package proofchain
+
+import "testing"
+
+func TestRequiredPermission(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"GET", "/wallets/w-1/balance", "wallets:read"},
+		{"POST", "/wallets/transfer", "wallets:write"},
+		{"POST", "/wallets/contracts/read", "wallets:read"},
+		{"POST", "/wallets/swaps/quote", "wallets:read"},
+		{"POST", "/wallets/swaps/execute", "wallets:write"},
+		{"POST", "/wallets/verify-signature", "wallets:read"},
+		{"POST", "/tenant/vault/search", "vault:read"},
+		{"POST", "/schemas/validate/batch", "schemas:read"},
+		{"DELETE", "/schemas/invoice", "schemas:write"},
+		{"POST", "/search", ""},
+	}
+	for _, tt := range tests {
+		if got := requiredPermission(tt.method, tt.path); got != tt.want {
+			t.Errorf("requiredPermission(%s, %q) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package proofchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ProofBundleVersion is the format version written by ExportProofBundle.
+const ProofBundleVersion = 1
+
+// Proof bundle payload types: what document_hash is the hash of.
+const (
+	// BundlePayloadEventData is the event's data, hashed as canonical JSON:
+	// object keys sorted, no insignificant whitespace, no HTML escaping.
+	BundlePayloadEventData = "event_data"
+	// BundlePayloadDocument is an attested file, which the bundle does not
+	// include; check it with VerifyBundleDocument.
+	BundlePayloadDocument = "document"
+)
+
+const proofBundleInstructions = `To verify this bundle independently:
+1. Confirm document_hash is the SHA-256 of the attested payload. For
+   payload_type "event_data" that is event.data encoded as canonical JSON
+   (keys sorted, no whitespace); for "document" it is the attested file.
+2. Recompute the Merkle root: starting from leaf (= document_hash), for each
+   entry in merkle_proof hash the pair with SHA-256, ordering the two 32-byte
+   values so the smaller one comes first. The result must equal merkle_root.
+3. Look up blockchain_tx on the chain identified by chain_id and confirm the
+   transaction to contract_address anchors merkle_root.
+proofchain.VerifyBundle performs steps 1 (for event_data) and 2 offline;
+proofchain.VerifyBundleDocument also performs step 1 given the document.`
+
+// ProofBundle is a self-contained attestation receipt for an event, suitable
+// for handing to external auditors.
+type ProofBundle struct {
+	Version         int             `json:"version"`
+	GeneratedAt     time.Time       `json:"generated_at"`
+	EventID         string          `json:"event_id"`
+	CertificateID   string          `json:"certificate_id,omitempty"`
+	Event           json.RawMessage `json:"event"`
+	DocumentHash    string          `json:"document_hash"`
+	PayloadType     string          `json:"payload_type,omitempty"`
+	HashAlgorithm   string          `json:"hash_algorithm"`
+	Leaf            string          `json:"leaf"`
+	LeafIndex       int             `json:"leaf_index"`
+	MerkleProof     []string        `json:"merkle_proof"`
+	MerkleRoot      string          `json:"merkle_root"`
+	BatchID         string          `json:"batch_id,omitempty"`
+	BlockchainTx    *string         `json:"blockchain_tx,omitempty"`
+	BlockNumber     *int64          `json:"block_number,omitempty"`
+	ChainID         int             `json:"chain_id,omitempty"`
+	ChainName       string          `json:"chain_name,omitempty"`
+	ContractAddress *string         `json:"contract_address,omitempty"`
+	Instructions    string          `json:"verification_instructions"`
+}
+
+// BundleVerification is the result of verifying a proof bundle offline.
+type BundleVerification struct {
+	Valid        bool     `json:"valid"`
+	ComputedRoot string   `json:"computed_root"`
+	Anchored     bool     `json:"anchored"` // bundle references an on-chain transaction
+	Errors       []string `json:"errors,omitempty"`
+	// PayloadVerified reports whether the payload was hashed and matched
+	// document_hash. It is false for document bundles checked without the
+	// document, whose Valid covers only the Merkle proof.
+	PayloadVerified bool `json:"payload_verified"`
+}
+
+// ExportProofBundle writes a self-contained proof bundle for eventID to w:
+// the event JSON, document hash, Merkle proof and root, transaction hash,
+// chain details and verification instructions. Check it with VerifyBundle.
+func (r *VerifyResource) ExportProofBundle(ctx context.Context, eventID string, w io.Writer) error {
+	var event json.RawMessage
+	if err := r.http.Get(ctx, "/tenant/events/"+eventID, nil, &event); err != nil {
+		return err
+	}
+	var header Event
+	if err := json.Unmarshal(event, &header); err != nil {
+		return err
+	}
+
+	proof, err := r.EventBatchProof(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	bundle := ProofBundle{
+		Version:       ProofBundleVersion,
+		GeneratedAt:   time.Now().UTC(),
+		EventID:       eventID,
+		CertificateID: proof.CertificateID,
+		Event:         event,
+		HashAlgorithm: "sha256",
+		LeafIndex:     proof.LeafIndex,
+		MerkleProof:   proof.MerkleProof,
+		MerkleRoot:    proof.MerkleRoot,
+		BatchID:       proof.BatchID,
+		BlockchainTx:  proof.BlockchainTx,
+		Instructions:  proofBundleInstructions,
+	}
+	if header.DocumentHash != nil {
+		bundle.DocumentHash = *header.DocumentHash
+	}
+	bundle.Leaf = bundle.DocumentHash
+	bundle.PayloadType = BundlePayloadDocument
+	if sum, err := eventDataHash(event); err == nil && strings.EqualFold(sum, trimHex(bundle.DocumentHash)) {
+		bundle.PayloadType = BundlePayloadEventData
+	}
+
+	if batch, err := r.Batch(ctx, proof.BatchID); err == nil {
+		bundle.BlockNumber = batch.BlockNumber
+		if bundle.BlockchainTx == nil {
+			bundle.BlockchainTx = batch.BlockchainTx
+		}
+	}
+
+	// Chain details are best-effort: public verification keys may not be
+	// allowed to read tenant blockchain stats.
+	var stats BlockchainStats
+	if err := r.http.Get(ctx, "/tenant/blockchain/stats", nil, &stats); err == nil {
+		bundle.ChainID = stats.ChainID
+		bundle.ChainName = stats.ChainName
+		bundle.ContractAddress = stats.ContractAddress
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&bundle)
+}
+
+// VerifyBundle checks a proof bundle produced by ExportProofBundle offline: it
+// checks the bundled event against document_hash, hashing its data for
+// event_data bundles, and recomputes the Merkle root from the leaf and proof
+// and compares it to the bundle's root. It does not contact the chain;
+// Anchored only reports whether the bundle names a transaction to check.
+func VerifyBundle(bundle []byte) (*BundleVerification, error) {
+	var b ProofBundle
+	if err := json.Unmarshal(bundle, &b); err != nil {
+		return nil, fmt.Errorf("proofchain: invalid proof bundle: %w", err)
+	}
+	if b.Version > ProofBundleVersion {
+		return nil, fmt.Errorf("proofchain: unsupported proof bundle version %d", b.Version)
+	}
+	if b.HashAlgorithm != "" && b.HashAlgorithm != "sha256" {
+		return nil, fmt.Errorf("proofchain: unsupported hash algorithm %q", b.HashAlgorithm)
+	}
+
+	result := &BundleVerification{Anchored: b.BlockchainTx != nil && *b.BlockchainTx != ""}
+	fail := func(format string, args ...interface{}) {
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+	}
+
+	if b.Leaf == "" {
+		fail("bundle has no leaf")
+	} else if b.DocumentHash != "" && !strings.EqualFold(trimHex(b.Leaf), trimHex(b.DocumentHash)) {
+		fail("leaf does not match document_hash")
+	}
+	if b.MerkleRoot == "" {
+		fail("bundle has no merkle_root")
+	}
+
+	if len(b.Event) > 0 {
+		var event Event
+		if err := json.Unmarshal(b.Event, &event); err != nil {
+			fail("event is not valid JSON: %v", err)
+		} else {
+			if event.ID != b.EventID {
+				fail("event id %q does not match event_id %q", event.ID, b.EventID)
+			}
+			if event.DocumentHash != nil && !strings.EqualFold(trimHex(*event.DocumentHash), trimHex(b.DocumentHash)) {
+				fail("event document_hash does not match document_hash")
+			}
+		}
+	}
+	switch b.PayloadType {
+	case BundlePayloadEventData:
+		if len(b.Event) == 0 {
+			fail("event_data bundle has no event")
+			break
+		}
+		sum, err := eventDataHash(b.Event)
+		if err != nil {
+			fail("hash event data: %v", err)
+		} else if !strings.EqualFold(sum, trimHex(b.DocumentHash)) {
+			fail("event data hashes to %s, not document_hash %s", sum, b.DocumentHash)
+		} else {
+			result.PayloadVerified = true
+		}
+	case "", BundlePayloadDocument:
+	default:
+		fail("unknown payload_type %q", b.PayloadType)
+	}
+
+	if len(result.Errors) == 0 {
+		root, err := computeMerkleRoot(b.Leaf, b.MerkleProof)
+		if err != nil {
+			fail("%v", err)
+		} else {
+			result.ComputedRoot = root
+			want, err := hex.DecodeString(trimHex(b.MerkleRoot))
+			got, _ := hex.DecodeString(root)
+			if err != nil || !bytes.Equal(want, got) {
+				fail("computed root %s does not match merkle_root %s", root, b.MerkleRoot)
+			}
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result, nil
+}
+
+// VerifyBundleDocument is VerifyBundle for a bundle whose payload is an
+// attested file: it also checks that document hashes to document_hash.
+func VerifyBundleDocument(bundle, document []byte) (*BundleVerification, error) {
+	result, err := VerifyBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+	var b ProofBundle
+	if err := json.Unmarshal(bundle, &b); err != nil {
+		return nil, fmt.Errorf("proofchain: invalid proof bundle: %w", err)
+	}
+
+	sum := sha256.Sum256(document)
+	if hex.EncodeToString(sum[:]) == strings.ToLower(trimHex(b.DocumentHash)) {
+		result.PayloadVerified = true
+	} else {
+		result.PayloadVerified = false
+		result.Errors = append(result.Errors, "document does not hash to document_hash")
+	}
+	result.Valid = len(result.Errors) == 0
+	return result, nil
+}
+
+// eventDataHash returns the hex SHA-256 of the canonical JSON encoding of
+// the data field of event.
+func eventDataHash(event json.RawMessage) (string, error) {
+	var e struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(event, &e); err != nil {
+		return "", err
+	}
+	if len(e.Data) == 0 {
+		return "", fmt.Errorf("event has no data")
+	}
+	canonical, err := canonicalJSON(e.Data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON re-encodes raw with object keys sorted, no insignificant
+// whitespace and no HTML escaping. Numbers keep their original text.
+func canonicalJSON(raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// computeMerkleRoot folds proof into leaf using SHA-256 over sorted pairs and
+// returns the root as lowercase hex.
+func computeMerkleRoot(leaf string, proof []string) (string, error) {
+	node, err := hex.DecodeString(trimHex(leaf))
+	if err != nil {
+		return "", fmt.Errorf("leaf is not hex: %w", err)
+	}
+	for i, p := range proof {
+		sibling, err := hex.DecodeString(trimHex(p))
+		if err != nil {
+			return "", fmt.Errorf("merkle_proof[%d] is not hex: %w", i, err)
+		}
+		var sum [32]byte
+		if bytes.Compare(node, sibling) <= 0 {
+			sum = sha256.Sum256(append(append([]byte{}, node...), sibling...))
+		} else {
+			sum = sha256.Sum256(append(append([]byte{}, sibling...), node...))
+		}
+		node = sum[:]
+	}
+	return hex.EncodeToString(node), nil
+}
+
+func trimHex(s string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+}
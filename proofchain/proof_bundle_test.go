@@ -0,0 +1,111 @@
+package proofchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// testBundle builds a two-leaf event_data bundle for event data.
+func testBundle(t *testing.T, data string) ProofBundle {
+	t.Helper()
+	canonical, err := canonicalJSON([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(canonical)
+	leaf := hex.EncodeToString(sum[:])
+	sibling := strings.Repeat("ab", 32)
+	root, err := computeMerkleRoot(leaf, []string{sibling})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ProofBundle{
+		Version:       ProofBundleVersion,
+		EventID:       "evt_1",
+		Event:         json.RawMessage(`{"id":"evt_1","document_hash":"` + leaf + `","data":` + data + `}`),
+		DocumentHash:  leaf,
+		PayloadType:   BundlePayloadEventData,
+		HashAlgorithm: "sha256",
+		Leaf:          leaf,
+		MerkleProof:   []string{sibling},
+		MerkleRoot:    root,
+	}
+}
+
+func verifyTestBundle(t *testing.T, b ProofBundle) *BundleVerification {
+	t.Helper()
+	raw, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := VerifyBundle(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestVerifyBundleValid(t *testing.T) {
+	b := testBundle(t, `{"z":1,"a":{"y":"<b>","x":2.50}}`)
+	result := verifyTestBundle(t, b)
+	if !result.Valid || !result.PayloadVerified {
+		t.Fatalf("expected valid, payload verified bundle, got %+v", result)
+	}
+}
+
+func TestVerifyBundleTamperedEventData(t *testing.T) {
+	b := testBundle(t, `{"amount":100}`)
+	b.Event = json.RawMessage(`{"id":"evt_1","document_hash":"` + b.DocumentHash + `","data":{"amount":900}}`)
+	result := verifyTestBundle(t, b)
+	if result.Valid || result.PayloadVerified {
+		t.Fatalf("tampered event data verified: %+v", result)
+	}
+}
+
+func TestVerifyBundleSwappedEvent(t *testing.T) {
+	b := testBundle(t, `{"amount":100}`)
+	b.Event = json.RawMessage(`{"id":"evt_2","document_hash":"` + b.DocumentHash + `","data":{"amount":100}}`)
+	if result := verifyTestBundle(t, b); result.Valid {
+		t.Fatalf("bundle with another event verified: %+v", result)
+	}
+}
+
+func TestVerifyBundleTamperedRoot(t *testing.T) {
+	b := testBundle(t, `{"amount":100}`)
+	b.MerkleRoot = strings.Repeat("00", 32)
+	if result := verifyTestBundle(t, b); result.Valid {
+		t.Fatalf("bundle with wrong root verified: %+v", result)
+	}
+}
+
+func TestVerifyBundleDocument(t *testing.T) {
+	document := []byte("%PDF-1.7 contract")
+	sum := sha256.Sum256(document)
+	leaf := hex.EncodeToString(sum[:])
+	b := ProofBundle{
+		Version:      ProofBundleVersion,
+		EventID:      "evt_1",
+		Event:        json.RawMessage(`{"id":"evt_1","document_hash":"` + leaf + `","data":{"filename":"contract.pdf"}}`),
+		DocumentHash: leaf,
+		PayloadType:  BundlePayloadDocument,
+		Leaf:         leaf,
+		MerkleRoot:   leaf,
+	}
+	raw, _ := json.Marshal(b)
+
+	result, err := VerifyBundle(raw)
+	if err != nil || !result.Valid || result.PayloadVerified {
+		t.Fatalf("VerifyBundle: got %+v, %v; want valid, payload unverified", result, err)
+	}
+	result, err = VerifyBundleDocument(raw, document)
+	if err != nil || !result.Valid || !result.PayloadVerified {
+		t.Fatalf("VerifyBundleDocument: got %+v, %v", result, err)
+	}
+	result, err = VerifyBundleDocument(raw, []byte("%PDF-1.7 forged"))
+	if err != nil || result.Valid || result.PayloadVerified {
+		t.Fatalf("forged document verified: %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,114 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// QRPayload is the content of the QR code printed on ProofChain
+// certificates.
+type QRPayload struct {
+	CertificateID string `json:"certificate_id"`
+	VerifyURL     string `json:"verify_url"`
+	// Hash is the certificate's document hash or IPFS hash, when the code
+	// includes one.
+	Hash string `json:"hash,omitempty"`
+}
+
+// DecodeQRPayload parses the contents of a certificate QR code. Both forms
+// ProofChain prints are accepted: a verify URL such as
+// https://proofchain.co.za/verify/cert/CERT-123?h=<hash>, and a JSON object
+// with certificate_id, verify_url and hash fields.
+func DecodeQRPayload(data string) (*QRPayload, error) {
+	data = strings.TrimSpace(data)
+	if strings.HasPrefix(data, "{") {
+		var payload QRPayload
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			return nil, invalidQRPayload("not a valid JSON payload")
+		}
+		if payload.CertificateID == "" && payload.VerifyURL != "" {
+			fromURL, err := DecodeQRPayload(payload.VerifyURL)
+			if err != nil {
+				return nil, err
+			}
+			payload.CertificateID = fromURL.CertificateID
+			if payload.Hash == "" {
+				payload.Hash = fromURL.Hash
+			}
+		}
+		if payload.CertificateID == "" {
+			return nil, invalidQRPayload("missing certificate_id")
+		}
+		return &payload, nil
+	}
+
+	u, err := url.Parse(data)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, invalidQRPayload("not a ProofChain verify URL")
+	}
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-2] != "cert" && segments[len(segments)-2] != "certificate" {
+		return nil, invalidQRPayload("not a certificate verify URL")
+	}
+	certificateID, err := url.PathUnescape(segments[len(segments)-1])
+	if err != nil || certificateID == "" {
+		return nil, invalidQRPayload("missing certificate ID")
+	}
+
+	hash := u.Query().Get("h")
+	if hash == "" {
+		hash = u.Query().Get("hash")
+	}
+	return &QRPayload{
+		CertificateID: certificateID,
+		VerifyURL:     data,
+		Hash:          hash,
+	}, nil
+}
+
+func invalidQRPayload(msg string) error {
+	return NewValidationError("invalid certificate QR code: "+msg, []ValidationErrorDetail{
+		{Field: "qr", Message: msg},
+	})
+}
+
+// FromQR decodes a scanned certificate QR code and verifies the certificate.
+// If the code carries a hash, it must match the document or IPFS hash of the
+// verified certificate's event, so a code pointing at someone else's
+// certificate is rejected; the verification result is returned with the
+// error.
+//
+// Example:
+//
+//	result, err := client.VerifyResource.FromQR(ctx, scanned)
+//	if err == nil && result.IsValid() {
+//	    kiosk.ShowValid(result)
+//	}
+func (r *VerifyResource) FromQR(ctx context.Context, payload string) (*CertificateVerifyResult, error) {
+	qr, err := DecodeQRPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.Certificate(ctx, qr.CertificateID)
+	if err != nil {
+		return nil, err
+	}
+	if qr.Hash != "" && !certificateHasHash(result, qr.Hash) {
+		return result, invalidQRPayload("hash does not match certificate " + qr.CertificateID)
+	}
+	return result, nil
+}
+
+// certificateHasHash reports whether hash is one of the hashes of the
+// certificate's event.
+func certificateHasHash(result *CertificateVerifyResult, hash string) bool {
+	for _, key := range []string{"document_hash", "ipfs_hash"} {
+		if h, ok := result.Event[key].(string); ok && strings.EqualFold(strings.TrimPrefix(h, "0x"), strings.TrimPrefix(hash, "0x")) {
+			return true
+		}
+	}
+	return false
+}
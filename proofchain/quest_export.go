@@ -0,0 +1,264 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// questDefinitionVersion is the current QuestDefinition format version.
+const questDefinitionVersion = 1
+
+// QuestDefinition is a portable quest definition produced by Export. It
+// refers to reward definitions and prerequisite quests by slug rather than
+// ID so it can be imported into another tenant. Encode it with
+// encoding/json to store it in version control.
+type QuestDefinition struct {
+	Version int                `json:"version"`
+	Quest   CreateQuestRequest `json:"quest"`
+	// RewardDefinitionSlug replaces Quest.RewardDefinitionID.
+	RewardDefinitionSlug string `json:"reward_definition_slug,omitempty"`
+	// PrerequisiteQuestSlugs replaces Quest.PrerequisiteQuestIDs.
+	PrerequisiteQuestSlugs []string `json:"prerequisite_quest_slugs,omitempty"`
+}
+
+// QuestImportOptions configures Import.
+type QuestImportOptions struct {
+	// DryRun computes the changes without applying them.
+	DryRun bool
+}
+
+// QuestFieldChange is a single field difference found by Import.
+type QuestFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// QuestImportResult is the result of Import.
+type QuestImportResult struct {
+	// Action is "create", "update" or "unchanged".
+	Action  string             `json:"action"`
+	Changes []QuestFieldChange `json:"changes,omitempty"`
+	DryRun  bool               `json:"dry_run"`
+	// Quest is the created or updated quest; nil for dry runs.
+	Quest *Quest `json:"quest,omitempty"`
+}
+
+// Export returns a portable definition of a quest, including its steps.
+func (q *QuestsClient) Export(ctx context.Context, questID string) (*QuestDefinition, error) {
+	quest, err := q.Get(ctx, questID)
+	if err != nil {
+		return nil, err
+	}
+
+	def := &QuestDefinition{
+		Version: questDefinitionVersion,
+		Quest:   questToRequest(quest),
+	}
+	def.Quest.RewardDefinitionID = nil
+	def.Quest.PrerequisiteQuestIDs = nil
+
+	if quest.RewardDefinitionID != nil {
+		reward, err := NewRewardsClient(q.http).GetDefinition(ctx, *quest.RewardDefinitionID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve reward definition %s: %w", *quest.RewardDefinitionID, err)
+		}
+		def.RewardDefinitionSlug = reward.Slug
+	}
+	for _, id := range quest.PrerequisiteQuestIDs {
+		prereq, err := q.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("resolve prerequisite quest %s: %w", id, err)
+		}
+		def.PrerequisiteQuestSlugs = append(def.PrerequisiteQuestSlugs, prereq.Slug)
+	}
+	return def, nil
+}
+
+// Import creates or updates the quest described by def, matching an
+// existing quest by slug. Reward and prerequisite slugs are resolved in the
+// target tenant and must already exist. With opts.DryRun the changes are
+// reported but not applied.
+func (q *QuestsClient) Import(ctx context.Context, def *QuestDefinition, opts *QuestImportOptions) (*QuestImportResult, error) {
+	if def.Version > questDefinitionVersion {
+		return nil, NewValidationError("unsupported quest definition version", []ValidationErrorDetail{
+			{Field: "version", Message: fmt.Sprintf("version %d is newer than %d", def.Version, questDefinitionVersion)},
+		})
+	}
+	if def.Quest.Slug == "" {
+		return nil, NewValidationError("quest slug is required", []ValidationErrorDetail{
+			{Field: "quest.slug", Message: "is required to match quests across tenants"},
+		})
+	}
+	dryRun := opts != nil && opts.DryRun
+
+	req := def.Quest
+	req.RewardDefinitionID = nil
+	req.PrerequisiteQuestIDs = nil
+	if def.RewardDefinitionSlug != "" {
+		id, err := q.rewardDefinitionIDBySlug(ctx, def.RewardDefinitionSlug)
+		if err != nil {
+			return nil, err
+		}
+		req.RewardDefinitionID = &id
+	}
+	for _, slug := range def.PrerequisiteQuestSlugs {
+		prereq, err := q.GetBySlug(ctx, slug)
+		if err != nil {
+			return nil, fmt.Errorf("resolve prerequisite quest %s: %w", slug, err)
+		}
+		req.PrerequisiteQuestIDs = append(req.PrerequisiteQuestIDs, prereq.ID)
+	}
+
+	existing, err := q.GetBySlug(ctx, req.Slug)
+	var notFound *NotFoundError
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	result := &QuestImportResult{DryRun: dryRun}
+	if existing == nil {
+		result.Action = "create"
+		result.Changes = diffQuestRequests(nil, &req)
+		if !dryRun {
+			result.Quest, err = q.Create(ctx, &req)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	current := questToRequest(existing)
+	result.Changes = diffQuestRequests(&current, &req)
+	if len(result.Changes) == 0 {
+		result.Action = "unchanged"
+		result.Quest = existing
+		return result, nil
+	}
+	result.Action = "update"
+	if !dryRun {
+		result.Quest, err = q.Update(ctx, existing.ID, &req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (q *QuestsClient) rewardDefinitionIDBySlug(ctx context.Context, slug string) (string, error) {
+	rewards := NewRewardsClient(q.http)
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		defs, err := rewards.ListDefinitions(ctx, &ListRewardsOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return "", err
+		}
+		for _, d := range defs {
+			if d.Slug == slug {
+				return d.ID, nil
+			}
+		}
+		if len(defs) < pageSize {
+			return "", NewNotFoundError("reward definition not found: " + slug)
+		}
+	}
+}
+
+// questToRequest converts a quest to the request that would recreate it.
+func questToRequest(quest *Quest) CreateQuestRequest {
+	req := CreateQuestRequest{
+		Name:                  quest.Name,
+		Slug:                  quest.Slug,
+		Description:           quest.Description,
+		ShortDescription:      quest.ShortDescription,
+		IconURL:               quest.IconURL,
+		BannerURL:             quest.BannerURL,
+		Category:              quest.Category,
+		Difficulty:            quest.Difficulty,
+		EstimatedTime:         quest.EstimatedTime,
+		IsOrdered:             quest.IsOrdered,
+		IsRepeatable:          quest.IsRepeatable,
+		RepeatCooldownHours:   quest.RepeatCooldownHours,
+		MaxCompletionsPerUser: quest.MaxCompletionsPerUser,
+		StartsAt:              quest.StartsAt,
+		EndsAt:                quest.EndsAt,
+		TimeLimitHours:        quest.TimeLimitHours,
+		PrerequisiteQuestIDs:  quest.PrerequisiteQuestIDs,
+		MaxParticipants:       quest.MaxParticipants,
+		MaxCompletions:        quest.MaxCompletions,
+		RewardDefinitionID:    quest.RewardDefinitionID,
+		RewardPoints:          quest.RewardPoints,
+		IsPublic:              quest.IsPublic,
+		IsFeatured:            quest.IsFeatured,
+		Tags:                  quest.Tags,
+		Steps:                 make([]CreateQuestStepRequest, 0, len(quest.Steps)),
+	}
+
+	steps := append([]QuestStep(nil), quest.Steps...)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Order < steps[j].Order })
+	for _, s := range steps {
+		order := s.Order
+		req.Steps = append(req.Steps, CreateQuestStepRequest{
+			Name:               s.Name,
+			Description:        s.Description,
+			Order:              &order,
+			StepType:           s.StepType,
+			EventType:          s.EventType,
+			EventTypes:         s.EventTypes,
+			Criteria:           s.Criteria,
+			RequiredDataFields: s.RequiredDataFields,
+			StepPoints:         s.StepPoints,
+			CTAText:            s.CTAText,
+			CTAURL:             s.CTAURL,
+			IconURL:            s.IconURL,
+			IsOptional:         s.IsOptional,
+		})
+	}
+	return req
+}
+
+// diffQuestRequests compares two requests field by field using their JSON
+// form. A nil from reports every set field of to as a change.
+func diffQuestRequests(from, to *CreateQuestRequest) []QuestFieldChange {
+	fromFields := jsonFields(from)
+	toFields := jsonFields(to)
+
+	keys := make(map[string]struct{})
+	for k := range fromFields {
+		keys[k] = struct{}{}
+	}
+	for k := range toFields {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []QuestFieldChange
+	for _, k := range sorted {
+		if !reflect.DeepEqual(fromFields[k], toFields[k]) {
+			changes = append(changes, QuestFieldChange{Field: k, From: fromFields[k], To: toFields[k]})
+		}
+	}
+	return changes
+}
+
+func jsonFields(v interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if reflect.ValueOf(v).IsNil() {
+		return fields
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(b, &fields)
+	return fields
+}
@@ -0,0 +1,145 @@
+package proofchain
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Quest progress webhook event types.
+const (
+	WebhookEventQuestStepCompleted = "quest.step_completed"
+	WebhookEventQuestCompleted     = "quest.completed"
+)
+
+// QuestStepCompleted is the payload of a quest.step_completed webhook
+type QuestStepCompleted struct {
+	QuestID  string            `json:"quest_id"`
+	UserID   string            `json:"user_id"`
+	Step     StepProgress      `json:"step"`
+	Progress UserQuestProgress `json:"progress"`
+}
+
+// QuestCompleted is the payload of a quest.completed webhook
+type QuestCompleted struct {
+	QuestID      string            `json:"quest_id"`
+	UserID       string            `json:"user_id"`
+	PointsEarned int               `json:"points_earned"`
+	RewardEarned bool              `json:"reward_earned"`
+	Progress     UserQuestProgress `json:"progress"`
+}
+
+const (
+	questStreamMinBackoff = time.Second
+	questStreamMaxBackoff = 30 * time.Second
+)
+
+// SubscribeProgress streams progress updates for all users of a quest as
+// server-sent events, so UIs can update as soon as a step completes instead
+// of polling GetUserProgress. Dropped connections are re-established with
+// backoff, resuming from the last event received. The channel is closed when
+// ctx is done. An error is returned only if the first connection fails.
+//
+// Example:
+//
+//	updates, err := client.Quests.SubscribeProgress(ctx, questID)
+//	for p := range updates {
+//	    ui.SetProgress(p.UserID, p.CurrentStepOrder, p.Status)
+//	}
+func (q *QuestsClient) SubscribeProgress(ctx context.Context, questID string) (<-chan UserQuestProgress, error) {
+	path := "/quests/" + questID + "/progress/stream"
+	header := http.Header{}
+	header.Set("Accept", "text/event-stream")
+
+	resp, err := q.http.GetStream(ctx, path, header)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan UserQuestProgress, 64)
+	go func() {
+		defer close(updates)
+
+		var lastEventID string
+		backoff := questStreamMinBackoff
+		for {
+			received := readServerSentEvents(resp.Body, func(id string, data []byte) bool {
+				if id != "" {
+					lastEventID = id
+				}
+				var progress UserQuestProgress
+				if err := json.Unmarshal(data, &progress); err != nil {
+					return true // Skip malformed or keep-alive payloads
+				}
+				select {
+				case updates <- progress:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			})
+			resp.Body.Close()
+			if received {
+				backoff = questStreamMinBackoff
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				backoff = min(backoff*2, questStreamMaxBackoff)
+
+				if lastEventID != "" {
+					header.Set("Last-Event-ID", lastEventID)
+				}
+				resp, err = q.http.GetStream(ctx, path, header)
+				if err == nil {
+					break
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// readServerSentEvents reads a text/event-stream from r, calling fn with the
+// ID and data of each event until fn returns false or the stream ends. It
+// reports whether any event was read.
+func readServerSentEvents(r io.Reader, fn func(id string, data []byte) bool) bool {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	var id string
+	var data bytes.Buffer
+	received := false
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			if data.Len() > 0 {
+				received = true
+				if !fn(id, bytes.TrimSuffix(data.Bytes(), []byte("\n"))) {
+					return received
+				}
+			}
+			data.Reset()
+			continue
+		}
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "data":
+			data.Write(value)
+			data.WriteByte('\n')
+		case "id":
+			id = string(value)
+		}
+	}
+	return received
+}
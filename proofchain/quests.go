@@ -420,3 +420,75 @@ func (q *QuestsClient) ReorderSteps(ctx context.Context, questID string, stepIDs
 	}
 	return &quest, nil
 }
+
+// QuestStepAnalytics is funnel data for a single quest step.
+type QuestStepAnalytics struct {
+	StepIndex   int     `json:"step_index"`
+	StepID      string  `json:"step_id"`
+	StepName    string  `json:"step_name"`
+	Starts      int     `json:"starts"`
+	Completions int     `json:"completions"`
+	DropOff     int     `json:"drop_off"`      // Users who started but did not complete the step
+	DropOffRate float64 `json:"drop_off_rate"` // DropOff / Starts, 0-1
+	// MedianSecondsToComplete is the median time from step start to completion.
+	MedianSecondsToComplete *float64 `json:"median_seconds_to_complete,omitempty"`
+}
+
+// QuestAnalytics is the completion funnel for a quest.
+type QuestAnalytics struct {
+	QuestID        string  `json:"quest_id"`
+	QuestName      string  `json:"quest_name"`
+	Starts         int     `json:"starts"`
+	Completions    int     `json:"completions"`
+	Claims         int     `json:"claims"`
+	CompletionRate float64 `json:"completion_rate"`
+	// MedianSecondsToComplete is the median time from quest start to completion.
+	MedianSecondsToComplete *float64             `json:"median_seconds_to_complete,omitempty"`
+	Steps                   []QuestStepAnalytics `json:"steps"`
+	PeriodStart             *string              `json:"period_start,omitempty"`
+	PeriodEnd               *string              `json:"period_end,omitempty"`
+}
+
+// QuestAnalyticsOptions filters quest analytics to a period.
+type QuestAnalyticsOptions struct {
+	FromDate *time.Time
+	ToDate   *time.Time
+}
+
+// QuestLeaderboardEntry is a user on a quest's fastest-completers board.
+type QuestLeaderboardEntry struct {
+	Rank              int     `json:"rank"`
+	UserID            string  `json:"user_id"`
+	DisplayName       *string `json:"display_name,omitempty"`
+	StartedAt         string  `json:"started_at"`
+	CompletedAt       string  `json:"completed_at"`
+	SecondsToComplete float64 `json:"seconds_to_complete"`
+}
+
+// GetAnalytics returns the completion funnel for a quest: starts,
+// completions, drop-off and median time-to-complete per step.
+func (q *QuestsClient) GetAnalytics(ctx context.Context, questID string, opts *QuestAnalyticsOptions) (*QuestAnalytics, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.FromDate != nil {
+			params.Set("from_date", opts.FromDate.Format(time.RFC3339))
+		}
+		if opts.ToDate != nil {
+			params.Set("to_date", opts.ToDate.Format(time.RFC3339))
+		}
+	}
+
+	var analytics QuestAnalytics
+	err := q.http.Get(ctx, "/quests/"+questID+"/analytics", params, &analytics)
+	if err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
+
+// GetLeaderboard returns the fastest completers of a quest.
+func (q *QuestsClient) GetLeaderboard(ctx context.Context, questID string) ([]QuestLeaderboardEntry, error) {
+	var entries []QuestLeaderboardEntry
+	err := q.http.Get(ctx, "/quests/"+questID+"/leaderboard", nil, &entries)
+	return entries, err
+}
@@ -0,0 +1,138 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// UsageAlert notifies a webhook when monthly event usage crosses a
+// threshold.
+type UsageAlert struct {
+	ID         string    `json:"id"`
+	Threshold  float64   `json:"threshold"` // Percentage of MaxEventsPerMonth
+	WebhookURL string    `json:"webhook_url"`
+	CreatedAt  Timestamp `json:"created_at"`
+}
+
+// SetUsageAlert posts to webhookURL when the tenant's event usage for the
+// month reaches threshold percent of MaxEventsPerMonth, e.g. 80.
+func (r *TenantResource) SetUsageAlert(ctx context.Context, threshold float64, webhookURL string) (*UsageAlert, error) {
+	if threshold <= 0 || threshold > 100 {
+		return nil, NewValidationError("invalid usage alert threshold", []ValidationErrorDetail{
+			{Field: "threshold", Message: "must be a percentage greater than 0 and at most 100"},
+		})
+	}
+	if u, err := url.Parse(webhookURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, NewValidationError("invalid webhook URL", []ValidationErrorDetail{
+			{Field: "webhook_url", Message: "must be an absolute http or https URL"},
+		})
+	}
+
+	payload := map[string]interface{}{
+		"threshold":   threshold,
+		"webhook_url": webhookURL,
+	}
+
+	var result UsageAlert
+	err := r.http.Post(ctx, "/tenant/usage/alerts", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListUsageAlerts lists the tenant's usage alerts.
+func (r *TenantResource) ListUsageAlerts(ctx context.Context) ([]UsageAlert, error) {
+	var result []UsageAlert
+	err := r.http.Get(ctx, "/tenant/usage/alerts", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteUsageAlert deletes a usage alert.
+func (r *TenantResource) DeleteUsageAlert(ctx context.Context, alertID string) error {
+	return r.http.Delete(ctx, "/tenant/usage/alerts/"+alertID)
+}
+
+// QuotaGuard checks bulk operations against the tenant's monthly event
+// quota before they are sent. Usage is fetched with Client.Usage and
+// refreshed periodically; events admitted since the last refresh are counted
+// locally so concurrent batches cannot overshoot together.
+type QuotaGuard struct {
+	client   *Client
+	refresh  time.Duration
+	onExceed func(err *QuotaExceededError)
+
+	mu       sync.Mutex
+	usage    *UsageStats
+	fetched  time.Time
+	admitted int
+}
+
+// NewQuotaGuard creates a guard that refreshes usage every refresh interval
+// (default 1m). If onExceed is nil, operations that would exceed the quota
+// fail with a *QuotaExceededError; otherwise onExceed is called to warn and
+// the operation proceeds.
+//
+// Example:
+//
+//	guard := proofchain.NewQuotaGuard(client, 0, nil)
+//	ingest := proofchain.NewIngestionClient(apiKey, proofchain.WithQuotaGuard(guard))
+func NewQuotaGuard(client *Client, refresh time.Duration, onExceed func(err *QuotaExceededError)) *QuotaGuard {
+	if refresh <= 0 {
+		refresh = time.Minute
+	}
+	return &QuotaGuard{client: client, refresh: refresh, onExceed: onExceed}
+}
+
+// Check admits n more events against the quota. It returns an error if the
+// events would exceed the quota and no onExceed callback is set, or if usage
+// cannot be fetched for the first time. Tenants without a monthly limit are
+// not checked.
+func (g *QuotaGuard) Check(ctx context.Context, n int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.usage == nil || time.Since(g.fetched) >= g.refresh {
+		usage, err := g.client.Usage(ctx, "month")
+		if err != nil {
+			if g.usage == nil {
+				return fmt.Errorf("quota guard: %w", err)
+			}
+			// Keep checking against the last known usage
+		} else {
+			g.usage = usage
+			g.fetched = time.Now()
+			g.admitted = 0
+		}
+	}
+
+	limit := g.usage.MaxEventsPerMonth
+	if limit <= 0 {
+		return nil
+	}
+	remaining := max(limit-g.usage.EventsThisMonth-g.admitted, 0)
+	if n > remaining {
+		err := NewQuotaExceededError(n, remaining, limit)
+		if g.onExceed == nil {
+			return err
+		}
+		g.onExceed(err)
+	}
+	g.admitted += n
+	return nil
+}
+
+// WithQuotaGuard checks Ingest and IngestBatch against guard before sending.
+// For gRPC streams, call guard.Check with the number of events before
+// starting a backfill.
+func WithQuotaGuard(guard *QuotaGuard) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.quota = guard
+	}
+}
@@ -0,0 +1,57 @@
+package proofchain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket limits throughput to rate events per second with a burst of
+// one second's worth of events. Requests larger than the burst are allowed
+// but delay subsequent callers until the bucket has refilled. It is safe for
+// concurrent use.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(eventsPerSecond int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(eventsPerSecond),
+		tokens: float64(eventsPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until n events may be sent or ctx is done.
+func (b *tokenBucket) Wait(ctx context.Context, n int) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+	b.last = now
+	b.tokens -= float64(n)
+	deficit := -b.tokens
+	b.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(deficit / b.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give back the reservation so other callers are not delayed
+		b.mu.Lock()
+		b.tokens += float64(n)
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,108 @@
+package proofchain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RedactedPrefix marks a data value replaced by RedactValue.
+const RedactedPrefix = "redacted:hmac-sha256:"
+
+// RedactValue returns the redacted form of value for the data field at path
+// (dot-separated for nested fields), keyed by key. The same key, path and
+// value always produce the same result, so a value can later be proven equal
+// to the attested one by recomputing it; see VerifyRedactedValue. Values are
+// encoded as JSON before hashing, so 1 and "1" redact differently.
+func RedactValue(key []byte, path string, value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(path))
+	mac.Write([]byte{0})
+	mac.Write(encoded)
+	return RedactedPrefix + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifyRedactedValue reports whether value is the original of a redacted
+// field produced with key.
+func VerifyRedactedValue(key []byte, path string, value interface{}, redacted string) bool {
+	expected, err := RedactValue(key, path, value)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(expected), []byte(redacted))
+}
+
+// RedactedData returns the event data as it will be sent, with the fields in
+// Redact replaced. Use it when forwarding the event through another transport.
+func (r *IngestEventRequest) RedactedData() (map[string]interface{}, error) {
+	return redactData(r.Data, r.Redact, r.RedactionKey, r.RedactOptional)
+}
+
+// redactData returns a copy of data with the fields at paths replaced by
+// their redacted form. data itself is not modified. A path that is absent,
+// or null part way down, is an error unless optional is set. A path through
+// a value that is not a map[string]interface{}, such as a map[string]string
+// or a struct, is always an error, since the field could not be redacted.
+func redactData(data map[string]interface{}, paths []string, key []byte, optional bool) (map[string]interface{}, error) {
+	if len(paths) == 0 || (data == nil && optional) {
+		return data, nil
+	}
+	if len(key) == 0 {
+		return nil, NewValidationError("redaction key is required", []ValidationErrorDetail{
+			{Field: "redaction_key", Message: "is required when redact is set"},
+		})
+	}
+
+	out := copyMap(data)
+	for _, path := range paths {
+		parts := strings.Split(path, ".")
+		m := out
+		for i, part := range parts {
+			v, ok := m[part]
+			if !ok || (v == nil && i < len(parts)-1) {
+				if optional {
+					break
+				}
+				return nil, NewValidationError("cannot redact field", []ValidationErrorDetail{
+					{Field: "data." + path, Message: "is not present in the event data"},
+				})
+			}
+			if i == len(parts)-1 {
+				redacted, err := RedactValue(key, path, v)
+				if err != nil {
+					return nil, NewValidationError("cannot redact field", []ValidationErrorDetail{
+						{Field: "data." + path, Message: err.Error()},
+					})
+				}
+				m[part] = redacted
+				break
+			}
+			nested, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, NewValidationError("cannot redact field", []ValidationErrorDetail{
+					{Field: "data." + strings.Join(parts[:i+1], "."), Message: fmt.Sprintf("is a %T, not a map[string]interface{}", v)},
+				})
+			}
+			// Copy on the way down so the caller's nested maps are untouched
+			nested = copyMap(nested)
+			m[part] = nested
+			m = nested
+		}
+	}
+	return out, nil
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
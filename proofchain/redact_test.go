@@ -0,0 +1,113 @@
+package proofchain
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testRedactionKey = []byte("test-redaction-key")
+
+func TestRedactDataNested(t *testing.T) {
+	payer := map[string]interface{}{"id_number": "8001015009087", "name": "Thandi"}
+	data := map[string]interface{}{"payer": payer, "amount": 100}
+
+	out, err := redactData(data, []string{"payer.id_number"}, testRedactionKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := out["payer"].(map[string]interface{})["id_number"].(string)
+	if !VerifyRedactedValue(testRedactionKey, "payer.id_number", "8001015009087", got) {
+		t.Fatalf("redacted value %q does not verify", got)
+	}
+	if payer["id_number"] != "8001015009087" {
+		t.Fatal("caller's nested map was modified")
+	}
+	if out["payer"].(map[string]interface{})["name"] != "Thandi" {
+		t.Fatal("unredacted sibling field changed")
+	}
+}
+
+func TestRedactDataTypedMap(t *testing.T) {
+	data := map[string]interface{}{
+		"payer": map[string]string{"id_number": "8001015009087"},
+	}
+	_, err := redactData(data, []string{"payer.id_number"}, testRedactionKey, false)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError for typed map, got %v", err)
+	}
+	if verr.Errors[0].Field != "data.payer" {
+		t.Fatalf("error names field %q, want data.payer", verr.Errors[0].Field)
+	}
+
+	// Optional does not excuse a value that is present but unreachable
+	if _, err := redactData(data, []string{"payer.id_number"}, testRedactionKey, true); err == nil {
+		t.Fatal("expected error for typed map with optional set")
+	}
+}
+
+func TestRedactDataMissingPath(t *testing.T) {
+	data := map[string]interface{}{"payer": map[string]interface{}{"id_number": "8001015009087"}}
+
+	for _, path := range []string{"payer.idnumber", "payee.id_number", "email"} {
+		_, err := redactData(data, []string{path}, testRedactionKey, false)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || !strings.Contains(verr.Errors[0].Message, "not present") {
+			t.Errorf("%s: expected not-present ValidationError, got %v", path, err)
+		}
+	}
+
+	out, err := redactData(data, []string{"email"}, testRedactionKey, true)
+	if err != nil {
+		t.Fatalf("optional missing path: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("unexpected data %v", out)
+	}
+}
+
+func TestRedactedDataRequiresKey(t *testing.T) {
+	req := &IngestEventRequest{Data: map[string]interface{}{"email": "a@b.co"}, Redact: []string{"email"}}
+	if _, err := req.RedactedData(); err == nil {
+		t.Fatal("expected error without redaction key")
+	}
+}
+
+func TestDeadLetterRedactsData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	sink, err := NewFileDeadLetterSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &IngestionClient{deadLetters: sink}
+	c.deadLetter(&IngestEventRequest{
+		UserID:       "user-1",
+		EventType:    "payment",
+		Data:         map[string]interface{}{"id_number": "8001015009087", "amount": 100},
+		Redact:       []string{"id_number"},
+		RedactionKey: testRedactionKey,
+	}, errors.New("event rejected by server: failed"))
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "8001015009087") {
+		t.Fatalf("dead letter contains the redacted value in clear text: %s", raw)
+	}
+	var letter DeadLetter
+	if err := json.Unmarshal(raw, &letter); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := letter.Event.Data["id_number"].(string)
+	if !VerifyRedactedValue(testRedactionKey, "id_number", "8001015009087", got) {
+		t.Fatalf("dead letter id_number %q is not the redacted value", got)
+	}
+}
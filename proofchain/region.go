@@ -0,0 +1,143 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Region identifies a ProofChain deployment region.
+type Region string
+
+const (
+	RegionZA Region = "za" // South Africa (default)
+	RegionEU Region = "eu"
+	RegionUS Region = "us"
+)
+
+// RegionEndpoints are the hostnames serving a region.
+type RegionEndpoints struct {
+	APIURL       string
+	IngestURL    string
+	GRPCEndpoint string
+}
+
+var regionEndpoints = map[Region]RegionEndpoints{
+	RegionZA: {
+		APIURL:       defaultBaseURL,
+		IngestURL:    defaultIngestURL,
+		GRPCEndpoint: defaultGRPCEndpoint,
+	},
+	RegionEU: {
+		APIURL:       "https://api.eu.proofchain.co.za",
+		IngestURL:    "https://ingest.eu.proofchain.co.za",
+		GRPCEndpoint: "grpc.eu.proofchain.co.za:443",
+	},
+	RegionUS: {
+		APIURL:       "https://api.us.proofchain.co.za",
+		IngestURL:    "https://ingest.us.proofchain.co.za",
+		GRPCEndpoint: "grpc.us.proofchain.co.za:443",
+	},
+}
+
+// Endpoints returns the hostnames serving the region.
+func (r Region) Endpoints() (RegionEndpoints, error) {
+	ep, ok := regionEndpoints[Region(strings.ToLower(string(r)))]
+	if !ok {
+		return RegionEndpoints{}, fmt.Errorf("unknown region %q", r)
+	}
+	return ep, nil
+}
+
+const (
+	// failoverThreshold is the number of consecutive 5xx responses or
+	// connection failures from the primary region that trigger failover.
+	failoverThreshold = 5
+	// failoverProbeInterval is how often the primary region's health
+	// endpoint is probed while traffic is on the secondary.
+	failoverProbeInterval = 30 * time.Second
+	failoverProbeTimeout  = 5 * time.Second
+	healthPath            = "/health"
+)
+
+// regionFailover switches requests from a primary to a secondary base URL
+// after sustained failures, and back once the primary's health endpoint
+// answers again. It is shared by tenant-scoped copies of a client.
+type regionFailover struct {
+	urls   [2]string // primary, secondary
+	client *http.Client
+
+	mu        sync.Mutex
+	active    int
+	failures  int
+	lastProbe time.Time
+	probing   bool
+}
+
+func newRegionFailover(primary, secondary string, client *http.Client) *regionFailover {
+	return &regionFailover{urls: [2]string{primary, secondary}, client: client}
+}
+
+// baseURL returns the base URL requests should use now, starting a health
+// probe of the primary in the background when one is due.
+func (f *regionFailover) baseURL() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active == 1 && !f.probing && time.Since(f.lastProbe) >= failoverProbeInterval {
+		f.probing = true
+		go f.probe()
+	}
+	return f.urls[f.active]
+}
+
+// record counts the outcome of a request. Requests sent to a region that is
+// no longer active are ignored.
+func (f *regionFailover) record(req *http.Request, resp *http.Response, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(req.URL.String(), f.urls[f.active]) {
+		return
+	}
+	if err == nil && resp.StatusCode < 500 {
+		f.failures = 0
+		return
+	}
+	if req.Context().Err() != nil {
+		return // cancelled by the caller, not a regional failure
+	}
+	f.failures++
+	if f.active == 0 && f.failures >= failoverThreshold {
+		f.active = 1
+		f.failures = 0
+		f.lastProbe = time.Now()
+	}
+}
+
+func (f *regionFailover) probe() {
+	ctx, cancel := context.WithTimeout(context.Background(), failoverProbeTimeout)
+	defer cancel()
+
+	healthy := false
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.urls[0]+healthPath, nil)
+	if err == nil {
+		req.Header.Set("User-Agent", userAgent)
+		if resp, err := f.client.Do(req); err == nil {
+			resp.Body.Close()
+			healthy = resp.StatusCode < 500
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probing = false
+	f.lastProbe = time.Now()
+	if healthy {
+		f.active = 0
+		f.failures = 0
+	}
+}
@@ -9,6 +9,10 @@ type AttestRequest struct {
 	EventType string                 `json:"event_type,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 	Encrypt   bool                   `json:"encrypt,omitempty"`
+	// ExtractText asks the platform to extract the document's text, using
+	// OCR where needed, along with its page count, language and title; see
+	// DocumentsResource.GetExtractedContent.
+	ExtractText bool `json:"extract_text,omitempty"`
 }
 
 // AttestBytesRequest is the request for attesting raw bytes.
@@ -19,6 +23,10 @@ type AttestBytesRequest struct {
 	EventType string                 `json:"event_type,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 	Encrypt   bool                   `json:"encrypt,omitempty"`
+	// ExtractText asks the platform to extract the document's text, using
+	// OCR where needed, along with its page count, language and title; see
+	// DocumentsResource.GetExtractedContent.
+	ExtractText bool `json:"extract_text,omitempty"`
 }
 
 // CreateEventRequest is the request for creating an event.
@@ -27,6 +35,29 @@ type CreateEventRequest struct {
 	UserID    string                 `json:"user_id"`
 	Data      map[string]interface{} `json:"data"`
 	Source    string                 `json:"event_source,omitempty"`
+
+	// Redact lists data fields (dot-separated for nested fields) that are
+	// replaced client-side by a keyed hash before sending; raw values never
+	// leave the process. See RedactValue. A path that is absent from Data
+	// is an error unless RedactOptional is set.
+	Redact         []string `json:"-"`
+	RedactionKey   []byte   `json:"-"`
+	RedactOptional bool     `json:"-"`
+}
+
+// AmendEventRequest is the request for amending an event.
+type AmendEventRequest struct {
+	// Data is the corrected event data. It replaces the original data in
+	// the amendment; the original event is left unchanged.
+	Data   map[string]interface{} `json:"data"`
+	Reason string                 `json:"reason"`
+
+	// Redact, RedactionKey and RedactOptional redact Data before sending,
+	// as for CreateEventRequest. Use the same key as the original event so
+	// redacted values stay comparable across the amendment chain.
+	Redact         []string `json:"-"`
+	RedactionKey   []byte   `json:"-"`
+	RedactOptional bool     `json:"-"`
 }
 
 // ListEventsRequest is the request for listing events.
@@ -38,6 +69,8 @@ type ListEventsRequest struct {
 	EndDate   string `json:"end_date,omitempty"`
 	Limit     int    `json:"limit,omitempty"`
 	Offset    int    `json:"offset,omitempty"`
+	// IncludeArchived includes events hidden with EventsResource.Archive.
+	IncludeArchived bool `json:"include_archived,omitempty"`
 }
 
 // SearchRequest is the request for searching events.
@@ -49,6 +82,8 @@ type SearchRequest struct {
 	EndDate   string `json:"end_date,omitempty"`
 	Limit     int    `json:"limit,omitempty"`
 	Page      int    `json:"page,omitempty"`
+	// IncludeArchived includes events hidden with EventsResource.Archive.
+	IncludeArchived bool `json:"include_archived,omitempty"`
 }
 
 // CreateChannelRequest is the request for creating a state channel.
@@ -87,16 +122,67 @@ type ListCertificatesRequest struct {
 	Offset         int    `json:"offset,omitempty"`
 }
 
+// CertificateSearchRequest is the request for searching certificates. All
+// set criteria must match.
+type CertificateSearchRequest struct {
+	// RecipientName and Title match case-insensitively anywhere in the field.
+	RecipientName string `json:"recipient_name,omitempty"`
+	Title         string `json:"title,omitempty"`
+	// MetadataFilters matches certificates whose metadata has each key set
+	// to the given value; nested keys are dot-separated.
+	MetadataFilters map[string]interface{} `json:"metadata_filters,omitempty"`
+	IssuedFrom      *time.Time             `json:"issued_from,omitempty"`
+	IssuedTo        *time.Time             `json:"issued_to,omitempty"` // Exclusive
+	// Revoked, if set, matches only revoked (true) or unrevoked (false)
+	// certificates.
+	Revoked *bool `json:"revoked,omitempty"`
+	Limit   int   `json:"limit,omitempty"`
+	Offset  int   `json:"offset,omitempty"`
+}
+
+// ClaimLinkOptions configures a certificate claim link.
+type ClaimLinkOptions struct {
+	// ExpiresInHours is the link lifetime (server default 72 hours).
+	ExpiresInHours int `json:"expires_in_hours,omitempty"`
+	// RecipientEmail, if set, restricts claiming to a user with this email
+	// and sends them the link.
+	RecipientEmail string `json:"recipient_email,omitempty"`
+	// RedirectURL is where the recipient is sent after claiming.
+	RedirectURL string `json:"redirect_url,omitempty"`
+}
+
 // CreateWebhookRequest is the request for creating a webhook.
 type CreateWebhookRequest struct {
 	URL    string   `json:"url"`
 	Events []string `json:"events"`
 	Secret string   `json:"secret,omitempty"`
+	// AttestDeliveries attests every delivery (payload hash, destination,
+	// response code) so notifications can later be proven to partners.
+	AttestDeliveries bool `json:"attest_deliveries,omitempty"`
 }
 
 // UpdateWebhookRequest is the request for updating a webhook.
 type UpdateWebhookRequest struct {
-	URL    *string   `json:"url,omitempty"`
-	Events *[]string `json:"events,omitempty"`
-	Active *bool     `json:"active,omitempty"`
+	URL              *string   `json:"url,omitempty"`
+	Events           *[]string `json:"events,omitempty"`
+	Active           *bool     `json:"active,omitempty"`
+	AttestDeliveries *bool     `json:"attest_deliveries,omitempty"`
+}
+
+// ListSettlementsOptions configures channel settlement history queries.
+type ListSettlementsOptions struct {
+	FromDate *time.Time
+	ToDate   *time.Time
+	Limit    int
+	Offset   int
+}
+
+// ListWebhookDeliveriesOptions configures webhook delivery queries.
+type ListWebhookDeliveriesOptions struct {
+	EventType string
+	Status    string // "succeeded", "failed" or "pending"; ListDeliveries only
+	FromDate  *time.Time
+	ToDate    *time.Time
+	Limit     int
+	Offset    int
 }
@@ -0,0 +1,91 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+)
+
+// resultSinkBatchSize is the maximum number of results per ResultSink call
+// for streamed events.
+const resultSinkBatchSize = 1000
+
+// IngestResult maps an ingested event, identified by the caller's ClientRef,
+// to the event and certificate the server created for it.
+type IngestResult struct {
+	ClientRef     string
+	EventID       string
+	CertificateID string
+	Status        string
+}
+
+// ResultSink receives the results of ingested events so callers can persist
+// the mapping from their own references to event and certificate IDs, for
+// example in the same database transaction that marks the events as sent.
+//
+// WriteResults is called with the results of a batch in the order the events
+// were submitted, before IngestBatch returns or, for streams, in the order
+// the server answered, before StreamEvents returns. An error means the results were not persisted; the
+// events were still ingested, and the error is returned to the caller
+// alongside the response so the batch is not considered done.
+// Implementations must be safe for concurrent use when streaming over
+// several connections.
+type ResultSink interface {
+	WriteResults(ctx context.Context, results []IngestResult) error
+}
+
+// ResultSinkFunc adapts a function to a ResultSink.
+type ResultSinkFunc func(ctx context.Context, results []IngestResult) error
+
+// WriteResults calls f(ctx, results).
+func (f ResultSinkFunc) WriteResults(ctx context.Context, results []IngestResult) error {
+	return f(ctx, results)
+}
+
+// WithResultSink delivers the results of every IngestBatch to sink; see
+// ResultSink. Set IngestEventRequest.ClientRef to correlate results with
+// your own records.
+//
+// Example:
+//
+//	proofchain.WithResultSink(proofchain.ResultSinkFunc(func(ctx context.Context, results []proofchain.IngestResult) error {
+//	    tx, err := db.BeginTx(ctx, nil)
+//	    // insert (ClientRef, EventID, CertificateID) rows, then
+//	    return tx.Commit()
+//	}))
+func WithResultSink(sink ResultSink) IngestionClientOption {
+	return func(c *IngestionClient) {
+		c.results = sink
+	}
+}
+
+// WithGRPCResultSink delivers the results of streamed events to sink, in the
+// order the server answered on each stream, in chunks of up to 1000; see
+// ResultSink. Set GRPCEvent.ClientRef to correlate results with your own
+// records: each result carries the ClientRef the server echoed, or none if
+// the server does not echo it.
+func WithGRPCResultSink(sink ResultSink) GRPCClientOption {
+	return func(c *GRPCClient) {
+		c.results = sink
+	}
+}
+
+// writeBatchResults delivers the results of a batch to the client's result
+// sink. The server must have returned one result per event.
+func (c *IngestionClient) writeBatchResults(ctx context.Context, req *BatchIngestRequest, resp *BatchIngestResponse) error {
+	if len(resp.Results) != len(req.Events) {
+		return fmt.Errorf("result sink: got %d results for %d events", len(resp.Results), len(req.Events))
+	}
+	results := make([]IngestResult, len(req.Events))
+	for i, r := range resp.Results {
+		results[i] = IngestResult{
+			ClientRef:     req.Events[i].ClientRef,
+			EventID:       r.EventID,
+			CertificateID: r.CertificateID,
+			Status:        r.Status,
+		}
+	}
+	if err := c.results.WriteResults(ctx, results); err != nil {
+		return fmt.Errorf("result sink: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+)
+
+// Trigger types understood by the rewards engine.
+const (
+	TriggerTypeEventCount      = "event_count"
+	TriggerTypePointsThreshold = "points_threshold"
+	TriggerTypeQuestCompletion = "quest_completion"
+	TriggerTypeManual          = "manual"
+)
+
+// RewardTrigger is a typed trigger condition for a reward definition. Build one
+// with TriggerOnEventCount, TriggerOnPointsThreshold or TriggerOnQuestCompletion
+// and attach it with CreateRewardDefinitionRequest.SetTrigger.
+type RewardTrigger struct {
+	Type   string
+	Config map[string]interface{}
+}
+
+// TriggerOnEventCount awards the reward once a user has sent n events of eventType.
+func TriggerOnEventCount(eventType string, n int) RewardTrigger {
+	return RewardTrigger{
+		Type: TriggerTypeEventCount,
+		Config: map[string]interface{}{
+			"event_type": eventType,
+			"count":      n,
+		},
+	}
+}
+
+// TriggerOnPointsThreshold awards the reward once a user's points reach n.
+func TriggerOnPointsThreshold(n int) RewardTrigger {
+	return RewardTrigger{
+		Type: TriggerTypePointsThreshold,
+		Config: map[string]interface{}{
+			"threshold": n,
+		},
+	}
+}
+
+// TriggerOnQuestCompletion awards the reward when a user completes questID.
+func TriggerOnQuestCompletion(questID string) RewardTrigger {
+	return RewardTrigger{
+		Type: TriggerTypeQuestCompletion,
+		Config: map[string]interface{}{
+			"quest_id": questID,
+		},
+	}
+}
+
+// Validate checks the trigger locally for missing or out-of-range values.
+func (t RewardTrigger) Validate() error {
+	var details []ValidationErrorDetail
+	switch t.Type {
+	case TriggerTypeEventCount:
+		if s, _ := t.Config["event_type"].(string); s == "" {
+			details = append(details, ValidationErrorDetail{Field: "trigger_config.event_type", Message: "event_type is required"})
+		}
+		if n, _ := t.Config["count"].(int); n < 1 {
+			details = append(details, ValidationErrorDetail{Field: "trigger_config.count", Message: "count must be at least 1"})
+		}
+	case TriggerTypePointsThreshold:
+		if n, _ := t.Config["threshold"].(int); n < 1 {
+			details = append(details, ValidationErrorDetail{Field: "trigger_config.threshold", Message: "threshold must be at least 1"})
+		}
+	case TriggerTypeQuestCompletion:
+		if s, _ := t.Config["quest_id"].(string); s == "" {
+			details = append(details, ValidationErrorDetail{Field: "trigger_config.quest_id", Message: "quest_id is required"})
+		}
+	case TriggerTypeManual:
+	default:
+		details = append(details, ValidationErrorDetail{Field: "trigger_type", Message: fmt.Sprintf("unknown trigger type %q", t.Type)})
+	}
+	if len(details) > 0 {
+		return NewValidationError("invalid reward trigger", details)
+	}
+	return nil
+}
+
+// SetTrigger sets TriggerType and TriggerConfig from a typed trigger.
+func (req *CreateRewardDefinitionRequest) SetTrigger(t RewardTrigger) *CreateRewardDefinitionRequest {
+	req.TriggerType = t.Type
+	req.TriggerConfig = t.Config
+	return req
+}
+
+// RewardDefinitionValidation is the result of validating a reward definition.
+type RewardDefinitionValidation struct {
+	Valid    bool                    `json:"valid"`
+	Errors   []ValidationErrorDetail `json:"errors,omitempty"`
+	Warnings []string                `json:"warnings,omitempty"`
+}
+
+// ValidateDefinition asks the server to validate a reward definition,
+// including its trigger config, without creating it. Malformed triggers are
+// otherwise only detected at award time.
+func (r *RewardsClient) ValidateDefinition(ctx context.Context, req *CreateRewardDefinitionRequest) (*RewardDefinitionValidation, error) {
+	var result RewardDefinitionValidation
+	err := r.http.Post(ctx, "/rewards/definitions/validate", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -48,8 +48,18 @@ type EarnedReward struct {
 	NFTTxHash      *string    `json:"nft_tx_hash,omitempty"`
 	EarnedAt       time.Time  `json:"earned_at"`
 	DistributedAt  *time.Time `json:"distributed_at,omitempty"`
+	ErrorMessage   *string    `json:"error_message,omitempty"`
 }
 
+// Earned reward statuses.
+const (
+	RewardStatusPending      = "pending"
+	RewardStatusDistributing = "distributing"
+	RewardStatusDistributed  = "distributed"
+	RewardStatusClaimed      = "claimed"
+	RewardStatusFailed       = "failed"
+)
+
 // RewardAsset represents an asset for a reward
 type RewardAsset struct {
 	ID           string                 `json:"id"`
@@ -285,9 +295,125 @@ func (r *RewardsClient) DistributePending(ctx context.Context, earnedRewardID st
 	return &reward, nil
 }
 
+// GetEarned returns a single earned reward
+func (r *RewardsClient) GetEarned(ctx context.Context, earnedRewardID string) (*EarnedReward, error) {
+	var reward EarnedReward
+	err := r.http.Get(ctx, "/rewards/earned/"+earnedRewardID, nil, &reward)
+	if err != nil {
+		return nil, err
+	}
+	return &reward, nil
+}
+
+// distributionPollInterval is how often WaitForDistribution checks status.
+var distributionPollInterval = 2 * time.Second
+
+// WaitForDistribution polls an earned reward until its NFT/token distribution
+// completes or fails. A failed distribution returns the reward together with an
+// error carrying the server's failure message. Use a context deadline to bound
+// the wait.
+func (r *RewardsClient) WaitForDistribution(ctx context.Context, earnedRewardID string) (*EarnedReward, error) {
+	for {
+		reward, err := r.GetEarned(ctx, earnedRewardID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch reward.Status {
+		case RewardStatusDistributed, RewardStatusClaimed:
+			return reward, nil
+		case RewardStatusFailed:
+			message := "reward distribution failed"
+			if reward.ErrorMessage != nil && *reward.ErrorMessage != "" {
+				message += ": " + *reward.ErrorMessage
+			}
+			return reward, &APIError{Message: message}
+		}
+
+		select {
+		case <-ctx.Done():
+			return reward, NewTimeoutError()
+		case <-time.After(distributionPollInterval):
+		}
+	}
+}
+
+// RetryDistributionsResult summarises a RetryFailedDistributions run.
+type RetryDistributionsResult struct {
+	Retried   int               `json:"retried"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Rewards   []EarnedReward    `json:"rewards"`
+	Errors    map[string]string `json:"errors,omitempty"` // earned reward ID -> error
+}
+
+// RetryFailedDistributions re-drives every failed distribution for a reward
+// definition by calling DistributePending on each. Individual failures are
+// recorded in the result rather than aborting the run.
+func (r *RewardsClient) RetryFailedDistributions(ctx context.Context, definitionID string) (*RetryDistributionsResult, error) {
+	const pageSize = 100
+	var failed []EarnedReward
+	for offset := 0; ; offset += pageSize {
+		page, err := r.ListEarned(ctx, "", definitionID, RewardStatusFailed, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		failed = append(failed, page...)
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	result := &RetryDistributionsResult{
+		Rewards: make([]EarnedReward, 0, len(failed)),
+		Errors:  make(map[string]string),
+	}
+	for _, earned := range failed {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		result.Retried++
+		reward, err := r.DistributePending(ctx, earned.ID)
+		if err != nil {
+			result.Failed++
+			result.Errors[earned.ID] = err.Error()
+			result.Rewards = append(result.Rewards, earned)
+			continue
+		}
+		result.Succeeded++
+		result.Rewards = append(result.Rewards, *reward)
+	}
+	return result, nil
+}
+
 // ListAssets returns assets for a reward definition
 func (r *RewardsClient) ListAssets(ctx context.Context, definitionID string) ([]RewardAsset, error) {
 	var assets []RewardAsset
 	err := r.http.Get(ctx, "/rewards/definitions/"+definitionID+"/assets", nil, &assets)
 	return assets, err
 }
+
+// UploadAsset attaches a file, such as NFT artwork or a badge icon, to a
+// reward definition. assetType says how the asset is used, e.g. "image" or
+// "icon".
+func (r *RewardsClient) UploadAsset(ctx context.Context, definitionID string, content []byte, filename, assetType string) (*RewardAsset, error) {
+	if assetType == "" {
+		return nil, NewValidationError("asset type is required", []ValidationErrorDetail{
+			{Field: "asset_type", Message: "is required"},
+		})
+	}
+
+	var asset RewardAsset
+	err := r.http.RequestMultipart(ctx, "/rewards/definitions/"+definitionID+"/assets", map[string]string{
+		"asset_type": assetType,
+	}, "file", filename, content, &asset)
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// DeleteAsset removes an asset from a reward definition
+func (r *RewardsClient) DeleteAsset(ctx context.Context, definitionID, assetID string) error {
+	return r.http.Delete(ctx, "/rewards/definitions/"+definitionID+"/assets/"+assetID)
+}
@@ -0,0 +1,371 @@
+package proofchain
+
+import "strings"
+
+// routeTemplates lists the API paths the SDK calls, with path parameters as
+// ":id". Request metrics are labelled with the matching template so endpoint
+// label cardinality stays bounded; add new endpoints here.
+var routeTemplates = []string{
+	"/certificates",
+	"/certificates/:id",
+	"/certificates/:id/claim",
+	"/certificates/:id/claim-links",
+	"/certificates/:id/revoke",
+	"/certificates/search",
+
+	"/channels",
+	"/channels/:id",
+	"/channels/:id/close",
+	"/channels/:id/settle",
+	"/channels/:id/settlements",
+	"/channels/:id/status",
+	"/channels/:id/stream",
+	"/channels/:id/stream/batch",
+
+	"/cohorts/definitions",
+	"/cohorts/definitions/:id",
+	"/cohorts/definitions/:id/archive",
+	"/cohorts/definitions/:id/leaderboard",
+	"/cohorts/definitions/:id/recompute",
+	"/cohorts/users/:id/breakdown",
+
+	"/competitions",
+	"/competitions/:id",
+	"/competitions/:id/cancel",
+	"/competitions/:id/distribute",
+	"/competitions/:id/finalize",
+	"/competitions/:id/standings",
+
+	"/credentials/issue",
+	"/credentials/issued",
+	"/credentials/issued/:id/reinstate",
+	"/credentials/issued/:id/revoke",
+	"/credentials/issued/:id/suspend",
+	"/credentials/opt-in/:id",
+	"/credentials/opt-out/:id",
+	"/credentials/types",
+	"/credentials/types/:id",
+	"/credentials/types/:id/activate",
+	"/credentials/types/:id/archive",
+	"/credentials/user/:id",
+	"/credentials/verify/:id",
+
+	"/data-mesh/event-metadata",
+	"/data-mesh/views",
+	"/data-mesh/views/:id/activity-summary",
+	"/data-mesh/views/:id/custom/:id",
+	"/data-mesh/views/:id/fan-profile",
+	"/data-mesh/views/custom",
+	"/data-mesh/views/custom/:id",
+	"/data-mesh/views/custom/:id/execute-bulk",
+	"/data-mesh/views/custom/:id/materialize",
+	"/data-mesh/views/custom/:id/materialized/:id",
+	"/data-mesh/views/preview",
+	"/data-mesh/views/templates",
+
+	"/end-users",
+	"/end-users/:id",
+	"/end-users/:id/gdpr",
+	"/end-users/:id/gdpr/preview",
+	"/end-users/:id/rewards",
+	"/end-users/activity-export",
+	"/end-users/attribute-schema",
+	"/end-users/bulk",
+	"/end-users/by-external/:id",
+	"/end-users/by-external/:id/activity",
+	"/end-users/by-external/:id/add-points",
+	"/end-users/by-external/:id/create-wallet",
+	"/end-users/by-external/:id/link-wallet",
+	"/end-users/by-external/:id/points-ledger",
+	"/end-users/by-external/:id/register-wallet",
+	"/end-users/by-external/:id/rewards",
+	"/end-users/by-external/:id/session-token",
+	"/end-users/merge",
+	"/end-users/points/transfer",
+
+	"/events/:id/status",
+	"/events/ingest",
+	"/events/ingest/batch",
+	"/events/status/batch",
+	"/events/types",
+
+	"/health",
+
+	"/org/tenants",
+	"/org/usage",
+
+	"/partner-keys/:id/ott-config",
+	"/partner-keys/:id/ott/request",
+	"/partner-keys/ott/redeem",
+
+	"/passport-v2/fanpass/:id/comparison",
+	"/passport-v2/fanpass/aggregation-rules",
+	"/passport-v2/fanpass/aggregation-rules/:id",
+	"/passport-v2/fanpass/leaderboard",
+
+	"/passports",
+	"/passports/:id",
+	"/passports/:id/achievements",
+	"/passports/:id/achievements/:id",
+	"/passports/:id/achievements/recompute",
+	"/passports/:id/add-points",
+	"/passports/:id/assign-template/:id",
+	"/passports/:id/badges",
+	"/passports/:id/badges/:id",
+	"/passports/:id/fields",
+	"/passports/:id/fields/:id",
+	"/passports/:id/history",
+	"/passports/:id/level-up",
+	"/passports/:id/points-ledger",
+	"/passports/:id/recompute",
+	"/passports/achievements",
+	"/passports/achievements/:id/event-binding",
+	"/passports/badges",
+	"/passports/templates",
+	"/passports/templates/:id",
+	"/passports/templates/:id/export",
+	"/passports/templates/:id/fields",
+	"/passports/templates/import",
+
+	"/quests",
+	"/quests/:id",
+	"/quests/:id/activate",
+	"/quests/:id/analytics",
+	"/quests/:id/archive",
+	"/quests/:id/leaderboard",
+	"/quests/:id/pause",
+	"/quests/:id/progress/:id",
+	"/quests/:id/progress/:id/claim",
+	"/quests/:id/progress/:id/step/:id/complete",
+	"/quests/:id/progress/:id/step/:id/start",
+	"/quests/:id/progress/stream",
+	"/quests/:id/start",
+	"/quests/:id/steps",
+	"/quests/:id/steps/:id",
+	"/quests/:id/steps/reorder",
+	"/quests/slug/:id",
+	"/quests/user/:id/progress",
+	"/quests/with-progress",
+
+	"/rewards/award",
+	"/rewards/catalog",
+	"/rewards/definitions",
+	"/rewards/definitions/:id",
+	"/rewards/definitions/:id/activate",
+	"/rewards/definitions/:id/assets",
+	"/rewards/definitions/:id/assets/:id",
+	"/rewards/definitions/:id/deactivate",
+	"/rewards/definitions/validate",
+	"/rewards/earned",
+	"/rewards/earned/:id",
+	"/rewards/earned/:id/claim",
+	"/rewards/earned/:id/distribute",
+	"/rewards/users/:id/rewards",
+
+	"/schemas",
+	"/schemas/:id",
+	"/schemas/:id/:id",
+	"/schemas/:id/:id/activate",
+	"/schemas/:id/:id/deprecate",
+	"/schemas/:id/:id/set-default",
+	"/schemas/:id/activate",
+	"/schemas/:id/clone",
+	"/schemas/:id/deprecate",
+	"/schemas/:id/parse",
+	"/schemas/:id/stats",
+	"/schemas/validate",
+	"/schemas/validate/batch",
+
+	"/search",
+	"/search/by-certificate/:id",
+	"/search/by-user/:id",
+	"/search/facets",
+	"/search/quick",
+	"/search/saved",
+	"/search/saved/:id",
+	"/search/saved/:id/run",
+	"/search/scroll",
+	"/search/scroll/:id",
+	"/search/scroll/next",
+	"/search/stats",
+	"/search/timeseries",
+
+	"/segments",
+	"/segments/:id",
+	"/segments/:id/members",
+	"/segments/:id/members/remove",
+	"/segments/:id/recompute",
+
+	"/tenant",
+	"/tenant/api-keys",
+	"/tenant/api-keys/:id",
+	"/tenant/api-keys/me",
+	"/tenant/blockchain/certificates",
+	"/tenant/blockchain/export",
+	"/tenant/blockchain/stats",
+	"/tenant/blockchain/verify/:id",
+	"/tenant/context",
+	"/tenant/documents",
+	"/tenant/documents/:id/content",
+	"/tenant/documents/uploads",
+	"/tenant/documents/uploads/:id",
+	"/tenant/documents/uploads/:id/complete",
+	"/tenant/documents/uploads/:id/parts/:id",
+	"/tenant/events",
+	"/tenant/events/:id",
+	"/tenant/events/:id/amend",
+	"/tenant/events/:id/archive",
+	"/tenant/events/:id/history",
+	"/tenant/events/:id/restore",
+	"/tenant/events/:id/settle",
+	"/tenant/events/by-hash/:id",
+	"/tenant/events/force-batch",
+	"/tenant/events/settle-all",
+	"/tenant/me",
+	"/tenant/usage",
+	"/tenant/usage/alerts",
+	"/tenant/usage/alerts/:id",
+	"/tenant/usage/detailed",
+	"/tenant/vault",
+	"/tenant/vault/files/:id",
+	"/tenant/vault/files/:id/attest",
+	"/tenant/vault/files/:id/download",
+	"/tenant/vault/files/:id/move",
+	"/tenant/vault/files/:id/versions",
+	"/tenant/vault/files/:id/versions/:id/download",
+	"/tenant/vault/folders",
+	"/tenant/vault/folders/:id",
+	"/tenant/vault/search",
+	"/tenant/vault/share",
+	"/tenant/vault/stats",
+	"/tenant/vault/upload",
+
+	"/time",
+
+	"/tokens",
+	"/tokens/:id",
+	"/tokens/by-contract/:id",
+	"/tokens/global",
+	"/tokens/prices",
+
+	"/verify/:id",
+	"/verify/batch",
+	"/verify/batch/:id",
+	"/verify/cert/:id",
+	"/verify/certificate/:id",
+	"/verify/document",
+	"/verify/event/:id",
+	"/verify/event/:id/batch-proof",
+	"/verify/proof",
+
+	"/wallets",
+	"/wallets/:id",
+	"/wallets/:id/activity-subscriptions",
+	"/wallets/:id/activity-subscriptions/:id",
+	"/wallets/:id/allowances",
+	"/wallets/:id/approve",
+	"/wallets/:id/balance",
+	"/wallets/:id/contract-call",
+	"/wallets/:id/export-backup",
+	"/wallets/:id/export-key",
+	"/wallets/:id/info",
+	"/wallets/:id/nfts",
+	"/wallets/:id/nfts/:id/refresh-metadata",
+	"/wallets/:id/sign",
+	"/wallets/:id/smart-account",
+	"/wallets/:id/smart-account/deploy",
+	"/wallets/:id/transactions",
+	"/wallets/contacts",
+	"/wallets/contacts/:id",
+	"/wallets/contracts/read",
+	"/wallets/dual",
+	"/wallets/dual/bulk",
+	"/wallets/gas-policies",
+	"/wallets/gas-policies/:id",
+	"/wallets/gas-policies/usage",
+	"/wallets/import-backup",
+	"/wallets/nft-collections",
+	"/wallets/nft-collections/:id",
+	"/wallets/nfts/by-contract/:id",
+	"/wallets/nfts/mint",
+	"/wallets/stats",
+	"/wallets/swaps/:id",
+	"/wallets/swaps/execute",
+	"/wallets/swaps/quote",
+	"/wallets/transfer",
+	"/wallets/transfers/batch",
+	"/wallets/transfers/batch/:id",
+	"/wallets/user/:id",
+	"/wallets/user/:id/all",
+	"/wallets/user/:id/nfts",
+	"/wallets/user/:id/summary",
+	"/wallets/users-with-wallets",
+	"/wallets/verify-signature",
+
+	"/webhooks",
+	"/webhooks/:id",
+	"/webhooks/:id/deliveries",
+	"/webhooks/:id/deliveries/:id/redeliver",
+	"/webhooks/:id/deliveries/attested",
+	"/webhooks/:id/test",
+}
+
+// otherRoute labels requests to paths not in routeTemplates, such as those
+// made through Client.Do.
+const otherRoute = "other"
+
+var parsedRoutes = parseRoutes(routeTemplates)
+
+func parseRoutes(templates []string) [][]string {
+	routes := make([][]string, len(templates))
+	for i, t := range templates {
+		routes[i] = strings.Split(strings.TrimPrefix(t, "/"), "/")
+	}
+	return routes
+}
+
+// endpointLabel returns the route template matching path. The template is
+// matched against the end of path so a base URL with a path prefix still
+// resolves; the longest match wins, then the one with the most literal
+// segments, so "/tenant/events/force-batch" is not labelled
+// "/tenant/events/:id".
+func endpointLabel(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	best, bestLen, bestLiterals := -1, 0, 0
+	for i, route := range parsedRoutes {
+		literals, ok := matchRoute(route, segments)
+		if !ok {
+			continue
+		}
+		if len(route) > bestLen || len(route) == bestLen && literals > bestLiterals {
+			best, bestLen, bestLiterals = i, len(route), literals
+		}
+	}
+	if best < 0 {
+		return otherRoute
+	}
+	return routeTemplates[best]
+}
+
+// matchRoute reports whether route matches the trailing segments of path
+// and how many of its segments are literals.
+func matchRoute(route, path []string) (int, bool) {
+	if len(route) > len(path) {
+		return 0, false
+	}
+	path = path[len(path)-len(route):]
+	literals := 0
+	for i, s := range route {
+		switch {
+		case s == ":id":
+			if path[i] == "" {
+				return 0, false
+			}
+		case s == path[i]:
+			literals++
+		default:
+			return 0, false
+		}
+	}
+	return literals, true
+}
@@ -0,0 +1,24 @@
+package proofchain
+
+import "testing"
+
+func TestEndpointLabel(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/end-users/by-external/alice/rewards", "/end-users/by-external/:id/rewards"},
+		{"/tenant/events/force-batch", "/tenant/events/force-batch"},
+		{"/tenant/events/3f2a9c", "/tenant/events/:id"},
+		{"/schemas/invoice/1.2.0/activate", "/schemas/:id/:id/activate"},
+		{"/api/v1/certificates/cert-42", "/certificates/:id"},
+		{"/end-users/by-external/a%2Fb", "/end-users/by-external/:id"},
+		{"/beta/widgets/count", "other"},
+		{"/certificates/", "other"},
+	}
+	for _, tt := range tests {
+		if got := endpointLabel(tt.path); got != tt.want {
+			t.Errorf("endpointLabel(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// SchemaCompatibility is the compatibility verdict between two schema versions.
+type SchemaCompatibility string
+
+const (
+	// SchemaCompatibilityFull means events valid under either version are
+	// valid under the other.
+	SchemaCompatibilityFull SchemaCompatibility = "full"
+	// SchemaCompatibilityBackward means events written for the old version
+	// are still accepted by the new version.
+	SchemaCompatibilityBackward SchemaCompatibility = "backward"
+	// SchemaCompatibilityForward means events written for the new version
+	// are still accepted by the old version.
+	SchemaCompatibilityForward SchemaCompatibility = "forward"
+	// SchemaCompatibilityBreaking means neither holds; publishing the new
+	// version will reject events from existing producers.
+	SchemaCompatibilityBreaking SchemaCompatibility = "breaking"
+)
+
+// SchemaFieldChange describes a field present in both versions whose
+// definition differs.
+type SchemaFieldChange struct {
+	Name    string      `json:"name"`
+	From    SchemaField `json:"from"`
+	To      SchemaField `json:"to"`
+	Changes []string    `json:"changes"`
+}
+
+// SchemaDiff is the difference between two versions of a schema.
+type SchemaDiff struct {
+	Name          string              `json:"name"`
+	FromVersion   string              `json:"from_version"`
+	ToVersion     string              `json:"to_version"`
+	Added         []SchemaField       `json:"added"`
+	Removed       []SchemaField       `json:"removed"`
+	Changed       []SchemaFieldChange `json:"changed"`
+	Compatibility SchemaCompatibility `json:"compatibility"`
+	// BackwardIssues and ForwardIssues explain why the respective
+	// compatibility does not hold.
+	BackwardIssues []string `json:"backward_issues,omitempty"`
+	ForwardIssues  []string `json:"forward_issues,omitempty"`
+}
+
+// IsBreaking reports whether the new version is incompatible in both
+// directions.
+func (d *SchemaDiff) IsBreaking() bool {
+	return d.Compatibility == SchemaCompatibilityBreaking
+}
+
+// Diff compares two versions of a schema field by field and reports their
+// compatibility.
+func (s *SchemasClient) Diff(ctx context.Context, name, versionA, versionB string) (*SchemaDiff, error) {
+	from, err := s.Get(ctx, name, &versionA)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.Get(ctx, name, &versionB)
+	if err != nil {
+		return nil, err
+	}
+	return diffSchemas(from, to), nil
+}
+
+// CheckCompatibility compares proposed YAML content against the current
+// default version of a schema without publishing it. Call it before Update to
+// avoid rejecting events from existing producers.
+func (s *SchemasClient) CheckCompatibility(ctx context.Context, name, yamlContent string) (*SchemaDiff, error) {
+	current, err := s.Get(ctx, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The YAML is parsed server-side so the comparison sees exactly the
+	// definition Update would store.
+	var proposed SchemaDetail
+	err = s.http.Post(ctx, "/schemas/"+url.PathEscape(name)+"/parse", &CreateSchemaRequest{YAMLContent: yamlContent}, &proposed)
+	if err != nil {
+		return nil, err
+	}
+	return diffSchemas(current, &proposed), nil
+}
+
+// diffSchemas compares the fields of two schema versions. Backward
+// compatibility asks whether events valid under from are valid under to;
+// forward compatibility asks the reverse.
+func diffSchemas(from, to *SchemaDetail) *SchemaDiff {
+	diff := &SchemaDiff{
+		Name:        to.Name,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Added:       []SchemaField{},
+		Removed:     []SchemaField{},
+		Changed:     []SchemaFieldChange{},
+	}
+	if diff.Name == "" {
+		diff.Name = from.Name
+	}
+
+	fromFields := schemaFields(from.SchemaDefinition)
+	toFields := schemaFields(to.SchemaDefinition)
+	fromOpen := additionalFieldsAllowed(from.SchemaDefinition)
+	toOpen := additionalFieldsAllowed(to.SchemaDefinition)
+
+	for _, name := range sortedFieldNames(toFields) {
+		f := toFields[name]
+		if _, ok := fromFields[name]; ok {
+			continue
+		}
+		diff.Added = append(diff.Added, f)
+		if f.Required && f.Default == nil {
+			diff.BackwardIssues = append(diff.BackwardIssues, fmt.Sprintf("%s: added as required without a default", name))
+		}
+		if !fromOpen {
+			diff.ForwardIssues = append(diff.ForwardIssues, fmt.Sprintf("%s: added but the old version rejects unknown fields", name))
+		}
+	}
+
+	for _, name := range sortedFieldNames(fromFields) {
+		f := fromFields[name]
+		t, ok := toFields[name]
+		if !ok {
+			diff.Removed = append(diff.Removed, f)
+			if !toOpen {
+				diff.BackwardIssues = append(diff.BackwardIssues, fmt.Sprintf("%s: removed but the new version rejects unknown fields", name))
+			}
+			if f.Required && f.Default == nil {
+				diff.ForwardIssues = append(diff.ForwardIssues, fmt.Sprintf("%s: removed but required by the old version", name))
+			}
+			continue
+		}
+		if change, backward, forward := diffSchemaField(f, t); len(change.Changes) > 0 {
+			diff.Changed = append(diff.Changed, change)
+			diff.BackwardIssues = append(diff.BackwardIssues, backward...)
+			diff.ForwardIssues = append(diff.ForwardIssues, forward...)
+		}
+	}
+
+	if fromOpen && !toOpen {
+		diff.BackwardIssues = append(diff.BackwardIssues, "additional_fields: no longer allowed")
+	}
+	if !fromOpen && toOpen {
+		diff.ForwardIssues = append(diff.ForwardIssues, "additional_fields: now allowed but rejected by the old version")
+	}
+
+	switch backward, forward := len(diff.BackwardIssues) == 0, len(diff.ForwardIssues) == 0; {
+	case backward && forward:
+		diff.Compatibility = SchemaCompatibilityFull
+	case backward:
+		diff.Compatibility = SchemaCompatibilityBackward
+	case forward:
+		diff.Compatibility = SchemaCompatibilityForward
+	default:
+		diff.Compatibility = SchemaCompatibilityBreaking
+	}
+	return diff
+}
+
+// diffSchemaField compares a field present in both versions, returning the
+// change and the backward and forward compatibility issues it introduces.
+func diffSchemaField(from, to SchemaField) (SchemaFieldChange, []string, []string) {
+	change := SchemaFieldChange{Name: to.Name, From: from, To: to}
+	var backward, forward []string
+	both := func(msg string) {
+		backward = append(backward, to.Name+": "+msg)
+		forward = append(forward, to.Name+": "+msg)
+	}
+
+	if normalizeFieldType(from.Type) != normalizeFieldType(to.Type) {
+		change.Changes = append(change.Changes, fmt.Sprintf("type %s -> %s", from.Type, to.Type))
+		both(fmt.Sprintf("type changed from %s to %s", from.Type, to.Type))
+	}
+
+	if from.Required != to.Required {
+		change.Changes = append(change.Changes, fmt.Sprintf("required %t -> %t", from.Required, to.Required))
+		if to.Required && to.Default == nil {
+			backward = append(backward, to.Name+": became required")
+		}
+		if from.Required && from.Default == nil {
+			forward = append(forward, to.Name+": no longer required")
+		}
+	}
+
+	if msg, tighter, looser := compareBound("min", from.Min, to.Min, false); msg != "" {
+		change.Changes = append(change.Changes, msg)
+		if tighter {
+			backward = append(backward, to.Name+": "+msg)
+		}
+		if looser {
+			forward = append(forward, to.Name+": "+msg)
+		}
+	}
+	if msg, tighter, looser := compareBound("max", from.Max, to.Max, true); msg != "" {
+		change.Changes = append(change.Changes, msg)
+		if tighter {
+			backward = append(backward, to.Name+": "+msg)
+		}
+		if looser {
+			forward = append(forward, to.Name+": "+msg)
+		}
+	}
+
+	if stringValue(from.Pattern) != stringValue(to.Pattern) {
+		msg := fmt.Sprintf("pattern %q -> %q", stringValue(from.Pattern), stringValue(to.Pattern))
+		change.Changes = append(change.Changes, msg)
+		if to.Pattern != nil {
+			backward = append(backward, to.Name+": "+msg)
+		}
+		if from.Pattern != nil {
+			forward = append(forward, to.Name+": "+msg)
+		}
+	}
+
+	for _, v := range from.Values {
+		if !containsString(to.Values, v) {
+			change.Changes = append(change.Changes, fmt.Sprintf("enum value %q removed", v))
+			backward = append(backward, fmt.Sprintf("%s: enum value %q removed", to.Name, v))
+		}
+	}
+	for _, v := range to.Values {
+		if !containsString(from.Values, v) {
+			change.Changes = append(change.Changes, fmt.Sprintf("enum value %q added", v))
+			forward = append(forward, fmt.Sprintf("%s: enum value %q added", to.Name, v))
+		}
+	}
+
+	return change, backward, forward
+}
+
+// compareBound compares a min or max bound. tighter reports that values
+// accepted before may now be rejected; looser reports the reverse.
+func compareBound(name string, from, to *float64, upper bool) (msg string, tighter, looser bool) {
+	switch {
+	case from == nil && to == nil:
+		return "", false, false
+	case from == nil:
+		return fmt.Sprintf("%s added (%v)", name, *to), true, false
+	case to == nil:
+		return fmt.Sprintf("%s removed (was %v)", name, *from), false, true
+	case *from == *to:
+		return "", false, false
+	}
+	msg = fmt.Sprintf("%s %v -> %v", name, *from, *to)
+	if (*to > *from) != upper {
+		return msg, true, false
+	}
+	return msg, false, true
+}
+
+// normalizeFieldType maps type aliases accepted by checkSchemaValue to a
+// single name so alias-only changes are not reported.
+func normalizeFieldType(t string) string {
+	switch t {
+	case "text":
+		return "string"
+	case "float", "decimal":
+		return "number"
+	case "int":
+		return "integer"
+	case "bool":
+		return "boolean"
+	case "timestamp":
+		return "datetime"
+	case "map":
+		return "object"
+	case "list":
+		return "array"
+	}
+	return t
+}
+
+func additionalFieldsAllowed(def map[string]interface{}) bool {
+	allowed, ok := def["additional_fields"].(bool)
+	return !ok || allowed
+}
+
+func sortedFieldNames(fields map[string]SchemaField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
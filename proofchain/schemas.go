@@ -132,7 +132,7 @@ func (s *SchemasClient) Get(ctx context.Context, name string, version *string) (
 	}
 
 	var schema SchemaDetail
-	err := s.http.Get(ctx, path, nil, &schema)
+	err := s.http.GetCached(ctx, path, nil, &schema)
 	if err != nil {
 		return nil, err
 	}
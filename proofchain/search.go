@@ -8,6 +8,13 @@ import (
 )
 
 // SearchFilters contains search filter criteria.
+//
+// DataFilters filters on event data fields in the wire format: each key is a
+// dotted path into the event data and each value either a bare value to
+// match exactly or a map from SearchOperator to operand, such as
+// {"payment.amount": {"gte": 100, "lt": 500}}. Data holds the same
+// conditions in typed form (see DataCondition) and is merged into
+// DataFilters when the query is sent.
 type SearchFilters struct {
 	Query              string                 `json:"query,omitempty"`
 	EventTypes         []string               `json:"event_types,omitempty"`
@@ -20,6 +27,8 @@ type SearchFilters struct {
 	FromDate           *Timestamp             `json:"from_date,omitempty"`
 	ToDate             *Timestamp             `json:"to_date,omitempty"`
 	DataFilters        map[string]interface{} `json:"data_filters,omitempty"`
+	Data               []DataCondition        `json:"-"`
+	IncludeArchived    bool                   `json:"include_archived,omitempty"`
 }
 
 // SearchRequest contains parameters for searching events.
@@ -83,6 +92,16 @@ type SearchStats struct {
 	TotalStorageBytes int64                    `json:"total_storage_bytes"`
 }
 
+// SavedSearch is a named, reusable search query.
+type SavedSearch struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description,omitempty"`
+	Query       map[string]interface{} `json:"query"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+}
+
 // SearchResource handles search operations.
 type SearchResource struct {
 	http *HTTPClient
@@ -90,6 +109,21 @@ type SearchResource struct {
 
 // Query searches events with filters.
 func (r *SearchResource) Query(ctx context.Context, req *SearchQueryRequest) (*SearchResponse, error) {
+	payload, err := searchPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SearchResponse
+	err = r.http.Post(ctx, "/search", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// searchPayload converts a query request to the wire format.
+func searchPayload(req *SearchQueryRequest) (map[string]interface{}, error) {
 	payload := map[string]interface{}{
 		"offset": req.Offset,
 		"limit":  req.Limit,
@@ -114,6 +148,9 @@ func (r *SearchResource) Query(ctx context.Context, req *SearchQueryRequest) (*S
 		if len(req.Filters.UserIDs) > 0 {
 			filters["user_ids"] = req.Filters.UserIDs
 		}
+		if len(req.Filters.CertificateIDs) > 0 {
+			filters["certificate_ids"] = req.Filters.CertificateIDs
+		}
 		if req.Filters.Status != "" {
 			filters["status"] = req.Filters.Status
 		}
@@ -129,17 +166,21 @@ func (r *SearchResource) Query(ctx context.Context, req *SearchQueryRequest) (*S
 		if req.Filters.ToDate != nil {
 			filters["to_date"] = req.Filters.ToDate.Format(time.RFC3339)
 		}
+		dataFilters, err := dataFiltersPayload(req.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if len(dataFilters) > 0 {
+			filters["data_filters"] = dataFilters
+		}
+		if req.Filters.IncludeArchived {
+			filters["include_archived"] = true
+		}
 		if len(filters) > 0 {
 			payload["filters"] = filters
 		}
 	}
-
-	var result SearchResponse
-	err := r.http.Post(ctx, "/search", payload, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return payload, nil
 }
 
 // Quick performs a quick search across all fields.
@@ -215,3 +256,61 @@ func (r *SearchResource) Stats(ctx context.Context) (*SearchStats, error) {
 	}
 	return &result, nil
 }
+
+// SaveQuery stores req as a named search that can be run later with RunSaved.
+func (r *SearchResource) SaveQuery(ctx context.Context, name string, req *SearchQueryRequest) (*SavedSearch, error) {
+	if name == "" {
+		return nil, NewValidationError("name is required", []ValidationErrorDetail{
+			{Field: "name", Message: "is required"},
+		})
+	}
+
+	query, err := searchPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SavedSearch
+	err = r.http.Post(ctx, "/search/saved", map[string]interface{}{
+		"name":  name,
+		"query": query,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListSaved lists saved searches.
+func (r *SearchResource) ListSaved(ctx context.Context) ([]SavedSearch, error) {
+	var result []SavedSearch
+	err := r.http.Get(ctx, "/search/saved", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RunSaved runs a saved search. limit and offset override the saved paging
+// when non-zero.
+func (r *SearchResource) RunSaved(ctx context.Context, savedSearchID string, limit, offset int) (*SearchResponse, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", intToString(limit))
+	}
+	if offset > 0 {
+		params.Set("offset", intToString(offset))
+	}
+
+	var result SearchResponse
+	err := r.http.Get(ctx, "/search/saved/"+url.PathEscape(savedSearchID)+"/run", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSaved deletes a saved search.
+func (r *SearchResource) DeleteSaved(ctx context.Context, savedSearchID string) error {
+	return r.http.Delete(ctx, "/search/saved/"+url.PathEscape(savedSearchID))
+}
@@ -0,0 +1,180 @@
+package proofchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// dataPathPattern matches a dotted path into event data, such as
+// "payment.amount".
+var dataPathPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*(\.[A-Za-z_][A-Za-z0-9_-]*)*$`)
+
+// DataCondition is a typed condition on an event data field, for
+// SearchFilters.Data. Path addresses nested fields with dots and may start
+// with "data.", e.g. "data.payment.amount" or "payment.amount".
+//
+// Example:
+//
+//	results, err := client.Search.Query(ctx, &proofchain.SearchQueryRequest{
+//	    Filters: &proofchain.SearchFilters{
+//	        EventTypes: []string{"purchase"},
+//	        Data: []proofchain.DataCondition{
+//	            proofchain.DataGte("data.payment.amount", 100),
+//	            proofchain.DataLt("data.payment.amount", 500),
+//	            proofchain.DataExists("data.payment.coupon", false),
+//	        },
+//	    },
+//	})
+type DataCondition struct {
+	Path  string
+	Op    SearchOperator
+	Value interface{}
+}
+
+// DataEq matches events whose field at path equals value.
+func DataEq(path string, value interface{}) DataCondition {
+	return DataCondition{Path: path, Op: SearchEq, Value: value}
+}
+
+// DataNe matches events whose field at path does not equal value.
+func DataNe(path string, value interface{}) DataCondition {
+	return DataCondition{Path: path, Op: SearchNe, Value: value}
+}
+
+// DataGt matches events whose field at path is greater than value, a number,
+// time or string.
+func DataGt(path string, value interface{}) DataCondition {
+	return DataCondition{Path: path, Op: SearchGt, Value: value}
+}
+
+// DataGte matches events whose field at path is at least value.
+func DataGte(path string, value interface{}) DataCondition {
+	return DataCondition{Path: path, Op: SearchGte, Value: value}
+}
+
+// DataLt matches events whose field at path is less than value.
+func DataLt(path string, value interface{}) DataCondition {
+	return DataCondition{Path: path, Op: SearchLt, Value: value}
+}
+
+// DataLte matches events whose field at path is at most value.
+func DataLte(path string, value interface{}) DataCondition {
+	return DataCondition{Path: path, Op: SearchLte, Value: value}
+}
+
+// DataIn matches events whose field at path equals one of values.
+func DataIn(path string, values ...interface{}) DataCondition {
+	return DataCondition{Path: path, Op: SearchIn, Value: values}
+}
+
+// DataContains matches events whose string field at path contains substr,
+// or whose array field at path contains substr as an element.
+func DataContains(path string, substr string) DataCondition {
+	return DataCondition{Path: path, Op: SearchContains, Value: substr}
+}
+
+// DataExists matches events where the field at path is present (exists is
+// true) or absent (exists is false).
+func DataExists(path string, exists bool) DataCondition {
+	return DataCondition{Path: path, Op: SearchExists, Value: exists}
+}
+
+// validate checks the condition, returning nil if it is valid.
+func (c DataCondition) validate() *ValidationErrorDetail {
+	path := dataFilterPath(c.Path)
+	if !dataPathPattern.MatchString(path) {
+		return &ValidationErrorDetail{Field: "data_filters", Message: fmt.Sprintf("invalid field path %q", c.Path)}
+	}
+	field := "data_filters." + path
+	switch c.Op {
+	case SearchEq, SearchNe:
+	case SearchGt, SearchGte, SearchLt, SearchLte:
+		if !isOrderable(c.Value) {
+			return &ValidationErrorDetail{Field: field, Message: string(c.Op) + " requires a number, time or string value"}
+		}
+	case SearchContains:
+		if _, ok := c.Value.(string); !ok {
+			return &ValidationErrorDetail{Field: field, Message: "contains requires a string value"}
+		}
+	case SearchIn:
+		if !isSlice(c.Value) {
+			return &ValidationErrorDetail{Field: field, Message: "in requires a slice value"}
+		}
+	case SearchExists:
+		if _, ok := c.Value.(bool); !ok {
+			return &ValidationErrorDetail{Field: field, Message: "exists requires a bool value"}
+		}
+	default:
+		return &ValidationErrorDetail{Field: field, Message: fmt.Sprintf("unknown operator %q", c.Op)}
+	}
+	return nil
+}
+
+// wireValue returns the condition's value as sent to the API. Times are
+// sent as RFC 3339 strings.
+func (c DataCondition) wireValue() interface{} {
+	switch v := c.Value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return c.Value
+}
+
+// dataFilterPath strips the optional "data." prefix from a field path. Data
+// filter keys are dotted paths relative to the event data.
+func dataFilterPath(path string) string {
+	return strings.TrimPrefix(path, "data.")
+}
+
+// dataFiltersPayload merges filters.DataFilters and filters.Data into the
+// data_filters wire format: a map from field path to a map of operator to
+// value. Conditions on the same path are combined.
+func dataFiltersPayload(filters *SearchFilters) (map[string]interface{}, error) {
+	if len(filters.Data) == 0 {
+		return filters.DataFilters, nil
+	}
+
+	var details []ValidationErrorDetail
+	merged := make(map[string]interface{}, len(filters.DataFilters)+len(filters.Data))
+	for field, conds := range filters.DataFilters {
+		if m, ok := conds.(map[string]interface{}); ok {
+			conds = copyMap(m)
+		}
+		merged[field] = conds
+	}
+	for _, c := range filters.Data {
+		if detail := c.validate(); detail != nil {
+			details = append(details, *detail)
+			continue
+		}
+		path := dataFilterPath(c.Path)
+		conds, ok := merged[path].(map[string]interface{})
+		if !ok {
+			// A bare value in DataFilters is an equality match.
+			conds = make(map[string]interface{})
+			if v, exists := merged[path]; exists {
+				conds[string(SearchEq)] = v
+			}
+			merged[path] = conds
+		}
+		conds[string(c.Op)] = c.wireValue()
+	}
+	if len(details) > 0 {
+		return nil, NewValidationError("invalid data filters", details)
+	}
+	return merged, nil
+}
+
+func isOrderable(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, json.Number, string, time.Time, *time.Time:
+		return true
+	}
+	return false
+}
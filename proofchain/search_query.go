@@ -0,0 +1,183 @@
+package proofchain
+
+import (
+	"time"
+)
+
+// SearchOperator is a comparison operator for data filters.
+type SearchOperator string
+
+const (
+	SearchEq       SearchOperator = "eq"
+	SearchNe       SearchOperator = "ne"
+	SearchGt       SearchOperator = "gt"
+	SearchGte      SearchOperator = "gte"
+	SearchLt       SearchOperator = "lt"
+	SearchLte      SearchOperator = "lte"
+	SearchIn       SearchOperator = "in"
+	SearchContains SearchOperator = "contains"
+	SearchExists   SearchOperator = "exists"
+)
+
+// SearchQueryBuilder builds a SearchQueryRequest fluently. Errors are
+// collected and reported by Build.
+//
+// Example:
+//
+//	req, err := proofchain.NewSearchQuery().
+//		EventType("purchase").
+//		Where(proofchain.DataGt("data.payment.amount", 100)).
+//		From(time.Now().AddDate(0, -1, 0)).
+//		Build()
+//	results, err := client.Search.Query(ctx, req)
+type SearchQueryBuilder struct {
+	filters     SearchFilters
+	offset      int
+	limit       int
+	includeData bool
+	details     []ValidationErrorDetail
+}
+
+// NewSearchQuery starts a search query.
+func NewSearchQuery() *SearchQueryBuilder {
+	return &SearchQueryBuilder{}
+}
+
+// Text sets the free-text query.
+func (b *SearchQueryBuilder) Text(query string) *SearchQueryBuilder {
+	b.filters.Query = query
+	return b
+}
+
+// EventType restricts results to the given event types.
+func (b *SearchQueryBuilder) EventType(eventTypes ...string) *SearchQueryBuilder {
+	b.filters.EventTypes = append(b.filters.EventTypes, eventTypes...)
+	return b
+}
+
+// EventSource restricts results to the given event sources.
+func (b *SearchQueryBuilder) EventSource(sources ...string) *SearchQueryBuilder {
+	b.filters.EventSources = append(b.filters.EventSources, sources...)
+	return b
+}
+
+// User restricts results to the given users.
+func (b *SearchQueryBuilder) User(userIDs ...string) *SearchQueryBuilder {
+	b.filters.UserIDs = append(b.filters.UserIDs, userIDs...)
+	return b
+}
+
+// Certificate restricts results to the given certificate IDs.
+func (b *SearchQueryBuilder) Certificate(certificateIDs ...string) *SearchQueryBuilder {
+	b.filters.CertificateIDs = append(b.filters.CertificateIDs, certificateIDs...)
+	return b
+}
+
+// Status restricts results to events with the given status.
+func (b *SearchQueryBuilder) Status(status string) *SearchQueryBuilder {
+	b.filters.Status = status
+	return b
+}
+
+// HasDocument restricts results to events with (or without) a document.
+func (b *SearchQueryBuilder) HasDocument(has bool) *SearchQueryBuilder {
+	b.filters.HasDocument = &has
+	return b
+}
+
+// HasBlockchainProof restricts results to events with (or without) an
+// on-chain proof.
+func (b *SearchQueryBuilder) HasBlockchainProof(has bool) *SearchQueryBuilder {
+	b.filters.HasBlockchainProof = &has
+	return b
+}
+
+// IncludeArchived includes archived events in results.
+func (b *SearchQueryBuilder) IncludeArchived() *SearchQueryBuilder {
+	b.filters.IncludeArchived = true
+	return b
+}
+
+// DataFilter adds a condition on an event data field. field may be a nested
+// path such as "data.payment.amount". Several conditions on the same field
+// are combined, e.g. Gte 10 and Lt 100 for a range. SearchIn takes a slice
+// and SearchExists a bool.
+func (b *SearchQueryBuilder) DataFilter(field string, op SearchOperator, value interface{}) *SearchQueryBuilder {
+	return b.Where(DataCondition{Path: field, Op: op, Value: value})
+}
+
+// Where adds typed data filter conditions; see DataCondition.
+func (b *SearchQueryBuilder) Where(conds ...DataCondition) *SearchQueryBuilder {
+	for _, c := range conds {
+		if detail := c.validate(); detail != nil {
+			b.details = append(b.details, *detail)
+			continue
+		}
+		b.filters.Data = append(b.filters.Data, c)
+	}
+	return b
+}
+
+// From restricts results to events at or after t.
+func (b *SearchQueryBuilder) From(t time.Time) *SearchQueryBuilder {
+	b.filters.FromDate = &Timestamp{Time: t}
+	return b
+}
+
+// To restricts results to events at or before t.
+func (b *SearchQueryBuilder) To(t time.Time) *SearchQueryBuilder {
+	b.filters.ToDate = &Timestamp{Time: t}
+	return b
+}
+
+// Limit sets the page size.
+func (b *SearchQueryBuilder) Limit(n int) *SearchQueryBuilder {
+	b.limit = n
+	return b
+}
+
+// Offset sets the page offset.
+func (b *SearchQueryBuilder) Offset(n int) *SearchQueryBuilder {
+	b.offset = n
+	return b
+}
+
+// IncludeData includes event data in results.
+func (b *SearchQueryBuilder) IncludeData() *SearchQueryBuilder {
+	b.includeData = true
+	return b
+}
+
+// Build validates and returns the request.
+func (b *SearchQueryBuilder) Build() (*SearchQueryRequest, error) {
+	details := append([]ValidationErrorDetail(nil), b.details...)
+	if b.filters.FromDate != nil && b.filters.ToDate != nil && b.filters.FromDate.After(b.filters.ToDate.Time) {
+		details = append(details, ValidationErrorDetail{Field: "from_date", Message: "must not be after to_date"})
+	}
+	if b.limit < 0 {
+		details = append(details, ValidationErrorDetail{Field: "limit", Message: "must not be negative"})
+	}
+	if b.offset < 0 {
+		details = append(details, ValidationErrorDetail{Field: "offset", Message: "must not be negative"})
+	}
+	if len(details) > 0 {
+		return nil, NewValidationError("invalid search query", details)
+	}
+
+	filters := b.filters
+	filters.Data = append([]DataCondition(nil), b.filters.Data...)
+	return &SearchQueryRequest{
+		Filters:     &filters,
+		Offset:      b.offset,
+		Limit:       b.limit,
+		IncludeData: b.includeData,
+	}, nil
+}
+
+func isSlice(v interface{}) bool {
+	switch v.(type) {
+	case []interface{}, []string, []int, []int64, []float64, []bool:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package proofchain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// searchScrollPageSize is the page size used when the request sets no limit.
+var searchScrollPageSize = 500
+
+type searchScrollResponse struct {
+	Results  []SearchEventResult `json:"results"`
+	Total    int                 `json:"total"`
+	ScrollID string              `json:"scroll_id"`
+}
+
+// SearchScroller iterates over all results of a search, one page at a time.
+// It uses a server-side scroll cursor when available and falls back to
+// offset paging otherwise. It is not safe for concurrent use.
+//
+// Example:
+//
+//	scroller, err := client.Search.Scroll(ctx, req)
+//	if err != nil {
+//		return err
+//	}
+//	defer scroller.Close(ctx)
+//	for scroller.Next(ctx) {
+//		process(scroller.Result())
+//	}
+//	if err := scroller.Err(); err != nil {
+//		return err
+//	}
+type SearchScroller struct {
+	search   *SearchResource
+	req      SearchQueryRequest
+	scrollID string
+	paging   bool // offset-paging fallback
+	total    int
+
+	page    []SearchEventResult
+	pos     int
+	current *SearchEventResult
+	done    bool
+	err     error
+}
+
+// Scroll starts iterating over every result matching req. req.Limit is the
+// page size; req.Offset is ignored.
+func (r *SearchResource) Scroll(ctx context.Context, req *SearchQueryRequest) (*SearchScroller, error) {
+	s := &SearchScroller{search: r, req: *req}
+	s.req.Offset = 0
+	if s.req.Limit <= 0 {
+		s.req.Limit = searchScrollPageSize
+	}
+
+	payload, err := searchPayload(&s.req)
+	if err != nil {
+		return nil, err
+	}
+	delete(payload, "offset")
+	var resp searchScrollResponse
+	err = r.http.Post(ctx, "/search/scroll", payload, &resp)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		// Scroll cursors are not supported by this deployment.
+		s.paging = true
+		if err := s.fetch(ctx); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.setPage(&resp)
+	return s, nil
+}
+
+// Next advances to the next result, fetching another page when needed. It
+// returns false when the results are exhausted or an error occurs.
+func (s *SearchScroller) Next(ctx context.Context) bool {
+	if s.err != nil {
+		return false
+	}
+	if s.pos >= len(s.page) {
+		if s.done {
+			return false
+		}
+		if s.err = s.fetch(ctx); s.err != nil || len(s.page) == 0 {
+			return false
+		}
+	}
+	s.current = &s.page[s.pos]
+	s.pos++
+	return true
+}
+
+// Result returns the current result.
+func (s *SearchScroller) Result() *SearchEventResult {
+	return s.current
+}
+
+// Total returns the total number of matching results reported by the server.
+func (s *SearchScroller) Total() int {
+	return s.total
+}
+
+// Err returns the error that stopped iteration, if any.
+func (s *SearchScroller) Err() error {
+	return s.err
+}
+
+// Close releases the server-side cursor. It is safe to call more than once.
+func (s *SearchScroller) Close(ctx context.Context) error {
+	s.done = true
+	s.page = nil
+	if s.paging || s.scrollID == "" {
+		return nil
+	}
+	id := s.scrollID
+	s.scrollID = ""
+	return s.search.http.Delete(ctx, "/search/scroll/"+url.PathEscape(id))
+}
+
+func (s *SearchScroller) fetch(ctx context.Context) error {
+	if s.paging {
+		resp, err := s.search.Query(ctx, &s.req)
+		if err != nil {
+			return err
+		}
+		s.total = resp.Total
+		s.page, s.pos = resp.Results, 0
+		s.req.Offset += len(resp.Results)
+		// The server may return short pages before the end, so only an empty
+		// page or reaching a reported total ends the search
+		s.done = len(resp.Results) == 0 || resp.Total > 0 && s.req.Offset >= resp.Total
+		return nil
+	}
+
+	var resp searchScrollResponse
+	err := s.search.http.Post(ctx, "/search/scroll/next", map[string]interface{}{
+		"scroll_id": s.scrollID,
+	}, &resp)
+	if err != nil {
+		return err
+	}
+	s.setPage(&resp)
+	return nil
+}
+
+func (s *SearchScroller) setPage(resp *searchScrollResponse) {
+	s.total = resp.Total
+	s.page, s.pos = resp.Results, 0
+	if resp.ScrollID != "" {
+		s.scrollID = resp.ScrollID
+	}
+	s.done = resp.ScrollID == "" || len(resp.Results) == 0
+}
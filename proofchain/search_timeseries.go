@@ -0,0 +1,134 @@
+package proofchain
+
+import (
+	"context"
+	"time"
+)
+
+// Time series bucket intervals.
+const (
+	IntervalHour  = "hour"
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+var timeSeriesIntervals = map[string]bool{
+	IntervalHour:  true,
+	IntervalDay:   true,
+	IntervalWeek:  true,
+	IntervalMonth: true,
+}
+
+var timeSeriesGroupBy = map[string]bool{
+	"":             true,
+	"event_type":   true,
+	"event_source": true,
+	"status":       true,
+}
+
+// TimeSeriesRequest asks for event counts bucketed over time.
+type TimeSeriesRequest struct {
+	// Interval is the bucket width: "hour", "day" (default), "week" or
+	// "month".
+	Interval string
+	// GroupBy splits each bucket's count by "event_type", "event_source" or
+	// "status". Empty counts all events together.
+	GroupBy string
+	// From and To bound the series; To is exclusive.
+	From time.Time
+	To   time.Time
+	// Timezone is the IANA zone whose midnight starts day, week and month
+	// buckets (default "UTC").
+	Timezone string
+	// Filters restricts the events counted. Its FromDate and ToDate are
+	// ignored in favour of From and To.
+	Filters *SearchFilters
+}
+
+// TimeSeriesBucket is the event count for one interval.
+type TimeSeriesBucket struct {
+	Start Timestamp `json:"start"`
+	Count int       `json:"count"`
+	// Groups holds the count per GroupBy value; it is empty without GroupBy.
+	Groups map[string]int `json:"groups,omitempty"`
+}
+
+// TimeSeriesResponse is a series of event counts. Buckets are in time order
+// and include intervals with no events.
+type TimeSeriesResponse struct {
+	Interval string             `json:"interval"`
+	GroupBy  string             `json:"group_by,omitempty"`
+	Total    int                `json:"total"`
+	Buckets  []TimeSeriesBucket `json:"buckets"`
+}
+
+// TimeSeries returns event counts bucketed by interval, optionally grouped,
+// for charting attestation volume without exporting events.
+//
+// Example:
+//
+//	series, err := client.Search.TimeSeries(ctx, &proofchain.TimeSeriesRequest{
+//		Interval: proofchain.IntervalDay,
+//		GroupBy:  "event_type",
+//		From:     time.Now().AddDate(0, 0, -30),
+//		To:       time.Now(),
+//	})
+func (r *SearchResource) TimeSeries(ctx context.Context, req *TimeSeriesRequest) (*TimeSeriesResponse, error) {
+	interval := req.Interval
+	if interval == "" {
+		interval = IntervalDay
+	}
+
+	var details []ValidationErrorDetail
+	if !timeSeriesIntervals[interval] {
+		details = append(details, ValidationErrorDetail{Field: "interval", Message: `must be "hour", "day", "week" or "month"`})
+	}
+	if !timeSeriesGroupBy[req.GroupBy] {
+		details = append(details, ValidationErrorDetail{Field: "group_by", Message: `must be "event_type", "event_source" or "status"`})
+	}
+	if req.From.IsZero() || req.To.IsZero() {
+		details = append(details, ValidationErrorDetail{Field: "from", Message: "from and to are required"})
+	} else if !req.From.Before(req.To) {
+		details = append(details, ValidationErrorDetail{Field: "to", Message: "must be after from"})
+	}
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			details = append(details, ValidationErrorDetail{Field: "timezone", Message: "unknown time zone " + req.Timezone})
+		}
+	}
+	if len(details) > 0 {
+		return nil, NewValidationError("invalid time series request", details)
+	}
+
+	payload := map[string]interface{}{
+		"interval": interval,
+		"from":     req.From.UTC().Format(time.RFC3339),
+		"to":       req.To.UTC().Format(time.RFC3339),
+	}
+	if req.GroupBy != "" {
+		payload["group_by"] = req.GroupBy
+	}
+	if req.Timezone != "" {
+		payload["timezone"] = req.Timezone
+	}
+	if req.Filters != nil {
+		filters := *req.Filters
+		filters.FromDate = nil
+		filters.ToDate = nil
+		query, err := searchPayload(&SearchQueryRequest{Filters: &filters})
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := query["filters"]; ok {
+			payload["filters"] = f
+		}
+	}
+
+	var result TimeSeriesResponse
+	err := r.http.Post(ctx, "/search/timeseries", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
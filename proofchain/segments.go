@@ -0,0 +1,241 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// =============================================================================
+// Types
+// =============================================================================
+
+// SegmentType distinguishes manually managed segments from rule-based ones.
+type SegmentType string
+
+const (
+	SegmentTypeStatic  SegmentType = "static"
+	SegmentTypeDynamic SegmentType = "dynamic"
+)
+
+// Segment rule types.
+const (
+	SegmentRuleEventCount = "event_count"
+	SegmentRuleAttribute  = "attribute"
+	SegmentRuleRecency    = "recency"
+)
+
+// SegmentRule is a single membership rule for a dynamic segment. Build rules
+// with SegmentRuleOnEventCount, SegmentRuleOnAttribute or SegmentRuleOnRecency.
+type SegmentRule struct {
+	Type      string      `json:"type"`
+	EventType string      `json:"event_type,omitempty"`
+	Attribute string      `json:"attribute,omitempty"`
+	Operator  string      `json:"operator,omitempty"` // "eq", "neq", "gt", "gte", "lt", "lte", "in", "contains"
+	Value     interface{} `json:"value,omitempty"`
+	Days      int         `json:"days,omitempty"`
+}
+
+// SegmentRuleOnEventCount matches users with at least min events of eventType
+// in the last days (0 = all time).
+func SegmentRuleOnEventCount(eventType string, min, days int) SegmentRule {
+	return SegmentRule{Type: SegmentRuleEventCount, EventType: eventType, Operator: "gte", Value: min, Days: days}
+}
+
+// SegmentRuleOnAttribute matches users whose attribute compares to value.
+func SegmentRuleOnAttribute(attribute, operator string, value interface{}) SegmentRule {
+	return SegmentRule{Type: SegmentRuleAttribute, Attribute: attribute, Operator: operator, Value: value}
+}
+
+// SegmentRuleOnRecency matches users active within the last days.
+func SegmentRuleOnRecency(days int) SegmentRule {
+	return SegmentRule{Type: SegmentRuleRecency, Days: days}
+}
+
+// Segment is a named group of end-users.
+type Segment struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description *string       `json:"description,omitempty"`
+	Type        SegmentType   `json:"type"`
+	Match       string        `json:"match,omitempty"` // "all" or "any"
+	Rules       []SegmentRule `json:"rules,omitempty"`
+	MemberCount int           `json:"member_count"`
+	ComputedAt  *string       `json:"computed_at,omitempty"`
+	CreatedAt   string        `json:"created_at"`
+	UpdatedAt   string        `json:"updated_at"`
+}
+
+// =============================================================================
+// Request types
+// =============================================================================
+
+// CreateSegmentRequest creates a segment. Rules are required for dynamic
+// segments and ignored for static ones.
+type CreateSegmentRequest struct {
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Type        SegmentType   `json:"type"`
+	Match       string        `json:"match,omitempty"`
+	Rules       []SegmentRule `json:"rules,omitempty"`
+}
+
+// UpdateSegmentRequest updates a segment.
+type UpdateSegmentRequest struct {
+	Name        *string       `json:"name,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Match       *string       `json:"match,omitempty"`
+	Rules       []SegmentRule `json:"rules,omitempty"`
+}
+
+// =============================================================================
+// Options
+// =============================================================================
+
+// ListSegmentsOptions configures the List query.
+type ListSegmentsOptions struct {
+	Type   SegmentType
+	Limit  int
+	Offset int
+}
+
+// ListSegmentMembersOptions configures the ListMembers query.
+type ListSegmentMembersOptions struct {
+	Page     int
+	PageSize int
+}
+
+// =============================================================================
+// Client
+// =============================================================================
+
+// SegmentsClient manages end-user segments.
+type SegmentsClient struct {
+	http *HTTPClient
+}
+
+// NewSegmentsClient creates a new segments client.
+func NewSegmentsClient(http *HTTPClient) *SegmentsClient {
+	return &SegmentsClient{http: http}
+}
+
+// List returns segments.
+func (c *SegmentsClient) List(ctx context.Context, opts *ListSegmentsOptions) ([]Segment, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Type != "" {
+			params.Set("type", string(opts.Type))
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		if opts.Offset > 0 {
+			params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+		}
+	}
+
+	var segments []Segment
+	err := c.http.Get(ctx, "/segments", params, &segments)
+	if err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+// Get returns a segment by ID.
+func (c *SegmentsClient) Get(ctx context.Context, segmentID string) (*Segment, error) {
+	var segment Segment
+	err := c.http.Get(ctx, "/segments/"+url.PathEscape(segmentID), nil, &segment)
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// Create creates a segment. Type defaults to SegmentTypeStatic; req itself
+// is not modified.
+func (c *SegmentsClient) Create(ctx context.Context, req *CreateSegmentRequest) (*Segment, error) {
+	body := *req
+	if body.Type == "" {
+		body.Type = SegmentTypeStatic
+	}
+	if body.Type == SegmentTypeDynamic && len(body.Rules) == 0 {
+		return nil, NewValidationError("dynamic segments require at least one rule", []ValidationErrorDetail{
+			{Field: "rules", Message: "at least one rule is required"},
+		})
+	}
+
+	var segment Segment
+	err := c.http.Post(ctx, "/segments", &body, &segment)
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// Update updates a segment.
+func (c *SegmentsClient) Update(ctx context.Context, segmentID string, req *UpdateSegmentRequest) (*Segment, error) {
+	var segment Segment
+	err := c.http.Patch(ctx, "/segments/"+url.PathEscape(segmentID), req, &segment)
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// Delete deletes a segment. Users keep their other segments.
+func (c *SegmentsClient) Delete(ctx context.Context, segmentID string) error {
+	return c.http.Delete(ctx, "/segments/"+url.PathEscape(segmentID))
+}
+
+// ListMembers returns the users in a segment.
+func (c *SegmentsClient) ListMembers(ctx context.Context, segmentID string, opts *ListSegmentMembersOptions) (*EndUserListResponse, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Page > 0 {
+			params.Set("page", fmt.Sprintf("%d", opts.Page))
+		}
+		if opts.PageSize > 0 {
+			params.Set("page_size", fmt.Sprintf("%d", opts.PageSize))
+		}
+	}
+
+	var result EndUserListResponse
+	err := c.http.Get(ctx, "/segments/"+url.PathEscape(segmentID)+"/members", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AddMembers adds users (by external ID) to a static segment.
+func (c *SegmentsClient) AddMembers(ctx context.Context, segmentID string, userIDs []string) (*Segment, error) {
+	var segment Segment
+	err := c.http.Post(ctx, "/segments/"+url.PathEscape(segmentID)+"/members", map[string]interface{}{"user_ids": userIDs}, &segment)
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// RemoveMembers removes users (by external ID) from a static segment.
+func (c *SegmentsClient) RemoveMembers(ctx context.Context, segmentID string, userIDs []string) (*Segment, error) {
+	var segment Segment
+	err := c.http.Post(ctx, "/segments/"+url.PathEscape(segmentID)+"/members/remove", map[string]interface{}{"user_ids": userIDs}, &segment)
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// Recompute re-evaluates a dynamic segment's rules immediately.
+func (c *SegmentsClient) Recompute(ctx context.Context, segmentID string) (*Segment, error) {
+	var segment Segment
+	err := c.http.Post(ctx, "/segments/"+url.PathEscape(segmentID)+"/recompute", nil, &segment)
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
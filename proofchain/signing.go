@@ -0,0 +1,135 @@
+package proofchain
+
+import (
+	"context"
+)
+
+// Signature schemes.
+const (
+	SignatureSchemeEIP191 = "eip191" // personal_sign
+	SignatureSchemeEIP712 = "eip712" // typed structured data
+)
+
+// TypedDataField is a member of an EIP-712 struct type
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is an EIP-712 typed data payload, in the same shape as
+// eth_signTypedData_v4
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// MessageSignature is a signature produced by a managed wallet
+type MessageSignature struct {
+	Signature string `json:"signature"` // 0x-prefixed hex
+	Address   string `json:"address"`
+	Network   string `json:"network"`
+	Scheme    string `json:"scheme"`
+}
+
+// VerifySignatureRequest is the request to verify a signature. Set exactly
+// one of Message and TypedData.
+type VerifySignatureRequest struct {
+	Address   string     `json:"address"`
+	Signature string     `json:"signature"`
+	Message   string     `json:"message,omitempty"`
+	TypedData *TypedData `json:"typed_data,omitempty"`
+	Network   string     `json:"network,omitempty"`
+}
+
+// SignatureVerification is the result of VerifySignature
+type SignatureVerification struct {
+	Valid            bool   `json:"valid"`
+	RecoveredAddress string `json:"recovered_address,omitempty"`
+	// SmartAccount is set when the address is a contract account and the
+	// signature was checked with EIP-1271 instead of ecrecover.
+	SmartAccount bool `json:"smart_account"`
+}
+
+// SignMessage signs message with a managed wallet using EIP-191
+// personal_sign, for example to sign in to a third-party dApp or to produce
+// the signature for Users.LinkWallet.
+//
+// Example:
+//
+//	sig, err := client.Wallets.SignMessage(ctx, walletID, challenge)
+//	user, err := client.Users.LinkWallet(ctx, externalID, &proofchain.LinkWalletRequest{
+//	    WalletAddress: sig.Address,
+//	    Signature:     &sig.Signature,
+//	})
+func (w *WalletClient) SignMessage(ctx context.Context, walletID, message string) (*MessageSignature, error) {
+	if message == "" {
+		return nil, NewValidationError("message is required", []ValidationErrorDetail{
+			{Field: "message", Message: "must not be empty"},
+		})
+	}
+	return w.sign(ctx, walletID, map[string]interface{}{
+		"scheme":  SignatureSchemeEIP191,
+		"message": message,
+	})
+}
+
+// SignTypedData signs an EIP-712 typed data payload with a managed wallet
+func (w *WalletClient) SignTypedData(ctx context.Context, walletID string, data *TypedData) (*MessageSignature, error) {
+	if err := validateTypedData(data); err != nil {
+		return nil, err
+	}
+	return w.sign(ctx, walletID, map[string]interface{}{
+		"scheme":     SignatureSchemeEIP712,
+		"typed_data": data,
+	})
+}
+
+func (w *WalletClient) sign(ctx context.Context, walletID string, body map[string]interface{}) (*MessageSignature, error) {
+	var sig MessageSignature
+	err := w.http.Post(ctx, "/wallets/"+walletID+"/sign", body, &sig)
+	if err != nil {
+		return nil, err
+	}
+	return &sig, nil
+}
+
+// VerifySignature checks that a message or typed data payload was signed by
+// an address. Smart account signatures are verified with EIP-1271.
+func (w *WalletClient) VerifySignature(ctx context.Context, req *VerifySignatureRequest) (*SignatureVerification, error) {
+	if err := validateAddress(req.Network, req.Address); err != nil {
+		return nil, err
+	}
+	if (req.Message == "") == (req.TypedData == nil) {
+		return nil, NewValidationError("exactly one of message and typed data is required", []ValidationErrorDetail{
+			{Field: "message", Message: "set either message or typed_data"},
+		})
+	}
+	if req.TypedData != nil {
+		if err := validateTypedData(req.TypedData); err != nil {
+			return nil, err
+		}
+	}
+
+	var result SignatureVerification
+	err := w.http.Post(ctx, "/wallets/verify-signature", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func validateTypedData(data *TypedData) error {
+	if data == nil || data.PrimaryType == "" {
+		return NewValidationError("typed data requires a primary type", []ValidationErrorDetail{
+			{Field: "typed_data.primaryType", Message: "must not be empty"},
+		})
+	}
+	if _, ok := data.Types[data.PrimaryType]; !ok {
+		return NewValidationError("typed data primary type is not defined", []ValidationErrorDetail{
+			{Field: "typed_data.types", Message: "must define " + data.PrimaryType},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SmartAccountStatus describes the on-chain state of a smart account wallet
+type SmartAccountStatus struct {
+	WalletID         string  `json:"wallet_id"`
+	Address          string  `json:"address"`
+	Network          string  `json:"network"`
+	IsDeployed       bool    `json:"is_deployed"`
+	DeploymentStatus string  `json:"deployment_status"` // "not_deployed", "pending", "deployed" or "failed"
+	DeploymentTxHash *string `json:"deployment_tx_hash,omitempty"`
+	DeployedAt       *string `json:"deployed_at,omitempty"`
+	EntryPoint       string  `json:"entry_point"`
+	Factory          *string `json:"factory,omitempty"`
+	Nonce            string  `json:"nonce"` // decimal string, may exceed 64 bits
+	// Paymaster is the paymaster sponsoring this account's user operations.
+	Paymaster           *string `json:"paymaster,omitempty"`
+	Sponsored           bool    `json:"sponsored"`
+	SponsorshipPolicyID *string `json:"sponsorship_policy_id,omitempty"`
+	PendingUserOps      int     `json:"pending_user_ops"`
+	Error               *string `json:"error,omitempty"`
+}
+
+// SmartAccountDeployment is the result of requesting a smart account deployment
+type SmartAccountDeployment struct {
+	WalletID   string  `json:"wallet_id"`
+	Status     string  `json:"status"`
+	UserOpHash *string `json:"user_op_hash,omitempty"`
+	TxHash     *string `json:"tx_hash,omitempty"`
+}
+
+// smartAccountPollInterval is how often WaitForSmartAccountDeployment checks status.
+var smartAccountPollInterval = 3 * time.Second
+
+// DeploySmartAccount deploys a counterfactual smart account on-chain.
+// Deploying an already deployed account is a no-op.
+func (w *WalletClient) DeploySmartAccount(ctx context.Context, walletID string) (*SmartAccountDeployment, error) {
+	var result SmartAccountDeployment
+	err := w.http.Post(ctx, "/wallets/"+walletID+"/smart-account/deploy", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSmartAccountStatus returns deployment, entry point, nonce and
+// sponsorship state for a smart account wallet
+func (w *WalletClient) GetSmartAccountStatus(ctx context.Context, walletID string) (*SmartAccountStatus, error) {
+	var result SmartAccountStatus
+	err := w.http.Get(ctx, "/wallets/"+walletID+"/smart-account", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WaitForSmartAccountDeployment polls until the smart account is deployed or
+// deployment fails. Use a context with a deadline to bound the wait.
+func (w *WalletClient) WaitForSmartAccountDeployment(ctx context.Context, walletID string) (*SmartAccountStatus, error) {
+	for {
+		status, err := w.GetSmartAccountStatus(ctx, walletID)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.IsDeployed {
+			return status, nil
+		}
+		if status.DeploymentStatus == "failed" {
+			msg := fmt.Sprintf("smart account %s deployment failed", walletID)
+			if status.Error != nil {
+				msg += ": " + *status.Error
+			}
+			return status, &APIError{Message: msg}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, NewTimeoutError()
+		case <-time.After(smartAccountPollInterval):
+		}
+	}
+}
@@ -4,6 +4,7 @@ package proofchain
 import (
 	"context"
 	"net/url"
+	"time"
 )
 
 // APIKey represents an API key for the tenant.
@@ -91,6 +92,69 @@ func (r *TenantResource) DeleteAPIKey(ctx context.Context, keyID string) error {
 	return r.http.Delete(ctx, "/tenant/api-keys/"+keyID)
 }
 
+// APIKeyRotation is the result of rotating an API key.
+type APIKeyRotation struct {
+	// NewKey is the replacement key; NewKey.Key holds the secret.
+	NewKey *APIKey
+	// OldKey is the key being retired.
+	OldKey *APIKey
+	// OldKeyExpiresAt is when the old key stops working.
+	OldKeyExpiresAt time.Time
+}
+
+// RotateAPIKey creates a replacement for keyID with the same name and
+// permissions and schedules the old key to expire after overlap, so callers
+// can roll the new key out (e.g. with Client.SetAPIKey) before the old one is
+// revoked.
+//
+// Example:
+//
+//	rotation, err := client.Tenant.RotateAPIKey(ctx, keyID, time.Hour)
+//	if err != nil {
+//		return err
+//	}
+//	client.SetAPIKey(rotation.NewKey.Key)
+func (r *TenantResource) RotateAPIKey(ctx context.Context, keyID string, overlap time.Duration) (*APIKeyRotation, error) {
+	keys, err := r.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var old *APIKey
+	for i := range keys {
+		if keys[i].ID == keyID {
+			old = &keys[i]
+			break
+		}
+	}
+	if old == nil {
+		return nil, NewNotFoundError("API key not found: " + keyID)
+	}
+
+	newKey, err := r.CreateAPIKey(ctx, &CreateAPIKeyRequest{
+		Name:        old.Name,
+		Permissions: old.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(overlap).UTC()
+	var updated APIKey
+	err = r.http.Patch(ctx, "/tenant/api-keys/"+keyID, map[string]interface{}{
+		"expires_at": expiresAt.Format(time.RFC3339),
+	}, &updated)
+	if err != nil {
+		return &APIKeyRotation{NewKey: newKey, OldKey: old}, err
+	}
+
+	return &APIKeyRotation{
+		NewKey:          newKey,
+		OldKey:          &updated,
+		OldKeyExpiresAt: expiresAt,
+	}, nil
+}
+
 // UsageDetailed gets detailed usage statistics.
 func (r *TenantResource) UsageDetailed(ctx context.Context, fromDate, toDate string) (map[string]interface{}, error) {
 	params := url.Values{}
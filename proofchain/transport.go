@@ -0,0 +1,166 @@
+package proofchain
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// transportConfig holds the network settings shared by the REST and gRPC
+// clients: a custom TLS configuration, an optional client certificate for
+// mTLS, and an optional egress proxy. Configuration errors are kept and
+// surfaced on the first request, since options cannot return errors.
+type transportConfig struct {
+	tlsConfig  *tls.Config
+	clientCert *tls.Certificate
+	proxyURL   *url.URL
+	err        error
+}
+
+func (t *transportConfig) configured() bool {
+	return t.tlsConfig != nil || t.clientCert != nil || t.proxyURL != nil || t.err != nil
+}
+
+func (t *transportConfig) setProxy(rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.err = fmt.Errorf("invalid proxy URL: %w", err)
+		return
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		t.err = fmt.Errorf("invalid proxy URL %q: want http:// or https:// with a host", u.Redacted())
+		return
+	}
+	t.proxyURL = u
+}
+
+func (t *transportConfig) setClientCertificate(certFile, keyFile string) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.err = fmt.Errorf("failed to load client certificate: %w", err)
+		return
+	}
+	t.clientCert = &cert
+}
+
+// tls returns the TLS configuration to use, with the client certificate
+// added when one is set. The caller's *tls.Config is never modified.
+func (t *transportConfig) tls() *tls.Config {
+	var cfg *tls.Config
+	if t.tlsConfig != nil {
+		cfg = t.tlsConfig.Clone()
+	} else {
+		cfg = &tls.Config{}
+	}
+	if t.clientCert != nil {
+		cfg.Certificates = append(cfg.Certificates, *t.clientCert)
+	}
+	return cfg
+}
+
+// roundTripper builds an http.Transport from the defaults with the TLS and
+// proxy settings applied.
+func (t *transportConfig) roundTripper() http.RoundTripper {
+	if t.err != nil {
+		return errRoundTripper{t.err}
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = t.tls()
+	if t.proxyURL != nil {
+		tr.Proxy = http.ProxyURL(t.proxyURL)
+	}
+	return tr
+}
+
+// dialProxy opens a tunnel to addr through the configured proxy with HTTP
+// CONNECT, authenticating with the credentials in the proxy URL if any.
+func (t *transportConfig) dialProxy(ctx context.Context, addr string) (net.Conn, error) {
+	proxy := t.proxyURL
+	proxyAddr := proxy.Host
+	if proxy.Port() == "" {
+		if proxy.Scheme == "https" {
+			proxyAddr = net.JoinHostPort(proxy.Hostname(), "443")
+		} else {
+			proxyAddr = net.JoinHostPort(proxy.Hostname(), "80")
+		}
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if proxy.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxy.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if user := proxy.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT to %s failed: %s", addr, resp.Status)
+	}
+
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a net.Conn whose first reads drain bytes the proxy sent
+// after its CONNECT response.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// errRoundTripper fails every request with a configuration error.
+type errRoundTripper struct {
+	err error
+}
+
+func (e errRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, e.err
+}
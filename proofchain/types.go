@@ -81,6 +81,9 @@ type AttestationResult struct {
 	Timestamp       Timestamp       `json:"timestamp"`
 	BlockchainTx    *string         `json:"blockchain_tx,omitempty"`
 	Proof           []string        `json:"proof,omitempty"`
+	VaultFileID     *string         `json:"vault_file_id,omitempty"` // Set when attested from the vault
+	// Extraction is set when the document was attested with ExtractText.
+	Extraction *DocumentExtraction `json:"extraction,omitempty"`
 }
 
 // Event represents an attested event record.
@@ -99,6 +102,14 @@ type Event struct {
 	BlockchainTx    *string                `json:"blockchain_tx,omitempty"`
 	BatchID         *string                `json:"batch_id,omitempty"`
 	ChannelID       *string                `json:"channel_id,omitempty"`
+	// SupersedesID is the event this event amends; SupersededByID is the
+	// amendment that replaces this event, if any.
+	SupersedesID   *string `json:"supersedes_id,omitempty"`
+	SupersededByID *string `json:"superseded_by_id,omitempty"`
+	// ConsistencyToken is set on newly created events; see ContextWithConsistencyToken.
+	ConsistencyToken ConsistencyToken `json:"consistency_token,omitempty"`
+	// ArchivedAt is set on events hidden with EventsResource.Archive.
+	ArchivedAt *Timestamp `json:"archived_at,omitempty"`
 }
 
 // Channel represents a state channel for high-volume streaming.
@@ -132,6 +143,10 @@ type Settlement struct {
 	BlockNumber int64     `json:"block_number"`
 	GasUsed     int64     `json:"gas_used"`
 	SettledAt   Timestamp `json:"settled_at"`
+	// FirstSequence and LastSequence bound the channel events covered by
+	// the settlement. They are set on settlements returned by ListSettlements.
+	FirstSequence *int64 `json:"first_sequence,omitempty"`
+	LastSequence  *int64 `json:"last_sequence,omitempty"`
 }
 
 // Certificate represents an issued certificate.
@@ -153,15 +168,78 @@ type Certificate struct {
 	BlockchainTx     *string                `json:"blockchain_tx,omitempty"`
 }
 
+// CertificateSearchResult is a page of certificates matching a search.
+type CertificateSearchResult struct {
+	Certificates []Certificate `json:"certificates"`
+	Total        int           `json:"total"`
+	Limit        int           `json:"limit"`
+	Offset       int           `json:"offset"`
+}
+
+// CertificateClaimLink is a time-limited URL a recipient uses to claim a
+// certificate into their own wallet or passport.
+type CertificateClaimLink struct {
+	ClaimID       string    `json:"claim_id"`
+	CertificateID string    `json:"certificate_id"`
+	ClaimURL      string    `json:"claim_url"`
+	ExpiresAt     Timestamp `json:"expires_at"`
+}
+
+// CertificateClaimStatus is the state of a certificate claim.
+type CertificateClaimStatus struct {
+	CertificateID string `json:"certificate_id"`
+	// Status is "unclaimed", "pending", "claimed" or "expired".
+	Status        string     `json:"status"`
+	ClaimedAt     *Timestamp `json:"claimed_at,omitempty"`
+	ClaimedBy     *string    `json:"claimed_by,omitempty"` // end-user external ID
+	WalletAddress *string    `json:"wallet_address,omitempty"`
+	PassportID    *string    `json:"passport_id,omitempty"`
+	LinkExpiresAt *Timestamp `json:"link_expires_at,omitempty"`
+	BlockchainTx  *string    `json:"blockchain_tx,omitempty"`
+}
+
 // Webhook represents a registered webhook endpoint.
 type Webhook struct {
-	ID            string     `json:"id"`
-	URL           string     `json:"url"`
-	Events        []string   `json:"events"`
-	Active        bool       `json:"active"`
-	CreatedAt     Timestamp  `json:"created_at"`
-	LastTriggered *Timestamp `json:"last_triggered,omitempty"`
-	FailureCount  int        `json:"failure_count"`
+	ID               string     `json:"id"`
+	URL              string     `json:"url"`
+	Events           []string   `json:"events"`
+	Active           bool       `json:"active"`
+	AttestDeliveries bool       `json:"attest_deliveries"`
+	CreatedAt        Timestamp  `json:"created_at"`
+	LastTriggered    *Timestamp `json:"last_triggered,omitempty"`
+	FailureCount     int        `json:"failure_count"`
+}
+
+// AttestedWebhookDelivery is a webhook delivery whose notification was itself attested.
+type AttestedWebhookDelivery struct {
+	DeliveryID    string      `json:"delivery_id"`
+	WebhookID     string      `json:"webhook_id"`
+	EventType     string      `json:"event_type"`
+	Destination   string      `json:"destination"`
+	PayloadHash   string      `json:"payload_hash"`
+	ResponseCode  int         `json:"response_code"`
+	DeliveredAt   Timestamp   `json:"delivered_at"`
+	EventID       string      `json:"event_id"`
+	CertificateID string      `json:"certificate_id"`
+	IPFSHash      string      `json:"ipfs_hash"`
+	Status        EventStatus `json:"status"`
+	BlockchainTx  *string     `json:"blockchain_tx,omitempty"`
+}
+
+// WebhookDelivery is a single attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID           string                 `json:"id"`
+	WebhookID    string                 `json:"webhook_id"`
+	EventType    string                 `json:"event_type"`
+	Status       string                 `json:"status"` // "succeeded", "failed" or "pending"
+	ResponseCode *int                   `json:"response_code,omitempty"`
+	ResponseBody *string                `json:"response_body,omitempty"`
+	LatencyMs    *int                   `json:"latency_ms,omitempty"`
+	Attempt      int                    `json:"attempt"`
+	Error        *string                `json:"error,omitempty"`
+	Payload      map[string]interface{} `json:"payload"`
+	CreatedAt    Timestamp              `json:"created_at"`
+	DeliveredAt  *Timestamp             `json:"delivered_at,omitempty"`
 }
 
 // VerificationResult is the result of verifying a document or event.
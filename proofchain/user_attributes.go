@@ -0,0 +1,160 @@
+package proofchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// AttributeType is the type of an end-user attribute.
+type AttributeType string
+
+const (
+	AttributeString  AttributeType = "string"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+	AttributeList    AttributeType = "list"
+)
+
+// AttributeDefinition describes one end-user attribute.
+type AttributeDefinition struct {
+	Type        AttributeType `json:"type"`
+	Required    bool          `json:"required,omitempty"`
+	Enum        []string      `json:"enum,omitempty"` // allowed values for string attributes
+	Description string        `json:"description,omitempty"`
+}
+
+// AttributeSchema defines the attributes end-users may carry.
+type AttributeSchema struct {
+	Attributes map[string]AttributeDefinition `json:"attributes"`
+	// Strict rejects attributes that are not in the schema.
+	Strict    bool   `json:"strict,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// Validate checks attributes against the schema. With partial set, as for
+// updates that merge into existing attributes, required attributes may be
+// absent.
+func (s *AttributeSchema) Validate(attributes map[string]interface{}, partial bool) error {
+	var details []ValidationErrorDetail
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		def, ok := s.Attributes[key]
+		if !ok {
+			if s.Strict {
+				details = append(details, ValidationErrorDetail{Field: "attributes." + key, Message: "not defined in attribute schema"})
+			}
+			continue
+		}
+		if msg := def.check(attributes[key]); msg != "" {
+			details = append(details, ValidationErrorDetail{Field: "attributes." + key, Message: msg})
+		}
+	}
+
+	if !partial {
+		for key, def := range s.Attributes {
+			if _, ok := attributes[key]; def.Required && !ok {
+				details = append(details, ValidationErrorDetail{Field: "attributes." + key, Message: "is required"})
+			}
+		}
+	}
+
+	if len(details) > 0 {
+		return NewValidationError("attributes do not match schema", details)
+	}
+	return nil
+}
+
+func (d AttributeDefinition) check(value interface{}) string {
+	if value == nil {
+		if d.Required {
+			return "is required"
+		}
+		return ""
+	}
+	switch d.Type {
+	case AttributeString:
+		s, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if len(d.Enum) > 0 && !containsString(d.Enum, s) {
+			return fmt.Sprintf("must be one of %v", d.Enum)
+		}
+	case AttributeNumber:
+		if _, ok := toFloat(value); !ok {
+			return "must be a number"
+		}
+	case AttributeBoolean:
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean"
+		}
+	case AttributeList:
+		if !isSlice(value) {
+			return "must be a list"
+		}
+	}
+	return ""
+}
+
+// SetAttributeSchema stores the tenant's end-user attribute schema. The
+// server enforces it on every write; this client also validates Create,
+// Update, UpdateByExternalID and UpdateAttributes before sending.
+func (u *EndUsersClient) SetAttributeSchema(ctx context.Context, schema *AttributeSchema) (*AttributeSchema, error) {
+	var result AttributeSchema
+	err := u.http.Put(ctx, "/end-users/attribute-schema", schema, &result)
+	if err != nil {
+		return nil, err
+	}
+	u.schema.Store(&result)
+	return &result, nil
+}
+
+// GetAttributeSchema returns the tenant's end-user attribute schema and
+// enables client-side validation with it.
+func (u *EndUsersClient) GetAttributeSchema(ctx context.Context) (*AttributeSchema, error) {
+	var result AttributeSchema
+	err := u.http.Get(ctx, "/end-users/attribute-schema", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	u.schema.Store(&result)
+	return &result, nil
+}
+
+func (u *EndUsersClient) validateAttributes(attributes map[string]interface{}, partial bool) error {
+	schema := u.schema.Load()
+	if schema == nil || (partial && len(attributes) == 0) {
+		return nil
+	}
+	return schema.Validate(attributes, partial)
+}
+
+// GetStringAttr returns a string attribute.
+func (e *EndUser) GetStringAttr(key string) (string, bool) {
+	v, ok := e.Attributes[key].(string)
+	return v, ok
+}
+
+// GetNumberAttr returns a numeric attribute.
+func (e *EndUser) GetNumberAttr(key string) (float64, bool) {
+	return toFloat(e.Attributes[key])
+}
+
+// GetBoolAttr returns a boolean attribute.
+func (e *EndUser) GetBoolAttr(key string) (bool, bool) {
+	v, ok := e.Attributes[key].(bool)
+	return v, ok
+}
+
+// GetListAttr returns a list attribute.
+func (e *EndUser) GetListAttr(key string) ([]interface{}, bool) {
+	v, ok := e.Attributes[key].([]interface{})
+	return v, ok
+}
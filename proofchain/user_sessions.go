@@ -0,0 +1,86 @@
+package proofchain
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Session token scopes. Each grants read access to one end-user-facing
+// surface for the token's user only.
+const (
+	SessionScopePassportRead    = "passport:read"
+	SessionScopeQuestsRead      = "quests:read"
+	SessionScopeLeaderboardRead = "leaderboard:read"
+)
+
+// MaxSessionTokenTTL is the longest lifetime the platform issues for an
+// end-user session token.
+const MaxSessionTokenTTL = 24 * time.Hour
+
+var sessionScopes = map[string]bool{
+	SessionScopePassportRead:    true,
+	SessionScopeQuestsRead:      true,
+	SessionScopeLeaderboardRead: true,
+}
+
+// SessionToken is a short-lived token scoped to a single end-user. It is
+// safe to hand to a browser or mobile app, which sends it as a bearer token
+// instead of the tenant API key.
+type SessionToken struct {
+	Token      string    `json:"token"`
+	UserID     string    `json:"user_id"`
+	ExternalID string    `json:"external_id"`
+	Scopes     []string  `json:"scopes"`
+	ExpiresAt  Timestamp `json:"expires_at"`
+}
+
+// Expired reports whether the token has expired.
+func (t *SessionToken) Expired() bool {
+	return !t.ExpiresAt.IsZero() && !time.Now().Before(t.ExpiresAt.Time)
+}
+
+// CreateSessionToken mints a session token for an end-user by external ID,
+// so frontends can call passport, quest and leaderboard read endpoints
+// directly. A zero ttl uses the server default; ttl is rounded down to whole
+// seconds and may not exceed MaxSessionTokenTTL.
+//
+//	tok, err := client.Users.CreateSessionToken(ctx, "user-123",
+//	    []string{proofchain.SessionScopePassportRead, proofchain.SessionScopeQuestsRead},
+//	    15*time.Minute)
+func (u *EndUsersClient) CreateSessionToken(ctx context.Context, externalID string, scopes []string, ttl time.Duration) (*SessionToken, error) {
+	var details []ValidationErrorDetail
+	if externalID == "" {
+		details = append(details, ValidationErrorDetail{Field: "external_id", Message: "is required"})
+	}
+	if len(scopes) == 0 {
+		details = append(details, ValidationErrorDetail{Field: "scopes", Message: "at least one scope is required"})
+	}
+	for _, s := range scopes {
+		if !sessionScopes[s] {
+			details = append(details, ValidationErrorDetail{Field: "scopes", Message: "unknown scope " + s})
+		}
+	}
+	if ttl < 0 || ttl > MaxSessionTokenTTL {
+		details = append(details, ValidationErrorDetail{Field: "ttl", Message: "must be between 0 and " + MaxSessionTokenTTL.String()})
+	} else if ttl > 0 && ttl < time.Second {
+		details = append(details, ValidationErrorDetail{Field: "ttl", Message: "must be at least 1s"})
+	}
+	if len(details) > 0 {
+		return nil, NewValidationError("invalid session token request", details)
+	}
+
+	payload := map[string]interface{}{
+		"scopes": scopes,
+	}
+	if ttl > 0 {
+		payload["ttl_seconds"] = int(ttl / time.Second)
+	}
+
+	var result SessionToken
+	err := u.http.Post(ctx, "/end-users/by-external/"+url.PathEscape(externalID)+"/session-token", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
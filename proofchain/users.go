@@ -1,9 +1,16 @@
 package proofchain
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -120,6 +127,32 @@ type GDPRPreviewResponse struct {
 	MerkleWarning *string                `json:"merkle_warning,omitempty"`
 }
 
+// GDPRBulkDeletionItem is the outcome of deleting a single user in a bulk GDPR request.
+type GDPRBulkDeletionItem struct {
+	UserID         string         `json:"user_id"`
+	ExternalID     string         `json:"external_id,omitempty"`
+	Success        bool           `json:"success"`
+	DeletedRecords map[string]int `json:"deleted_records,omitempty"`
+	MerkleWarning  *string        `json:"merkle_warning,omitempty"`
+	AuditID        *string        `json:"audit_id,omitempty"`
+	Error          string         `json:"error,omitempty"`
+	CompletedAt    time.Time      `json:"completed_at"`
+}
+
+// GDPRBulkDeletionReport is a machine-readable compliance report for a bulk
+// GDPR deletion. It marshals to JSON for archiving alongside the erasure request.
+type GDPRBulkDeletionReport struct {
+	Reason         *string                `json:"reason,omitempty"`
+	StartedAt      time.Time              `json:"started_at"`
+	CompletedAt    time.Time              `json:"completed_at"`
+	TotalRequested int                    `json:"total_requested"`
+	TotalDeleted   int                    `json:"total_deleted"`
+	TotalFailed    int                    `json:"total_failed"`
+	MerkleWarnings int                    `json:"merkle_warnings"`
+	DeletedRecords map[string]int         `json:"deleted_records"`
+	Results        []GDPRBulkDeletionItem `json:"results"`
+}
+
 // PointsResult is the response from adding/subtracting points.
 type PointsResult struct {
 	UserID         string `json:"user_id"`
@@ -129,6 +162,38 @@ type PointsResult struct {
 	Reason         string `json:"reason"`
 }
 
+// PointsTransferResult is the response from an atomic points transfer.
+type PointsTransferResult struct {
+	TransferID  string `json:"transfer_id"`
+	FromUserID  string `json:"from_user_id"`
+	ToUserID    string `json:"to_user_id"`
+	Points      int    `json:"points"`
+	FromBalance int    `json:"from_balance"`
+	ToBalance   int    `json:"to_balance"`
+	Reason      string `json:"reason"`
+}
+
+// PointsLedgerEntry is a single points movement.
+type PointsLedgerEntry struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	Delta        int       `json:"delta"` // Positive for credits, negative for debits
+	BalanceAfter int       `json:"balance_after"`
+	Reason       string    `json:"reason"`
+	Source       string    `json:"source"`                 // e.g. "manual", "event", "quest", "transfer"
+	Actor        string    `json:"actor,omitempty"`        // API key, admin or system component that made the change
+	ReferenceID  *string   `json:"reference_id,omitempty"` // Related event, quest or transfer ID
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// PointsLedger is a page of points ledger entries, newest first.
+type PointsLedger struct {
+	Entries        []PointsLedgerEntry `json:"entries"`
+	Total          int                 `json:"total"`
+	CurrentBalance int                 `json:"current_balance"`
+	HasMore        bool                `json:"has_more"`
+}
+
 // =============================================================================
 // Request types
 // =============================================================================
@@ -184,6 +249,38 @@ type ListEndUsersOptions struct {
 	SortOrder string
 }
 
+// PointsLedgerOptions filters a points ledger query.
+type PointsLedgerOptions struct {
+	FromDate *time.Time
+	ToDate   *time.Time
+	Source   string
+	Limit    int
+	Offset   int
+}
+
+func pointsLedgerParams(opts *PointsLedgerOptions) url.Values {
+	params := url.Values{}
+	if opts == nil {
+		return params
+	}
+	if opts.FromDate != nil {
+		params.Set("from_date", opts.FromDate.Format(time.RFC3339))
+	}
+	if opts.ToDate != nil {
+		params.Set("to_date", opts.ToDate.Format(time.RFC3339))
+	}
+	if opts.Source != "" {
+		params.Set("source", opts.Source)
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+	return params
+}
+
 // LinkWalletRequest links an external wallet to a user.
 type LinkWalletRequest struct {
 	WalletAddress string  `json:"wallet_address"`
@@ -215,6 +312,7 @@ type GDPRDeletionRequest struct {
 	DeleteEvents  *bool   `json:"delete_events,omitempty"`
 	DeleteWallets *bool   `json:"delete_wallets,omitempty"`
 	Reason        *string `json:"reason,omitempty"`
+	Concurrency   int     `json:"-"` // Parallel deletions for GDPRDeleteBulk (default 5)
 }
 
 // =============================================================================
@@ -224,6 +322,10 @@ type GDPRDeletionRequest struct {
 // EndUsersClient provides end-user operations.
 type EndUsersClient struct {
 	http *HTTPClient
+
+	// schema is the attribute schema used for client-side validation, set
+	// by SetAttributeSchema or GetAttributeSchema.
+	schema atomic.Pointer[AttributeSchema]
 }
 
 // NewEndUsersClient creates a new end-users client.
@@ -294,6 +396,10 @@ func (u *EndUsersClient) GetByExternalID(ctx context.Context, externalID string)
 
 // Create creates an end-user manually.
 func (u *EndUsersClient) Create(ctx context.Context, req *CreateEndUserRequest) (*EndUser, error) {
+	if err := u.validateAttributes(req.Attributes, false); err != nil {
+		return nil, err
+	}
+
 	var user EndUser
 	err := u.http.Post(ctx, "/end-users", req, &user)
 	if err != nil {
@@ -304,6 +410,10 @@ func (u *EndUsersClient) Create(ctx context.Context, req *CreateEndUserRequest)
 
 // Update updates an end-user profile by internal UUID.
 func (u *EndUsersClient) Update(ctx context.Context, userID string, req *UpdateEndUserRequest) (*EndUser, error) {
+	if err := u.validateAttributes(req.Attributes, true); err != nil {
+		return nil, err
+	}
+
 	var user EndUser
 	err := u.http.Patch(ctx, "/end-users/"+userID, req, &user)
 	if err != nil {
@@ -314,6 +424,10 @@ func (u *EndUsersClient) Update(ctx context.Context, userID string, req *UpdateE
 
 // UpdateByExternalID updates an end-user profile by external ID.
 func (u *EndUsersClient) UpdateByExternalID(ctx context.Context, externalID string, req *UpdateEndUserRequest) (*EndUser, error) {
+	if err := u.validateAttributes(req.Attributes, true); err != nil {
+		return nil, err
+	}
+
 	var user EndUser
 	err := u.http.Patch(ctx, "/end-users/by-external/"+url.PathEscape(externalID), req, &user)
 	if err != nil {
@@ -384,6 +498,60 @@ func (u *EndUsersClient) AddPoints(ctx context.Context, externalID string, point
 	return &result, nil
 }
 
+// TransferPoints moves points from one user to another (both by external ID)
+// in a single server-side transaction: either both the debit and the credit
+// are applied or neither is. The transfer fails if the sender's balance is
+// insufficient. Both legs appear in the users' points ledgers with the
+// transfer ID as reference.
+//
+// The request carries an Idempotency-Key header so a retry after a lost
+// response cannot move the points twice. It is generated once per call; to
+// retry safely across calls, supply your own with ContextWithHeaders.
+func (u *EndUsersClient) TransferPoints(ctx context.Context, fromExternalID, toExternalID string, points int, reason string) (*PointsTransferResult, error) {
+	if points <= 0 {
+		return nil, NewValidationError("points must be positive", []ValidationErrorDetail{
+			{Field: "points", Message: "must be greater than 0"},
+		})
+	}
+	if fromExternalID == toExternalID {
+		return nil, NewValidationError("cannot transfer points to the same user", []ValidationErrorDetail{
+			{Field: "to_user_id", Message: "must differ from from_user_id"},
+		})
+	}
+
+	if !hasHeader(HeadersFromContext(ctx), "Idempotency-Key") {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("generate idempotency key: %w", err)
+		}
+		ctx = ContextWithHeaders(ctx, map[string]string{"Idempotency-Key": hex.EncodeToString(b)})
+	}
+
+	var result PointsTransferResult
+	err := u.http.Post(ctx, "/end-users/points/transfer", map[string]interface{}{
+		"from_user_id": fromExternalID,
+		"to_user_id":   toExternalID,
+		"points":       points,
+		"reason":       reason,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPointsLedger returns the points ledger for a user by external ID: every
+// credit and debit with its reason, actor and resulting balance.
+func (u *EndUsersClient) GetPointsLedger(ctx context.Context, externalID string, opts *PointsLedgerOptions) (*PointsLedger, error) {
+	var result PointsLedger
+	path := "/end-users/by-external/" + url.PathEscape(externalID) + "/points-ledger"
+	err := u.http.Get(ctx, path, pointsLedgerParams(opts), &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // GetRewards returns rewards earned by a user by external ID.
 func (u *EndUsersClient) GetRewards(ctx context.Context, externalID string, status string, page, pageSize int) (*UserRewardsResponse, error) {
 	params := url.Values{}
@@ -607,3 +775,239 @@ func (u *EndUsersClient) GDPRDelete(ctx context.Context, userID string, req *GDP
 	}
 	return &result, nil
 }
+
+// GDPRDeleteBulk permanently deletes data for many users with bounded concurrency.
+//
+// Each user is deleted with the same request options. Individual failures do not
+// abort the run; they are recorded in the returned report alongside per-user
+// deleted record counts and Merkle warnings. Results are in the same order as userIDs.
+func (u *EndUsersClient) GDPRDeleteBulk(ctx context.Context, userIDs []string, req *GDPRDeletionRequest) (*GDPRBulkDeletionReport, error) {
+	if req == nil || !req.Confirm {
+		return nil, NewValidationError("GDPR deletion requires Confirm to be true", nil)
+	}
+
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+
+	report := &GDPRBulkDeletionReport{
+		Reason:         req.Reason,
+		StartedAt:      time.Now().UTC(),
+		TotalRequested: len(userIDs),
+		DeletedRecords: make(map[string]int),
+		Results:        make([]GDPRBulkDeletionItem, len(userIDs)),
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(userIDs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				report.Results[i] = u.gdprDeleteItem(ctx, userIDs[i], req)
+			}
+		}()
+	}
+	for i := range userIDs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, item := range report.Results {
+		if item.Success {
+			report.TotalDeleted++
+		} else {
+			report.TotalFailed++
+		}
+		if item.MerkleWarning != nil {
+			report.MerkleWarnings++
+		}
+		for table, count := range item.DeletedRecords {
+			report.DeletedRecords[table] += count
+		}
+	}
+	report.CompletedAt = time.Now().UTC()
+
+	return report, nil
+}
+
+// gdprDeleteItem deletes one user for GDPRDeleteBulk, recording the outcome.
+func (u *EndUsersClient) gdprDeleteItem(ctx context.Context, userID string, req *GDPRDeletionRequest) GDPRBulkDeletionItem {
+	item := GDPRBulkDeletionItem{UserID: userID}
+	if err := ctx.Err(); err != nil {
+		item.Error = err.Error()
+	} else if resp, err := u.GDPRDelete(ctx, userID, req); err != nil {
+		item.Error = err.Error()
+	} else {
+		item.Success = resp.Success
+		item.ExternalID = resp.ExternalID
+		item.DeletedRecords = resp.DeletedRecords
+		item.MerkleWarning = resp.MerkleWarning
+		item.AuditID = resp.AuditID
+		if !resp.Success {
+			item.Error = "deletion reported unsuccessful"
+		}
+	}
+	item.CompletedAt = time.Now().UTC()
+	return item
+}
+
+// GDPRExport is a portable export of all data held about an end-user
+// (Right of Access / data subject access request).
+type GDPRExport struct {
+	ExportedAt   time.Time               `json:"exported_at"`
+	User         *EndUser                `json:"user"`
+	Events       []Event                 `json:"events"`
+	Rewards      []UserReward            `json:"rewards"`
+	Passport     *Passport               `json:"passport,omitempty"`
+	Wallets      []Wallet                `json:"wallets"`
+	Certificates []Certificate           `json:"certificates"`
+	Credentials  *UserCredentialsSummary `json:"credentials,omitempty"`
+}
+
+// GDPRExportData gathers the user's profile, events, rewards, passport, wallets
+// and certificates by internal UUID. Sections the tenant has not enabled
+// (e.g. no passport) are left empty rather than failing the export.
+func (u *EndUsersClient) GDPRExportData(ctx context.Context, userID string) (*GDPRExport, error) {
+	user, err := u.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &GDPRExport{
+		ExportedAt:   time.Now().UTC(),
+		User:         user,
+		Events:       []Event{},
+		Rewards:      []UserReward{},
+		Wallets:      []Wallet{},
+		Certificates: []Certificate{},
+	}
+	externalID := user.ExternalID
+
+	const pageSize = 500
+	for offset := 0; ; offset += pageSize {
+		var page struct {
+			Events []Event `json:"events"`
+		}
+		params := url.Values{}
+		params.Set("user_id", externalID)
+		params.Set("limit", fmt.Sprintf("%d", pageSize))
+		params.Set("offset", fmt.Sprintf("%d", offset))
+		if err := u.http.Get(ctx, "/tenant/events", params, &page); err != nil {
+			return nil, err
+		}
+		export.Events = append(export.Events, page.Events...)
+		if len(page.Events) < pageSize {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		rewards, err := u.GetRewardsByInternalID(ctx, userID, "", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		export.Rewards = append(export.Rewards, rewards.Rewards...)
+		if !rewards.HasMore {
+			break
+		}
+	}
+
+	var passport Passport
+	if err := u.http.Get(ctx, "/passports/"+url.PathEscape(externalID), nil, &passport); err == nil {
+		export.Passport = &passport
+	} else if _, ok := err.(*NotFoundError); !ok {
+		return nil, err
+	}
+
+	var wallets []Wallet
+	if err := u.http.Get(ctx, "/wallets/user/"+url.PathEscape(externalID), nil, &wallets); err == nil {
+		export.Wallets = append(export.Wallets, wallets...)
+	} else if _, ok := err.(*NotFoundError); !ok {
+		return nil, err
+	}
+
+	if user.Email != nil && *user.Email != "" {
+		for offset := 0; ; offset += pageSize {
+			var page struct {
+				Certificates []Certificate `json:"certificates"`
+			}
+			params := url.Values{}
+			params.Set("recipient_email", *user.Email)
+			params.Set("limit", fmt.Sprintf("%d", pageSize))
+			params.Set("offset", fmt.Sprintf("%d", offset))
+			if err := u.http.Get(ctx, "/certificates", params, &page); err != nil {
+				return nil, err
+			}
+			export.Certificates = append(export.Certificates, page.Certificates...)
+			if len(page.Certificates) < pageSize {
+				break
+			}
+		}
+	}
+
+	var credentials UserCredentialsSummary
+	if err := u.http.Get(ctx, "/credentials/user/"+url.PathEscape(externalID), nil, &credentials); err == nil {
+		export.Credentials = &credentials
+	} else if _, ok := err.(*NotFoundError); !ok {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// GDPRExport writes a single JSON document containing all data held about the
+// user (see GDPRExportData) to w.
+func (u *EndUsersClient) GDPRExport(ctx context.Context, userID string, w io.Writer) error {
+	export, err := u.GDPRExportData(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+// GDPRExportZip writes the user's data to w as a zip archive with one JSON
+// file per section (user.json, events.json, rewards.json, ...).
+func (u *EndUsersClient) GDPRExportZip(ctx context.Context, userID string, w io.Writer) error {
+	export, err := u.GDPRExportData(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	sections := []struct {
+		name string
+		data interface{}
+	}{
+		{"user.json", export.User},
+		{"events.json", export.Events},
+		{"rewards.json", export.Rewards},
+		{"passport.json", export.Passport},
+		{"wallets.json", export.Wallets},
+		{"certificates.json", export.Certificates},
+		{"credentials.json", export.Credentials},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, section := range sections {
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     section.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(section.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
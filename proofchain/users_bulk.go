@@ -0,0 +1,367 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBulkUserChunk is the most users the API accepts in one bulk request.
+const maxBulkUserChunk = 500
+
+// Bulk user row statuses.
+const (
+	BulkUserCreated = "created"
+	BulkUserUpdated = "updated"
+	BulkUserFailed  = "failed"
+)
+
+// BulkCreateUsersOptions configures CreateBulk and ImportCSV.
+type BulkCreateUsersOptions struct {
+	// ChunkSize is the number of users per request (default and maximum 500).
+	ChunkSize int
+	// Concurrency is the number of requests in flight (default 2).
+	Concurrency int
+	// OnProgress, if set, is called after each chunk with the number of rows
+	// processed so far. Calls may come from several goroutines.
+	OnProgress func(done, total int)
+}
+
+// BulkUserResult is the outcome for one row of a bulk create.
+type BulkUserResult struct {
+	// Row is the index of the row in the input; for ImportCSV, the data row
+	// after the header.
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id"`
+	UserID     string `json:"user_id,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// BulkCreateUsersReport summarises a bulk create. Results are in input order.
+type BulkCreateUsersReport struct {
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	Results []BulkUserResult `json:"results"`
+}
+
+// CreateBulk creates or updates many end-users, upserting by external ID:
+// a user whose external ID already exists has its profile updated with the
+// set fields. Users are sent in chunks with bounded concurrency. Rows that
+// fail validation or are rejected by the API are recorded in the report and
+// do not abort the run; a chunk that fails as a whole marks all its rows
+// failed.
+func (u *EndUsersClient) CreateBulk(ctx context.Context, users []CreateEndUserRequest, opts *BulkCreateUsersOptions) (*BulkCreateUsersReport, error) {
+	return u.createBulk(ctx, users, nil, opts)
+}
+
+// createBulk is CreateBulk with rows that already failed to parse; those are
+// reported with their error and not sent.
+func (u *EndUsersClient) createBulk(ctx context.Context, users []CreateEndUserRequest, rowErrs map[int]error, opts *BulkCreateUsersOptions) (*BulkCreateUsersReport, error) {
+	if opts == nil {
+		opts = &BulkCreateUsersOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 || chunkSize > maxBulkUserChunk {
+		chunkSize = maxBulkUserChunk
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 2
+	}
+
+	report := &BulkCreateUsersReport{
+		Total:   len(users),
+		Results: make([]BulkUserResult, len(users)),
+	}
+
+	var rows []int // Indexes of rows to send
+	for i := range users {
+		report.Results[i] = BulkUserResult{Row: i, ExternalID: users[i].ExternalID}
+		err := rowErrs[i]
+		if err == nil && users[i].ExternalID == "" {
+			err = fmt.Errorf("external_id is required")
+		}
+		if err == nil {
+			err = u.validateAttributes(users[i].Attributes, false)
+		}
+		if err != nil {
+			report.Results[i].Status = BulkUserFailed
+			report.Results[i].Error = err.Error()
+			continue
+		}
+		rows = append(rows, i)
+	}
+
+	var (
+		mu   sync.Mutex
+		done = len(users) - len(rows)
+		wg   sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+	for start := 0; start < len(rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		chunk := rows[start:end]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				u.sendBulkChunk(ctx, users, chunk, report.Results)
+				<-sem
+			case <-ctx.Done():
+				for _, row := range chunk {
+					report.Results[row].Status = BulkUserFailed
+					report.Results[row].Error = ctx.Err().Error()
+				}
+			}
+			if opts.OnProgress != nil {
+				mu.Lock()
+				done += len(chunk)
+				n := done
+				mu.Unlock()
+				opts.OnProgress(n, len(users))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range report.Results {
+		switch r.Status {
+		case BulkUserCreated:
+			report.Created++
+		case BulkUserUpdated:
+			report.Updated++
+		default:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// sendBulkChunk upserts the users at rows and records their outcome in
+// results.
+func (u *EndUsersClient) sendBulkChunk(ctx context.Context, users []CreateEndUserRequest, rows []int, results []BulkUserResult) {
+	batch := make([]CreateEndUserRequest, len(rows))
+	for i, row := range rows {
+		batch[i] = users[row]
+	}
+
+	var resp struct {
+		Results []struct {
+			Index  int    `json:"index"`
+			UserID string `json:"user_id"`
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"results"`
+	}
+	err := u.http.Post(ctx, "/end-users/bulk", map[string]interface{}{
+		"users":  batch,
+		"upsert": true,
+	}, &resp)
+
+	seen := make([]bool, len(rows))
+	if err == nil {
+		for _, r := range resp.Results {
+			if r.Index < 0 || r.Index >= len(rows) {
+				continue
+			}
+			seen[r.Index] = true
+			res := &results[rows[r.Index]]
+			res.UserID = r.UserID
+			res.Status = r.Status
+			res.Error = r.Error
+			if res.Status != BulkUserCreated && res.Status != BulkUserUpdated {
+				res.Status = BulkUserFailed
+			}
+		}
+	}
+	for i, row := range rows {
+		if seen[i] {
+			continue
+		}
+		results[row].Status = BulkUserFailed
+		if err != nil {
+			results[row].Error = err.Error()
+		} else {
+			results[row].Error = "no result returned for row"
+		}
+	}
+}
+
+// ImportCSV reads end-users from CSV and upserts them with CreateBulk. The
+// first row is a header naming each column; external_id is required.
+// Columns named after CreateEndUserRequest fields (email, first_name,
+// last_name, display_name, phone, date_of_birth as YYYY-MM-DD, country,
+// city, timezone, language, bio, wallet_address and segments, separated by
+// ";") set those fields; their names are matched case-insensitively. Any
+// other column sets an attribute named exactly as its header, converted to
+// the type given by the attribute schema if one is set. Empty cells are
+// ignored.
+//
+// Rows that cannot be parsed are reported as failed. An error is returned
+// only if the CSV itself is malformed.
+func (u *EndUsersClient) ImportCSV(ctx context.Context, r io.Reader, opts *BulkCreateUsersOptions) (*BulkCreateUsersReport, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+		if col := strings.ToLower(header[i]); csvUserColumns[col] {
+			header[i] = col
+		}
+	}
+	hasExternalID := false
+	for _, col := range header {
+		hasExternalID = hasExternalID || col == "external_id"
+	}
+	if !hasExternalID {
+		return nil, NewValidationError("CSV has no external_id column", nil)
+	}
+
+	var users []CreateEndUserRequest
+	rowErrs := make(map[int]error)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+		user, err := u.parseCSVUser(header, record)
+		if err != nil {
+			rowErrs[len(users)] = err
+		}
+		users = append(users, user)
+	}
+
+	return u.createBulk(ctx, users, rowErrs, opts)
+}
+
+// csvUserColumns are the ImportCSV columns that set CreateEndUserRequest
+// fields rather than attributes.
+var csvUserColumns = map[string]bool{
+	"external_id": true, "email": true, "first_name": true, "last_name": true,
+	"display_name": true, "phone": true, "date_of_birth": true, "country": true,
+	"city": true, "timezone": true, "language": true, "bio": true,
+	"wallet_address": true, "segments": true,
+}
+
+func (u *EndUsersClient) parseCSVUser(header, record []string) (CreateEndUserRequest, error) {
+	var user CreateEndUserRequest
+	schema := u.schema.Load()
+	for i, col := range header {
+		if i >= len(record) {
+			break
+		}
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+		str := func() *string { return &value }
+
+		switch col {
+		case "external_id":
+			user.ExternalID = value
+		case "email":
+			user.Email = str()
+		case "first_name":
+			user.FirstName = str()
+		case "last_name":
+			user.LastName = str()
+		case "display_name":
+			user.DisplayName = str()
+		case "phone":
+			user.Phone = str()
+		case "date_of_birth":
+			t, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return user, fmt.Errorf("date_of_birth: %q is not YYYY-MM-DD", value)
+			}
+			user.DateOfBirth = &t
+		case "country":
+			user.Country = str()
+		case "city":
+			user.City = str()
+		case "timezone":
+			user.Timezone = str()
+		case "language":
+			user.Language = str()
+		case "bio":
+			user.Bio = str()
+		case "wallet_address":
+			user.WalletAddress = str()
+		case "segments":
+			user.Segments = splitCSVList(value)
+		default:
+			v, err := csvAttributeValue(schema, col, value)
+			if err != nil {
+				return user, err
+			}
+			if user.Attributes == nil {
+				user.Attributes = make(map[string]interface{})
+			}
+			user.Attributes[col] = v
+		}
+	}
+	return user, nil
+}
+
+// csvAttributeValue converts a CSV cell to the type of attribute key in
+// schema; without a definition the value stays a string.
+func csvAttributeValue(schema *AttributeSchema, key, value string) (interface{}, error) {
+	if schema == nil {
+		return value, nil
+	}
+	def, ok := schema.Attributes[key]
+	if !ok {
+		return value, nil
+	}
+	switch def.Type {
+	case AttributeNumber:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a number", key, value)
+		}
+		return f, nil
+	case AttributeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a boolean", key, value)
+		}
+		return b, nil
+	case AttributeList:
+		list := splitCSVList(value)
+		items := make([]interface{}, len(list))
+		for i, s := range list {
+			items[i] = s
+		}
+		return items, nil
+	}
+	return value, nil
+}
+
+func splitCSVList(value string) []string {
+	var out []string
+	for _, s := range strings.Split(value, ";") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
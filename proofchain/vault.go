@@ -3,22 +3,50 @@ package proofchain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 )
 
+// vaultDownloadRetries is the number of times a streaming download resumes
+// after a network failure without making progress.
+var vaultDownloadRetries = 3
+
 // VaultFile represents a file stored in the vault.
 type VaultFile struct {
-	ID            string     `json:"id"`
-	Name          string     `json:"name"`
-	Size          int64      `json:"size"`
-	MimeType      string     `json:"mime_type"`
-	FolderID      *string    `json:"folder_id,omitempty"`
-	IPFSHash      string     `json:"ipfs_hash"`
-	CertificateID *string    `json:"certificate_id,omitempty"`
-	TxHash        *string    `json:"tx_hash,omitempty"`
-	Status        string     `json:"status"`
-	AccessMode    string     `json:"access_mode"`
-	CreatedAt     Timestamp  `json:"created_at"`
-	UpdatedAt     *Timestamp `json:"updated_at,omitempty"`
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Size          int64             `json:"size"`
+	MimeType      string            `json:"mime_type"`
+	FolderID      *string           `json:"folder_id,omitempty"`
+	IPFSHash      string            `json:"ipfs_hash"`
+	CertificateID *string           `json:"certificate_id,omitempty"`
+	TxHash        *string           `json:"tx_hash,omitempty"`
+	Status        string            `json:"status"`
+	AccessMode    string            `json:"access_mode"`
+	Version       int               `json:"version,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	CreatedAt     Timestamp         `json:"created_at"`
+	UpdatedAt     *Timestamp        `json:"updated_at,omitempty"`
+}
+
+// VaultFileVersion is one attested version of a vault file.
+type VaultFileVersion struct {
+	Version       int       `json:"version"`
+	Size          int64     `json:"size"`
+	MimeType      string    `json:"mime_type"`
+	IPFSHash      string    `json:"ipfs_hash"`
+	CertificateID *string   `json:"certificate_id,omitempty"`
+	TxHash        *string   `json:"tx_hash,omitempty"`
+	CreatedAt     Timestamp `json:"created_at"`
 }
 
 // VaultFolder represents a folder in the vault.
@@ -53,6 +81,8 @@ type VaultUploadRequest struct {
 	FolderID   string
 	AccessMode string // "private" or "public"
 	Encrypt    bool
+	Tags       []string
+	Metadata   map[string]string
 }
 
 // VaultUploadBytesRequest contains parameters for uploading raw bytes.
@@ -64,6 +94,28 @@ type VaultUploadBytesRequest struct {
 	FolderID   string
 	AccessMode string
 	Encrypt    bool
+	Tags       []string
+	Metadata   map[string]string
+}
+
+// VaultSearchRequest searches vault files. All set criteria must match.
+type VaultSearchRequest struct {
+	Query     string     `json:"query,omitempty"` // matched against file names
+	Tags      []string   `json:"tags,omitempty"`  // files must have every tag
+	MimeTypes []string   `json:"mime_types,omitempty"`
+	FolderID  string     `json:"folder_id,omitempty"`
+	From      *time.Time `json:"from_date,omitempty"`
+	To        *time.Time `json:"to_date,omitempty"`
+	Limit     int        `json:"limit,omitempty"`
+	Offset    int        `json:"offset,omitempty"`
+}
+
+// VaultSearchResponse is the response from searching the vault.
+type VaultSearchResponse struct {
+	Files  []VaultFile `json:"files"`
+	Total  int         `json:"total"`
+	Offset int         `json:"offset"`
+	Limit  int         `json:"limit"`
 }
 
 // VaultResource handles file vault operations.
@@ -109,6 +161,9 @@ func (r *VaultResource) Upload(ctx context.Context, req *VaultUploadRequest) (*V
 	if req.Encrypt {
 		fields["encrypt"] = "true"
 	}
+	if err := setVaultTagFields(fields, req.Tags, req.Metadata); err != nil {
+		return nil, err
+	}
 
 	var result VaultFile
 	err = r.http.RequestMultipart(ctx, "/tenant/vault/upload", fields, "file", filename, content, &result)
@@ -139,6 +194,9 @@ func (r *VaultResource) UploadBytes(ctx context.Context, req *VaultUploadBytesRe
 	if req.Encrypt {
 		fields["encrypt"] = "true"
 	}
+	if err := setVaultTagFields(fields, req.Tags, req.Metadata); err != nil {
+		return nil, err
+	}
 
 	var result VaultFile
 	err := r.http.RequestMultipart(ctx, "/tenant/vault/upload", fields, "file", req.Filename, req.Content, &result)
@@ -163,6 +221,112 @@ func (r *VaultResource) Download(ctx context.Context, fileID string) ([]byte, er
 	return r.http.GetRaw(ctx, "/tenant/vault/files/"+fileID+"/download")
 }
 
+// DownloadTo streams a file's content to w without buffering it in memory.
+// If the connection drops, the download resumes from the last byte written
+// using a Range request. The content is verified against the SHA-256 digest
+// the server reports for the stored file (X-Content-SHA256), when present.
+// It returns the number of bytes written.
+func (r *VaultResource) DownloadTo(ctx context.Context, fileID string, w io.Writer) (int64, error) {
+	return r.download(ctx, fileID, w, 0, sha256.New())
+}
+
+// ResumeDownloadTo is like DownloadTo but continues a partially written
+// file, e.g. after a restart: the bytes already in f are kept and the rest
+// of the file is appended. The existing bytes are read back so the whole
+// file is verified against the server's checksum. It returns the number of
+// bytes appended.
+func (r *VaultResource) ResumeDownloadTo(ctx context.Context, fileID string, f io.ReadWriteSeeker) (int64, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	digest := sha256.New()
+	offset, err := io.Copy(digest, f)
+	if err != nil {
+		return 0, fmt.Errorf("read partial download: %w", err)
+	}
+	return r.download(ctx, fileID, f, offset, digest)
+}
+
+// download writes the file from offset to w. digest must already hold the
+// first offset bytes of the file.
+func (r *VaultResource) download(ctx context.Context, fileID string, w io.Writer, offset int64, digest hash.Hash) (int64, error) {
+	path := "/tenant/vault/files/" + fileID + "/download"
+
+	var expected string
+	var written int64
+
+	for failures := 0; ; {
+		header := http.Header{}
+		if pos := offset + written; pos > 0 {
+			header.Set("Range", fmt.Sprintf("bytes=%d-", pos))
+		}
+
+		resp, err := r.http.GetStream(ctx, path, header)
+		if err != nil {
+			var netErr *NetworkError
+			if errors.As(err, &netErr) && failures < vaultDownloadRetries {
+				failures++
+				continue
+			}
+			return written, err
+		}
+		if header.Get("Range") != "" && resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			return written, &APIError{Message: "server does not support resuming downloads", StatusCode: resp.StatusCode}
+		}
+		if expected == "" {
+			expected = resp.Header.Get("X-Content-SHA256")
+		}
+
+		dst := io.MultiWriter(w, digest)
+		body := &readErrTracker{r: resp.Body}
+		n, err := io.Copy(dst, body)
+		resp.Body.Close()
+		written += n
+
+		if err == nil {
+			break
+		}
+		if body.err == nil {
+			// The writer failed; resuming would not help
+			return written, err
+		}
+		if ctx.Err() != nil {
+			return written, NewTimeoutError()
+		}
+		if n == 0 {
+			failures++
+		} else {
+			failures = 0
+		}
+		if failures > vaultDownloadRetries {
+			return written, NewNetworkError(err)
+		}
+	}
+
+	if expected != "" {
+		if got := hex.EncodeToString(digest.Sum(nil)); !strings.EqualFold(got, expected) {
+			return written, &APIError{Message: fmt.Sprintf("checksum mismatch: expected %s, got %s", expected, got)}
+		}
+	}
+	return written, nil
+}
+
+// readErrTracker records read errors so they can be told apart from write
+// errors after io.Copy.
+type readErrTracker struct {
+	r   io.Reader
+	err error
+}
+
+func (t *readErrTracker) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err != nil && err != io.EOF {
+		t.err = err
+	}
+	return n, err
+}
+
 // Delete deletes a file from the vault.
 func (r *VaultResource) Delete(ctx context.Context, fileID string) error {
 	return r.http.Delete(ctx, "/tenant/vault/files/"+fileID)
@@ -230,3 +394,99 @@ func (r *VaultResource) Share(ctx context.Context, fileID string, expiresInHours
 	}
 	return result, nil
 }
+
+// UploadVersion uploads new content for an existing file. The file keeps its
+// ID, name and folder; the previous content remains available as an earlier
+// version and each version is attested separately.
+func (r *VaultResource) UploadVersion(ctx context.Context, fileID string, content []byte) (*VaultFile, error) {
+	var result VaultFile
+	err := r.http.RequestMultipart(ctx, "/tenant/vault/files/"+fileID+"/versions", nil, "file", fileID, content, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListVersions lists a file's versions, newest first.
+func (r *VaultResource) ListVersions(ctx context.Context, fileID string) ([]VaultFileVersion, error) {
+	var result struct {
+		Versions []VaultFileVersion `json:"versions"`
+	}
+	err := r.http.Get(ctx, "/tenant/vault/files/"+fileID+"/versions", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Versions, nil
+}
+
+// DownloadVersion downloads the content of a specific file version.
+func (r *VaultResource) DownloadVersion(ctx context.Context, fileID string, version int) ([]byte, error) {
+	return r.http.GetRaw(ctx, "/tenant/vault/files/"+fileID+"/versions/"+intToString(version)+"/download")
+}
+
+// Search finds vault files by name, tag, content type and upload date.
+func (r *VaultResource) Search(ctx context.Context, req *VaultSearchRequest) (*VaultSearchResponse, error) {
+	var result VaultSearchResponse
+	err := r.http.Post(ctx, "/tenant/vault/search", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// setVaultTagFields adds tags (comma-separated) and metadata (JSON) to
+// multipart upload fields.
+func setVaultTagFields(fields map[string]string, tags []string, metadata map[string]string) error {
+	if len(tags) > 0 {
+		for _, tag := range tags {
+			if strings.Contains(tag, ",") {
+				return NewValidationError("invalid tag", []ValidationErrorDetail{
+					{Field: "tags", Message: "tags must not contain commas"},
+				})
+			}
+		}
+		fields["tags"] = strings.Join(tags, ",")
+	}
+	if len(metadata) > 0 {
+		b, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		fields["metadata"] = string(b)
+	}
+	return nil
+}
+
+// Attest attests a file already stored in the vault, without uploading its
+// content again. The attestation covers the file's current version and is
+// linked to it: the result's VaultFileID is fileID, and the file's
+// CertificateID is set once the attestation is recorded. eventType defaults
+// to "document_uploaded".
+func (r *VaultResource) Attest(ctx context.Context, fileID, eventType, userID string, metadata map[string]interface{}) (*AttestationResult, error) {
+	if fileID == "" {
+		return nil, NewValidationError("file ID is required", []ValidationErrorDetail{
+			{Field: "file_id", Message: "must not be empty"},
+		})
+	}
+	if eventType == "" {
+		eventType = "document_uploaded"
+	}
+
+	payload := map[string]interface{}{
+		"user_id":    userID,
+		"event_type": eventType,
+	}
+	if metadata != nil {
+		payload["metadata"] = metadata
+	}
+
+	var result AttestationResult
+	err := r.http.Post(ctx, "/tenant/vault/files/"+fileID+"/attest", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.VaultFileID == nil {
+		result.VaultFileID = &fileID
+	}
+	return &result, nil
+}
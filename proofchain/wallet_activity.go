@@ -0,0 +1,125 @@
+package proofchain
+
+import (
+	"context"
+	"net/url"
+)
+
+// Wallet activity webhook event types.
+const (
+	WebhookEventWalletTransferReceived = "wallet.transfer.received"
+	WebhookEventWalletNFTReceived      = "wallet.nft.received"
+	WebhookEventWalletSwapCompleted    = "wallet.swap.completed"
+)
+
+// WalletActivitySubscription delivers activity on a managed wallet to a
+// webhook endpoint
+type WalletActivitySubscription struct {
+	ID         string   `json:"id"`
+	WalletID   string   `json:"wallet_id"`
+	WebhookURL string   `json:"webhook_url"`
+	Events     []string `json:"events"`
+	// Secret signs deliveries; it is only returned when the subscription is
+	// created. Pass it to NewWebhookHandler.
+	Secret    string `json:"secret,omitempty"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`
+}
+
+// WalletTransferReceived is the payload of a wallet.transfer.received
+// webhook, sent when native currency or a token arrives in a managed wallet
+type WalletTransferReceived struct {
+	WalletID     string `json:"wallet_id"`
+	TxHash       string `json:"tx_hash"`
+	Network      string `json:"network"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	Amount       string `json:"amount"`
+	Token        string `json:"token"`
+	TokenAddress string `json:"token_address,omitempty"` // Empty for native currency
+	BlockNumber  int64  `json:"block_number"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// WalletNFTReceived is the payload of a wallet.nft.received webhook
+type WalletNFTReceived struct {
+	WalletID        string  `json:"wallet_id"`
+	TxHash          string  `json:"tx_hash"`
+	Network         string  `json:"network"`
+	From            string  `json:"from"`
+	ContractAddress string  `json:"contract_address"`
+	TokenID         string  `json:"token_id"`
+	Name            *string `json:"name,omitempty"`
+	ImageURL        *string `json:"image_url,omitempty"`
+	BlockNumber     int64   `json:"block_number"`
+	Timestamp       string  `json:"timestamp"`
+}
+
+// WalletSwapCompleted is the payload of a wallet.swap.completed webhook
+type WalletSwapCompleted struct {
+	SwapResult
+	WalletID  string `json:"wallet_id"`
+	Network   string `json:"network"`
+	Timestamp string `json:"timestamp"`
+}
+
+// SubscribeActivity registers webhookURL to receive activity on a managed
+// wallet. events selects from the WebhookEventWallet* types; empty
+// subscribes to all of them. Handle deliveries with NewWebhookHandler and the
+// OnWallet* callbacks, using the returned subscription's Secret.
+//
+// Example:
+//
+//	sub, err := client.Wallets.SubscribeActivity(ctx, walletID, "https://example.com/hooks/wallets",
+//	    []string{proofchain.WebhookEventWalletTransferReceived})
+func (w *WalletClient) SubscribeActivity(ctx context.Context, walletID, webhookURL string, events []string) (*WalletActivitySubscription, error) {
+	if walletID == "" {
+		return nil, NewValidationError("wallet ID is required", []ValidationErrorDetail{
+			{Field: "wallet_id", Message: "must not be empty"},
+		})
+	}
+	if u, err := url.Parse(webhookURL); err != nil || u.Host == "" {
+		return nil, NewValidationError("webhook URL must be an absolute URL", []ValidationErrorDetail{
+			{Field: "webhook_url", Message: "must be an absolute URL"},
+		})
+	}
+	for _, event := range events {
+		if !containsString(walletActivityEvents, event) {
+			return nil, NewValidationError("unknown wallet activity event: "+event, []ValidationErrorDetail{
+				{Field: "events", Message: "unknown event type " + event},
+			})
+		}
+	}
+
+	body := map[string]interface{}{
+		"webhook_url": webhookURL,
+	}
+	if len(events) > 0 {
+		body["events"] = events
+	}
+
+	var sub WalletActivitySubscription
+	err := w.http.Post(ctx, "/wallets/"+walletID+"/activity-subscriptions", body, &sub)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ListActivitySubscriptions returns the activity subscriptions of a wallet
+func (w *WalletClient) ListActivitySubscriptions(ctx context.Context, walletID string) ([]WalletActivitySubscription, error) {
+	var subs []WalletActivitySubscription
+	err := w.http.Get(ctx, "/wallets/"+walletID+"/activity-subscriptions", nil, &subs)
+	return subs, err
+}
+
+// UnsubscribeActivity deletes a wallet activity subscription
+func (w *WalletClient) UnsubscribeActivity(ctx context.Context, walletID, subscriptionID string) error {
+	return w.http.Delete(ctx, "/wallets/"+walletID+"/activity-subscriptions/"+subscriptionID)
+}
+
+var walletActivityEvents = []string{
+	WebhookEventWalletTransferReceived,
+	WebhookEventWalletNFTReceived,
+	WebhookEventWalletSwapCompleted,
+}
@@ -0,0 +1,89 @@
+package proofchain
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// minBackupPassphrase is the shortest passphrase accepted for wallet backups.
+const minBackupPassphrase = 12
+
+// WalletBackup is an encrypted backup of a wallet's key. The key is
+// encrypted by the platform and never leaves it in plaintext, so the backup
+// can be stored or moved like any other file. For a smart wallet the backup
+// holds the owner key.
+type WalletBackup struct {
+	WalletID   string `json:"wallet_id"`
+	Address    string `json:"address"`
+	WalletType string `json:"wallet_type"`
+	Network    string `json:"network"`
+	// OwnerAddress is the owner EOA of a smart wallet.
+	OwnerAddress *string `json:"owner_address,omitempty"`
+	// Keystore is a Web3 Secret Storage (version 3) keystore encrypted with
+	// the backup passphrase.
+	Keystore  json.RawMessage `json:"keystore"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// ImportWalletBackupRequest restores a wallet from a WalletBackup.
+type ImportWalletBackupRequest struct {
+	// UserID is the end-user the restored wallet belongs to. It may differ
+	// from the original owner when migrating custody.
+	UserID     string        `json:"user_id"`
+	Backup     *WalletBackup `json:"backup"`
+	Passphrase string        `json:"passphrase"`
+	Name       *string       `json:"name,omitempty"`
+}
+
+// ExportBackup exports an encrypted keystore for an EOA or smart wallet,
+// protected by passphrase. Unlike ExportKey, the private key is never
+// returned in plaintext. Restore it with ImportBackup.
+func (w *WalletClient) ExportBackup(ctx context.Context, walletID, passphrase string) (*WalletBackup, error) {
+	if err := validateBackupPassphrase(passphrase); err != nil {
+		return nil, err
+	}
+
+	var result WalletBackup
+	err := w.http.Post(ctx, "/wallets/"+walletID+"/export-backup", map[string]interface{}{
+		"passphrase": passphrase,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ImportBackup restores a wallet from a backup made with ExportBackup, for
+// example when migrating custody to another tenant. A smart wallet is
+// restored together with its owner key.
+func (w *WalletClient) ImportBackup(ctx context.Context, req *ImportWalletBackupRequest) (*Wallet, error) {
+	var details []ValidationErrorDetail
+	if req.UserID == "" {
+		details = append(details, ValidationErrorDetail{Field: "user_id", Message: "is required"})
+	}
+	if req.Backup == nil || len(req.Backup.Keystore) == 0 {
+		details = append(details, ValidationErrorDetail{Field: "backup", Message: "keystore is required"})
+	}
+	if len(details) > 0 {
+		return nil, NewValidationError("invalid wallet backup import", details)
+	}
+	if err := validateBackupPassphrase(req.Passphrase); err != nil {
+		return nil, err
+	}
+
+	var result Wallet
+	err := w.http.Post(ctx, "/wallets/import-backup", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func validateBackupPassphrase(passphrase string) error {
+	if len([]rune(passphrase)) < minBackupPassphrase {
+		return NewValidationError("invalid backup passphrase", []ValidationErrorDetail{
+			{Field: "passphrase", Message: "must be at least 12 characters"},
+		})
+	}
+	return nil
+}
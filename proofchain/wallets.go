@@ -2,8 +2,14 @@ package proofchain
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math/big"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // Wallet represents a CDP wallet
@@ -40,8 +46,10 @@ type WalletBalance struct {
 
 // TokenBalance represents a single token balance
 type TokenBalance struct {
-	Token    string   `json:"token"`
-	Symbol   string   `json:"symbol"`
+	Token  string `json:"token"`
+	Symbol string `json:"symbol"`
+	// Balance is in the token's base units; divide by 10^Decimals for
+	// whole tokens.
 	Balance  string   `json:"balance"`
 	Decimals int      `json:"decimals"`
 	USDValue *float64 `json:"usd_value,omitempty"`
@@ -77,6 +85,16 @@ type SwapQuote struct {
 	ExpiresAt          string `json:"expires_at"`
 }
 
+// Expired reports whether the quote's ExpiresAt has passed. Quotes with an
+// unparseable expiry are treated as not expired and left to the server.
+func (q *SwapQuote) Expired() bool {
+	expiresAt, err := time.Parse(time.RFC3339, q.ExpiresAt)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(expiresAt)
+}
+
 // SwapResult represents a completed swap
 type SwapResult struct {
 	TransactionHash string `json:"transaction_hash"`
@@ -142,6 +160,11 @@ type UserWalletSummary struct {
 	TotalNFTs    int                 `json:"total_nfts"`
 	TotalSwaps   int                 `json:"total_swaps"`
 	Wallets      []WalletSummaryItem `json:"wallets"`
+	// TotalUSDValue and UnpricedTokens are set when GetUserSummary is
+	// called WithUSDValuation. UnpricedTokens lists the symbols the price
+	// feed could not value; they are not part of TotalUSDValue.
+	TotalUSDValue  *float64 `json:"total_usd_value,omitempty"`
+	UnpricedTokens []string `json:"unpriced_tokens,omitempty"`
 }
 
 // WalletSummaryItem represents a wallet in the user summary
@@ -197,6 +220,13 @@ type ExecuteSwapRequest struct {
 	SlippageBps int    `json:"slippage_bps,omitempty"`
 }
 
+// executeQuoteRequest binds a swap execution to a previously obtained quote.
+type executeQuoteRequest struct {
+	WalletID    string `json:"wallet_id"`
+	QuoteID     string `json:"quote_id"`
+	MinToAmount string `json:"min_to_amount,omitempty"`
+}
+
 type AddNFTRequest struct {
 	ContractAddress string                 `json:"contract_address"`
 	TokenID         string                 `json:"token_id"`
@@ -228,11 +258,17 @@ type TransferResult struct {
 // WalletClient provides wallet operations
 type WalletClient struct {
 	http *HTTPClient
+
+	// GasPolicies manages paymaster sponsorship policies for smart accounts
+	GasPolicies *GasPoliciesClient
 }
 
 // NewWalletClient creates a new wallet client
 func NewWalletClient(http *HTTPClient) *WalletClient {
-	return &WalletClient{http: http}
+	return &WalletClient{
+		http:        http,
+		GasPolicies: NewGasPoliciesClient(http),
+	}
 }
 
 // Create creates a single wallet
@@ -325,9 +361,34 @@ func (w *WalletClient) GetInfo(ctx context.Context, walletID string, opts *GetIn
 	return &info, nil
 }
 
+// UserSummaryOption configures GetUserSummary.
+type UserSummaryOption func(*userSummaryOptions)
+
+type userSummaryOptions struct {
+	usdValuation bool
+}
+
+// WithUSDValuation fills in each token's USDValue from the price feed and
+// totals them into TotalUSDValue. Balances are always included. Tokens the
+// price feed cannot value are listed in UnpricedTokens and left out of the
+// total.
+func WithUSDValuation() UserSummaryOption {
+	return func(o *userSummaryOptions) {
+		o.usdValuation = true
+	}
+}
+
 // GetUserSummary returns comprehensive summary of all wallets for a user.
 // Aggregates data across all user's wallets (EOA + Smart).
-func (w *WalletClient) GetUserSummary(ctx context.Context, userID string, includeBalances bool) (*UserWalletSummary, error) {
+func (w *WalletClient) GetUserSummary(ctx context.Context, userID string, includeBalances bool, opts ...UserSummaryOption) (*UserWalletSummary, error) {
+	var o userSummaryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.usdValuation {
+		includeBalances = true
+	}
+
 	path := fmt.Sprintf("/wallets/user/%s/summary?include_balances=%t", url.PathEscape(userID), includeBalances)
 
 	var summary UserWalletSummary
@@ -335,9 +396,83 @@ func (w *WalletClient) GetUserSummary(ctx context.Context, userID string, includ
 	if err != nil {
 		return nil, err
 	}
+	if o.usdValuation {
+		if err := w.valueSummary(ctx, &summary); err != nil {
+			return nil, err
+		}
+	}
 	return &summary, nil
 }
 
+// valueSummary prices the summary's token balances in USD.
+func (w *WalletClient) valueSummary(ctx context.Context, summary *UserWalletSummary) error {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, wallet := range summary.Wallets {
+		if wallet.Balances == nil {
+			continue
+		}
+		for _, tb := range wallet.Balances.Tokens {
+			if tb.USDValue == nil && !seen[tb.Symbol] {
+				seen[tb.Symbol] = true
+				symbols = append(symbols, tb.Symbol)
+			}
+		}
+	}
+
+	prices := map[string]TokenPrice{}
+	if len(symbols) > 0 {
+		var err error
+		prices, err = w.GetTokenPrices(ctx, symbols, "usd")
+		if err != nil {
+			return err
+		}
+	}
+
+	var total float64
+	unpriced := make(map[string]bool)
+	summary.UnpricedTokens = []string{}
+	for i := range summary.Wallets {
+		balances := summary.Wallets[i].Balances
+		if balances == nil {
+			continue
+		}
+		for j := range balances.Tokens {
+			tb := &balances.Tokens[j]
+			if tb.USDValue == nil {
+				price, ok := prices[tb.Symbol]
+				amount, err := tokenAmount(tb.Balance, tb.Decimals)
+				if !ok || err != nil {
+					if !unpriced[tb.Symbol] {
+						unpriced[tb.Symbol] = true
+						summary.UnpricedTokens = append(summary.UnpricedTokens, tb.Symbol)
+					}
+					continue
+				}
+				value := amount * price.Price
+				tb.USDValue = &value
+			}
+			total += *tb.USDValue
+		}
+	}
+	summary.TotalUSDValue = &total
+	return nil
+}
+
+// tokenAmount converts a balance in base units to whole tokens.
+func tokenAmount(balance string, decimals int) (float64, error) {
+	amount, ok := new(big.Float).SetString(balance)
+	if !ok {
+		return 0, fmt.Errorf("invalid token balance %q", balance)
+	}
+	if decimals > 0 {
+		scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+		amount.Quo(amount, scale)
+	}
+	f, _ := amount.Float64()
+	return f, nil
+}
+
 // ExportKey exports private key for an EOA wallet
 func (w *WalletClient) ExportKey(ctx context.Context, walletID string) (string, error) {
 	var result struct {
@@ -384,6 +519,69 @@ func (w *WalletClient) ExecuteSwap(ctx context.Context, req *ExecuteSwapRequest)
 	return &result, nil
 }
 
+// ExecuteSwapFromQuote executes the swap described by a quote from
+// GetSwapQuote. An expired quote is rejected before anything is sent; the
+// server also rejects the execution if the output would fall below the
+// quote's MinToAmount, so amounts cannot drift between quote and execution.
+func (w *WalletClient) ExecuteSwapFromQuote(ctx context.Context, walletID string, quote *SwapQuote) (*SwapResult, error) {
+	if quote == nil || quote.QuoteID == "" {
+		return nil, NewValidationError("a quote with a quote_id is required", []ValidationErrorDetail{
+			{Field: "quote_id", Message: "required"},
+		})
+	}
+	if quote.Expired() {
+		return nil, NewValidationError("swap quote has expired", []ValidationErrorDetail{
+			{Field: "expires_at", Message: "quote expired at " + quote.ExpiresAt + "; request a new quote"},
+		})
+	}
+
+	req := &executeQuoteRequest{WalletID: walletID, QuoteID: quote.QuoteID, MinToAmount: quote.MinToAmount}
+	var result SwapResult
+	err := w.http.Post(ctx, "/wallets/swaps/execute", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// swapPollInterval is how often WaitForSwap checks transaction status.
+var swapPollInterval = 3 * time.Second
+
+// GetSwapStatus returns the current status of a swap transaction.
+func (w *WalletClient) GetSwapStatus(ctx context.Context, transactionHash string) (*SwapResult, error) {
+	var result SwapResult
+	err := w.http.Get(ctx, "/wallets/swaps/"+transactionHash, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WaitForSwap polls a swap transaction until it is confirmed or fails. A
+// failed or reverted swap returns the result together with an error. Use a
+// context deadline to bound the wait.
+func (w *WalletClient) WaitForSwap(ctx context.Context, transactionHash string) (*SwapResult, error) {
+	for {
+		result, err := w.GetSwapStatus(ctx, transactionHash)
+		if err != nil {
+			return nil, err
+		}
+
+		switch result.Status {
+		case "confirmed", "completed", "success":
+			return result, nil
+		case "failed", "reverted":
+			return result, &APIError{Message: fmt.Sprintf("swap %s %s", transactionHash, result.Status)}
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, NewTimeoutError()
+		case <-time.After(swapPollInterval):
+		}
+	}
+}
+
 // GetNFTs returns NFTs for a wallet
 func (w *WalletClient) GetNFTs(ctx context.Context, walletID string) ([]NFT, error) {
 	var nfts []NFT
@@ -408,6 +606,54 @@ func (w *WalletClient) AddNFT(ctx context.Context, walletID string, req *AddNFTR
 	return &nft, nil
 }
 
+// RefreshNFTMetadata re-fetches an NFT's tokenURI metadata from chain and
+// returns the updated NFT. Use it to fix stale names or images.
+func (w *WalletClient) RefreshNFTMetadata(ctx context.Context, walletID, nftID string) (*NFT, error) {
+	var nft NFT
+	err := w.http.Post(ctx, "/wallets/"+walletID+"/nfts/"+nftID+"/refresh-metadata", nil, &nft)
+	if err != nil {
+		return nil, err
+	}
+	return &nft, nil
+}
+
+// CollectionNFT is a tracked NFT together with its owner.
+type CollectionNFT struct {
+	NFT
+	UserID        string `json:"user_id"`
+	WalletAddress string `json:"wallet_address"`
+}
+
+// NFTCollectionPage is a page of tracked NFTs for a contract.
+type NFTCollectionPage struct {
+	ContractAddress string          `json:"contract_address"`
+	Network         string          `json:"network"`
+	NFTs            []CollectionNFT `json:"nfts"`
+	Total           int             `json:"total"`
+	Page            int             `json:"page"`
+	PageSize        int             `json:"page_size"`
+	HasMore         bool            `json:"has_more"`
+}
+
+// ListNFTsByContract lists all tracked NFTs for a collection across users.
+// Pages start at 1.
+func (w *WalletClient) ListNFTsByContract(ctx context.Context, contractAddress, network string, page int) (*NFTCollectionPage, error) {
+	params := url.Values{}
+	if network != "" {
+		params.Set("network", network)
+	}
+	if page > 0 {
+		params.Set("page", fmt.Sprintf("%d", page))
+	}
+
+	var result NFTCollectionPage
+	err := w.http.Get(ctx, "/wallets/nfts/by-contract/"+url.PathEscape(contractAddress), params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ---------------------------------------------------------------------------
 // Transaction History
 // ---------------------------------------------------------------------------
@@ -432,17 +678,60 @@ type TransactionHistory struct {
 	TotalSent     int           `json:"total_sent"`
 	TotalReceived int           `json:"total_received"`
 	Transactions  []Transaction `json:"transactions"`
+	NextCursor    *string       `json:"next_cursor,omitempty"`
 	Error         *string       `json:"error,omitempty"`
 }
 
+// TransactionHistoryOptions filters and pages transaction history
+type TransactionHistoryOptions struct {
+	Asset     string // token symbol, e.g. "USDC"
+	Direction string // "sent" or "received"
+	Category  string // e.g. "external", "erc20", "erc721"
+	FromDate  *time.Time
+	ToDate    *time.Time
+	Limit     int
+	// Cursor continues from a previous page's NextCursor. It takes
+	// precedence over Offset, which can miss transactions on busy wallets.
+	Cursor string
+	Offset int
+}
+
 // GetTransactions returns transaction history for a wallet.
 func (w *WalletClient) GetTransactions(ctx context.Context, walletID string, limit, offset int) (*TransactionHistory, error) {
+	return w.GetTransactionsWithOptions(ctx, walletID, &TransactionHistoryOptions{
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// GetTransactionsWithOptions returns filtered transaction history for a
+// wallet. Page with Cursor and the returned NextCursor.
+func (w *WalletClient) GetTransactionsWithOptions(ctx context.Context, walletID string, opts *TransactionHistoryOptions) (*TransactionHistory, error) {
 	params := url.Values{}
-	if limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", limit))
-	}
-	if offset > 0 {
-		params.Set("offset", fmt.Sprintf("%d", offset))
+	if opts != nil {
+		if opts.Asset != "" {
+			params.Set("asset", opts.Asset)
+		}
+		if opts.Direction != "" {
+			params.Set("direction", opts.Direction)
+		}
+		if opts.Category != "" {
+			params.Set("category", opts.Category)
+		}
+		if opts.FromDate != nil {
+			params.Set("from_date", opts.FromDate.Format(time.RFC3339))
+		}
+		if opts.ToDate != nil {
+			params.Set("to_date", opts.ToDate.Format(time.RFC3339))
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		} else if opts.Offset > 0 {
+			params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+		}
 	}
 
 	path := "/wallets/" + walletID + "/transactions"
@@ -458,6 +747,66 @@ func (w *WalletClient) GetTransactions(ctx context.Context, walletID string, lim
 	return &history, nil
 }
 
+// ExportTransactions writes a wallet's full transaction history matching
+// opts to out, paging through it with cursors. format is "csv" (with a
+// header row) or "jsonl" (one JSON object per line). Limit sets the page
+// size; Cursor and Offset are ignored.
+func (w *WalletClient) ExportTransactions(ctx context.Context, walletID, format string, opts *TransactionHistoryOptions, out io.Writer) (int, error) {
+	var write func(*Transaction) error
+	var flush func() error
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(out)
+		if err := cw.Write([]string{"hash", "type", "from", "to", "value", "asset", "category", "block_num", "timestamp"}); err != nil {
+			return 0, err
+		}
+		write = func(t *Transaction) error {
+			return cw.Write([]string{t.Hash, t.Type, t.From, t.To, t.Value, t.Asset, t.Category, t.BlockNum, t.Timestamp})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case "jsonl":
+		enc := json.NewEncoder(out)
+		write = func(t *Transaction) error { return enc.Encode(t) }
+		flush = func() error { return nil }
+	default:
+		return 0, NewValidationError("unsupported export format", []ValidationErrorDetail{
+			{Field: "format", Message: `must be "csv" or "jsonl"`},
+		})
+	}
+
+	page := TransactionHistoryOptions{Limit: 500}
+	if opts != nil {
+		page = *opts
+		page.Offset = 0
+		page.Cursor = ""
+		if page.Limit <= 0 {
+			page.Limit = 500
+		}
+	}
+
+	count := 0
+	for {
+		history, err := w.GetTransactionsWithOptions(ctx, walletID, &page)
+		if err != nil {
+			return count, err
+		}
+		for i := range history.Transactions {
+			if err := write(&history.Transactions[i]); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if history.NextCursor == nil || *history.NextCursor == "" || len(history.Transactions) == 0 {
+			break
+		}
+		page.Cursor = *history.NextCursor
+	}
+	return count, flush()
+}
+
 // ---------------------------------------------------------------------------
 // Users With Wallets
 // ---------------------------------------------------------------------------
@@ -582,7 +931,7 @@ func (w *WalletClient) ListTokens(ctx context.Context, opts *ListTokensOptions)
 	}
 
 	var tokens []Token
-	err := w.http.Get(ctx, path, nil, &tokens)
+	err := w.http.GetCached(ctx, path, nil, &tokens)
 	return tokens, err
 }
 
@@ -594,7 +943,7 @@ func (w *WalletClient) ListGlobalTokens(ctx context.Context, network string) ([]
 	}
 
 	var tokens []Token
-	err := w.http.Get(ctx, path, nil, &tokens)
+	err := w.http.GetCached(ctx, path, nil, &tokens)
 	return tokens, err
 }
 
@@ -620,6 +969,43 @@ func (w *WalletClient) GetTokenByContract(ctx context.Context, contractAddress,
 	return &token, nil
 }
 
+// TokenPrice is the current price of a token.
+type TokenPrice struct {
+	Symbol       string   `json:"symbol"`
+	CoingeckoID  *string  `json:"coingecko_id,omitempty"`
+	Price        float64  `json:"price"`
+	Currency     string   `json:"currency"`
+	Change24hPct *float64 `json:"change_24h_pct,omitempty"`
+	MarketCap    *float64 `json:"market_cap,omitempty"`
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+// GetTokenPrices returns current prices keyed by symbol, resolved through the
+// token registry's CoinGecko/CoinMarketCap IDs. vsCurrency defaults to "usd".
+// Symbols without a price source are omitted from the result.
+func (w *WalletClient) GetTokenPrices(ctx context.Context, symbols []string, vsCurrency string) (map[string]TokenPrice, error) {
+	if vsCurrency == "" {
+		vsCurrency = "usd"
+	}
+	params := url.Values{}
+	params.Set("symbols", strings.Join(symbols, ","))
+	params.Set("vs_currency", vsCurrency)
+
+	var result struct {
+		Prices []TokenPrice `json:"prices"`
+	}
+	err := w.http.Get(ctx, "/tokens/prices", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]TokenPrice, len(result.Prices))
+	for _, p := range result.Prices {
+		prices[p.Symbol] = p
+	}
+	return prices, nil
+}
+
 // UpdateToken updates a custom token.
 func (w *WalletClient) UpdateToken(ctx context.Context, tokenID string, req *UpdateTokenRequest) (*Token, error) {
 	var token Token
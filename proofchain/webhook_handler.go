@@ -0,0 +1,251 @@
+package proofchain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader carries the delivery signature, formatted as
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed by the secret>".
+const WebhookSignatureHeader = "X-ProofChain-Signature"
+
+// DefaultWebhookTolerance is the maximum age of a delivery accepted by
+// NewWebhookHandler.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// maxWebhookBodySize bounds the size of a delivery body read by the handler.
+const maxWebhookBodySize = 1 << 20
+
+// Webhook event types.
+const (
+	WebhookEventDocumentAttested = "document.attested"
+	WebhookEventChannelSettled   = "channel.settled"
+)
+
+// WebhookEvent is the envelope of a webhook delivery.
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt Timestamp       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookHandlers holds the callbacks used by NewWebhookHandler. A callback
+// returning an error makes the handler respond 500 so the delivery is
+// retried. Events with no matching callback are acknowledged and dropped.
+type WebhookHandlers struct {
+	OnDocumentAttested func(ctx context.Context, event *WebhookEvent, data *Event) error
+	OnChannelSettled   func(ctx context.Context, event *WebhookEvent, data *Settlement) error
+
+	OnWalletTransferReceived func(ctx context.Context, event *WebhookEvent, data *WalletTransferReceived) error
+	OnWalletNFTReceived      func(ctx context.Context, event *WebhookEvent, data *WalletNFTReceived) error
+	OnWalletSwapCompleted    func(ctx context.Context, event *WebhookEvent, data *WalletSwapCompleted) error
+
+	OnQuestStepCompleted func(ctx context.Context, event *WebhookEvent, data *QuestStepCompleted) error
+	OnQuestCompleted     func(ctx context.Context, event *WebhookEvent, data *QuestCompleted) error
+
+	// On maps other event types to callbacks receiving the raw envelope.
+	On map[string]func(ctx context.Context, event *WebhookEvent) error
+	// Default handles event types with no other callback.
+	Default func(ctx context.Context, event *WebhookEvent) error
+
+	// Tolerance is the maximum age of a delivery (default
+	// DefaultWebhookTolerance). Older deliveries are rejected as replays.
+	Tolerance time.Duration
+
+	// OnError, if set, is called with every delivery the handler rejects or
+	// fails to process. Responses carry only the status text, so this is
+	// where the cause is reported.
+	OnError func(r *http.Request, err error)
+}
+
+// NewWebhookHandler returns an http.Handler that receives ProofChain webhook
+// deliveries. It verifies the signature against secret, rejects deliveries
+// outside the timestamp tolerance, decodes the payload and dispatches it to
+// the matching callback. It responds 401 to deliveries that fail
+// verification, 400 to malformed payloads and 500 when a callback fails.
+//
+// Example:
+//
+//	http.Handle("/webhook", proofchain.NewWebhookHandler(secret, proofchain.WebhookHandlers{
+//		OnChannelSettled: func(ctx context.Context, e *proofchain.WebhookEvent, s *proofchain.Settlement) error {
+//			log.Printf("channel %s settled in %s", s.ChannelID, s.TxHash)
+//			return nil
+//		},
+//	}))
+func NewWebhookHandler(secret string, handlers WebhookHandlers) http.Handler {
+	tolerance := handlers.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+
+	// fail responds with the status text only, so callback and
+	// verification details are not sent back to the caller
+	fail := func(w http.ResponseWriter, r *http.Request, status int, err error) {
+		if handlers.OnError != nil {
+			handlers.OnError(r, err)
+		}
+		http.Error(w, http.StatusText(status), status)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+		if err != nil {
+			fail(w, r, http.StatusBadRequest, NewNetworkError(err))
+			return
+		}
+		if err := VerifyWebhookSignature(secret, r.Header.Get(WebhookSignatureHeader), body, tolerance); err != nil {
+			fail(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		var event WebhookEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			fail(w, r, http.StatusBadRequest, NewValidationError("invalid webhook payload: "+err.Error(), nil))
+			return
+		}
+
+		if err := handlers.dispatch(r.Context(), &event); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				fail(w, r, http.StatusBadRequest, err)
+			} else {
+				fail(w, r, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// decodeWebhookData decodes the data of event into v.
+func decodeWebhookData(event *WebhookEvent, v interface{}) error {
+	if err := json.Unmarshal(event.Data, v); err != nil {
+		return NewValidationError(fmt.Sprintf("invalid %s data: %v", event.Type, err), nil)
+	}
+	return nil
+}
+
+func (h *WebhookHandlers) dispatch(ctx context.Context, event *WebhookEvent) error {
+	switch {
+	case event.Type == WebhookEventDocumentAttested && h.OnDocumentAttested != nil:
+		var data Event
+		if err := decodeWebhookData(event, &data); err != nil {
+			return err
+		}
+		return h.OnDocumentAttested(ctx, event, &data)
+	case event.Type == WebhookEventChannelSettled && h.OnChannelSettled != nil:
+		var data Settlement
+		if err := decodeWebhookData(event, &data); err != nil {
+			return err
+		}
+		return h.OnChannelSettled(ctx, event, &data)
+	case event.Type == WebhookEventWalletTransferReceived && h.OnWalletTransferReceived != nil:
+		var data WalletTransferReceived
+		if err := decodeWebhookData(event, &data); err != nil {
+			return err
+		}
+		return h.OnWalletTransferReceived(ctx, event, &data)
+	case event.Type == WebhookEventWalletNFTReceived && h.OnWalletNFTReceived != nil:
+		var data WalletNFTReceived
+		if err := decodeWebhookData(event, &data); err != nil {
+			return err
+		}
+		return h.OnWalletNFTReceived(ctx, event, &data)
+	case event.Type == WebhookEventWalletSwapCompleted && h.OnWalletSwapCompleted != nil:
+		var data WalletSwapCompleted
+		if err := decodeWebhookData(event, &data); err != nil {
+			return err
+		}
+		return h.OnWalletSwapCompleted(ctx, event, &data)
+	case event.Type == WebhookEventQuestStepCompleted && h.OnQuestStepCompleted != nil:
+		var data QuestStepCompleted
+		if err := decodeWebhookData(event, &data); err != nil {
+			return err
+		}
+		return h.OnQuestStepCompleted(ctx, event, &data)
+	case event.Type == WebhookEventQuestCompleted && h.OnQuestCompleted != nil:
+		var data QuestCompleted
+		if err := decodeWebhookData(event, &data); err != nil {
+			return err
+		}
+		return h.OnQuestCompleted(ctx, event, &data)
+	}
+	if fn, ok := h.On[event.Type]; ok {
+		return fn(ctx, event)
+	}
+	if h.Default != nil {
+		return h.Default(ctx, event)
+	}
+	return nil
+}
+
+// VerifyWebhookSignature checks a WebhookSignatureHeader value against body.
+// Deliveries signed more than tolerance ago (or in the future) are rejected.
+// A tolerance of zero disables the timestamp check. Failures are returned as
+// an *AuthenticationError.
+func VerifyWebhookSignature(secret, header string, body []byte, tolerance time.Duration) error {
+	if header == "" {
+		return NewAuthenticationError("missing webhook signature")
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return NewAuthenticationError("malformed webhook signature")
+	}
+
+	if tolerance > 0 {
+		sec, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return NewAuthenticationError("malformed webhook signature timestamp")
+		}
+		age := time.Since(time.Unix(sec, 0))
+		if age > tolerance || age < -tolerance {
+			return NewAuthenticationError("webhook signature timestamp outside tolerance")
+		}
+	}
+
+	expected := signWebhookPayload(secret, timestamp, body)
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+	return NewAuthenticationError("webhook signature mismatch")
+}
+
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
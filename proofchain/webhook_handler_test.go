@@ -0,0 +1,101 @@
+package proofchain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "whsec_test"
+
+func signedWebhookRequest(body string, at time.Time, secret string) *http.Request {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(WebhookSignatureHeader, "t="+ts+",v1="+signWebhookPayload(secret, ts, []byte(body)))
+	return req
+}
+
+const settledDelivery = `{"id":"evt_1","type":"channel.settled","created_at":"2026-10-16T10:00:00Z","data":{"channel_id":"ch_1","tx_hash":"0xabc"}}`
+
+func TestWebhookHandlerDispatches(t *testing.T) {
+	var got *Settlement
+	h := NewWebhookHandler(testWebhookSecret, WebhookHandlers{
+		OnChannelSettled: func(ctx context.Context, e *WebhookEvent, s *Settlement) error {
+			got = s
+			return nil
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedWebhookRequest(settledDelivery, time.Now(), testWebhookSecret))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", rec.Code)
+	}
+	if got == nil || got.ChannelID != "ch_1" || got.TxHash != "0xabc" {
+		t.Fatalf("OnChannelSettled got %+v", got)
+	}
+}
+
+func TestWebhookHandlerRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"wrong secret", signedWebhookRequest(settledDelivery, time.Now(), "other"), http.StatusUnauthorized},
+		{"stale", signedWebhookRequest(settledDelivery, time.Now().Add(-time.Hour), testWebhookSecret), http.StatusUnauthorized},
+		{"unsigned", httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(settledDelivery)), http.StatusUnauthorized},
+		{"malformed data", signedWebhookRequest(`{"id":"evt_2","type":"channel.settled","data":{"event_count":"many"}}`, time.Now(), testWebhookSecret), http.StatusBadRequest},
+		{"method", httptest.NewRequest(http.MethodGet, "/webhook", nil), http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported error
+			h := NewWebhookHandler(testWebhookSecret, WebhookHandlers{
+				OnChannelSettled: func(context.Context, *WebhookEvent, *Settlement) error {
+					t.Fatal("callback called for a rejected delivery")
+					return nil
+				},
+				OnError: func(r *http.Request, err error) { reported = err },
+			})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusUnauthorized {
+				var authErr *AuthenticationError
+				if !errors.As(reported, &authErr) {
+					t.Fatalf("OnError got %v, want an *AuthenticationError", reported)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerCallbackErrorNotExposed(t *testing.T) {
+	var reported error
+	h := NewWebhookHandler(testWebhookSecret, WebhookHandlers{
+		OnChannelSettled: func(context.Context, *WebhookEvent, *Settlement) error {
+			return errors.New("db password rejected for host 10.0.0.5")
+		},
+		OnError: func(r *http.Request, err error) { reported = err },
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedWebhookRequest(settledDelivery, time.Now(), testWebhookSecret))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "10.0.0.5") {
+		t.Fatalf("response exposes the callback error: %q", rec.Body.String())
+	}
+	if reported == nil || !strings.Contains(reported.Error(), "10.0.0.5") {
+		t.Fatalf("OnError got %v, want the callback error", reported)
+	}
+}